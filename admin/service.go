@@ -111,6 +111,8 @@ func (service *AdminServiceImpl) SignInAdmin(ctx context.Context, request AdminL
 		panic(exception.NewBadRequestError("invalid email or password"))
 	}
 
+	user.CheckSignIn(ctx, tx, service.userRepository, request.Email)
+
 	admin := service.userRepository.FindOne(ctx, tx, 0, request.Email)
 
 	accessTokenExpired := helpers.AccessTokenDuration(appEnv)
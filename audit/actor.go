@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type Actor struct {
+	Id         int
+	Ip         string
+	User_Agent string
+}
+
+type actorKey struct{}
+
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+func ActorFromContext(ctx context.Context) Actor {
+	actor, _ := ctx.Value(actorKey{}).(Actor)
+	return actor
+}
+
+func ActorFromRequest(request *http.Request, userId int) Actor {
+	return Actor{
+		Id:         userId,
+		Ip:         clientIp(request),
+		User_Agent: request.UserAgent(),
+	}
+}
+
+// clientIp only believes X-Forwarded-For when the request came through one of
+// the proxies in TRUSTED_PROXIES. The header is then read from the right,
+// skipping the trusted hops, so a client cannot forge the address by sending
+// the header itself.
+func clientIp(request *http.Request) string {
+	ip := request.RemoteAddr
+	if host, _, err := net.SplitHostPort(request.RemoteAddr); err == nil {
+		ip = host
+	}
+
+	trusted := trustedProxies()
+
+	if !trusted[ip] {
+		return ip
+	}
+
+	forwardedFor := strings.Split(request.Header.Get("X-Forwarded-For"), ",")
+
+	for i := len(forwardedFor) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(forwardedFor[i])
+
+		if hop != "" && !trusted[hop] {
+			return hop
+		}
+	}
+
+	return ip
+}
+
+func trustedProxies() map[string]bool {
+	proxies := map[string]bool{}
+
+	for _, proxy := range strings.Split(helpers.NewEnv().App.TrustedProxies, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies[proxy] = true
+		}
+	}
+
+	return proxies
+}
@@ -0,0 +1,12 @@
+package audit
+
+import "time"
+
+type AuditLogFilterRequest struct {
+	Actor_Id     int
+	Action       string
+	Target_Type  string
+	Target_Id    int
+	Created_From time.Time
+	Created_To   time.Time
+}
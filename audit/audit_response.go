@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditLogResponse struct {
+	Id          int             `json:"id"`
+	Actor_Id    int             `json:"actor_id"`
+	Action      string          `json:"action"`
+	Target_Type string          `json:"target_type"`
+	Target_Id   int             `json:"target_id"`
+	Before      json.RawMessage `json:"before"`
+	After       json.RawMessage `json:"after"`
+	Ip          string          `json:"ip"`
+	User_Agent  string          `json:"user_agent"`
+	Created_At  time.Time       `json:"created_at"`
+}
+
+func ToAuditLogResponse(auditLog AuditLog) AuditLogResponse {
+	return AuditLogResponse{
+		Id:          auditLog.Id,
+		Actor_Id:    auditLog.Actor_Id,
+		Action:      auditLog.Action,
+		Target_Type: auditLog.Target_Type,
+		Target_Id:   auditLog.Target_Id,
+		Before:      toRawMessage(auditLog.Before),
+		After:       toRawMessage(auditLog.After),
+		Ip:          auditLog.Ip,
+		User_Agent:  auditLog.User_Agent,
+		Created_At:  auditLog.Created_At,
+	}
+}
+
+func toRawMessage(snapshot string) json.RawMessage {
+	if snapshot == "" {
+		return json.RawMessage("null")
+	}
+	return json.RawMessage(snapshot)
+}
@@ -0,0 +1,130 @@
+package audit
+
+import (
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/julienschmidt/httprouter"
+)
+
+type AuditController interface {
+	FindAllAuditLogHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ExportAuditLogHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
+
+type AuditControllerImpl struct {
+	service AuditService
+}
+
+func NewAuditController(service AuditService) AuditController {
+	return &AuditControllerImpl{
+		service: service,
+	}
+}
+
+func (controller *AuditControllerImpl) FindAllAuditLogHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	isAdmin := helpers.IsAdmin(request)
+	limit, offset := helpers.GetLimitOffset(request)
+
+	filter := auditLogFilterFromRequest(request)
+
+	auditLogs, countAuditLogs := controller.service.FindAll(request.Context(), filter, limit, offset, isAdmin)
+
+	auditLogResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"audit_logs": auditLogs,
+			"limit":      limit,
+			"offset":     offset,
+			"total":      countAuditLogs,
+		},
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, auditLogResponse)
+}
+
+func (controller *AuditControllerImpl) ExportAuditLogHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	isAdmin := helpers.IsAdmin(request)
+
+	filter := auditLogFilterFromRequest(request)
+
+	auditLogs := controller.service.Export(request.Context(), filter, isAdmin)
+
+	writer.Header().Set("Content-Type", "text/csv")
+	writer.Header().Set("Content-Disposition", `attachment; filename="audit-log.csv"`)
+	writer.WriteHeader(http.StatusOK)
+
+	csvWriter := csv.NewWriter(writer)
+
+	err := csvWriter.Write([]string{"id", "actor_id", "action", "target_type", "target_id", "before", "after", "ip", "user_agent", "created_at"})
+	helpers.PanicError(err, "failed to write audit log csv header")
+
+	for _, auditLog := range auditLogs {
+		err := csvWriter.Write([]string{
+			strconv.Itoa(auditLog.Id),
+			strconv.Itoa(auditLog.Actor_Id),
+			auditLog.Action,
+			auditLog.Target_Type,
+			strconv.Itoa(auditLog.Target_Id),
+			auditLog.Before,
+			auditLog.After,
+			auditLog.Ip,
+			auditLog.User_Agent,
+			auditLog.Created_At.UTC().Format(time.RFC3339),
+		})
+		helpers.PanicError(err, "failed to write audit log csv row")
+	}
+
+	csvWriter.Flush()
+	helpers.PanicError(csvWriter.Error(), "failed to flush audit log csv")
+}
+
+func auditLogFilterFromRequest(request *http.Request) AuditLogFilterRequest {
+	query := request.URL.Query()
+
+	actorId, err := parseIdQuery(query.Get("actor_id"))
+	if err != nil {
+		panic(exception.NewBadRequestError("invalid actor_id"))
+	}
+
+	targetId, err := parseIdQuery(query.Get("target_id"))
+	if err != nil {
+		panic(exception.NewBadRequestError("invalid target_id"))
+	}
+
+	createdFrom, err := helpers.ParseDateQuery(query.Get("created_from"))
+	if err != nil {
+		panic(exception.NewBadRequestError("invalid created_from, use YYYY-MM-DD"))
+	}
+
+	createdTo, err := helpers.ParseDateQuery(query.Get("created_to"))
+	if err != nil {
+		panic(exception.NewBadRequestError("invalid created_to, use YYYY-MM-DD"))
+	}
+
+	if !createdTo.IsZero() {
+		createdTo = createdTo.AddDate(0, 0, 1)
+	}
+
+	return AuditLogFilterRequest{
+		Actor_Id:     actorId,
+		Action:       query.Get("action"),
+		Target_Type:  query.Get("target_type"),
+		Target_Id:    targetId,
+		Created_From: createdFrom,
+		Created_To:   createdTo,
+	}
+}
+
+func parseIdQuery(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+func NewEntry(ctx context.Context, action, targetType string, targetId int, before, after interface{}) AuditLog {
+	actor := ActorFromContext(ctx)
+
+	return AuditLog{
+		Actor_Id:    actor.Id,
+		Action:      action,
+		Target_Type: targetType,
+		Target_Id:   targetId,
+		Before:      marshalSnapshot(before),
+		After:       marshalSnapshot(after),
+		Ip:          actor.Ip,
+		User_Agent:  actor.User_Agent,
+	}
+}
+
+func marshalSnapshot(snapshot interface{}) string {
+	if snapshot == nil {
+		return ""
+	}
+
+	data, err := json.Marshal(snapshot)
+	helpers.PanicError(err, "failed to marshal audit snapshot")
+
+	return string(data)
+}
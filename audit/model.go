@@ -0,0 +1,60 @@
+package audit
+
+import "time"
+
+const (
+	ActionRoleCreate           = "role.create"
+	ActionRoleUpdate           = "role.update"
+	ActionRoleDelete           = "role.delete"
+	ActionCategoryCreate       = "category.create"
+	ActionCategoryUpdate       = "category.update"
+	ActionCategoryDelete       = "category.delete"
+	ActionUserDelete           = "user.delete"
+	ActionUserPurge            = "user.purge"
+	ActionUserRoleUpdate       = "user.role_update"
+	ActionUserSuspend          = "user.suspend"
+	ActionUserSuspendLift      = "user.suspension_lift"
+	ActionReportResolve        = "report.resolve"
+	ActionPostApprove          = "post.approve"
+	ActionPostReject           = "post.reject"
+	ActionPostDelete           = "post.delete"
+	ActionPostRestore          = "post.restore"
+	ActionPostUnpublish        = "post.unpublish"
+	ActionPostRecategorize     = "post.recategorize"
+	ActionFilterRuleCreate     = "filter_rule.create"
+	ActionFilterRuleUpdate     = "filter_rule.update"
+	ActionFilterRuleDelete     = "filter_rule.delete"
+	ActionCommentModerate      = "comment.moderate"
+	ActionImpersonationStart   = "impersonation.start"
+	ActionImpersonationEnd     = "impersonation.end"
+	ActionImpersonationRequest = "impersonation.request"
+	ActionRevisionPolicyUpdate = "revision_policy.update"
+	ActionTagRename            = "tag.rename"
+	ActionTagMerge             = "tag.merge"
+)
+
+const (
+	TargetRole           = "role"
+	TargetCategory       = "category"
+	TargetUser           = "user"
+	TargetReport         = "report"
+	TargetPost           = "post"
+	TargetFilterRule     = "filter_rule"
+	TargetComment        = "comment"
+	TargetImpersonation  = "impersonation"
+	TargetRevisionPolicy = "revision_policy"
+	TargetTag            = "tag"
+)
+
+type AuditLog struct {
+	Id          int
+	Actor_Id    int
+	Action      string
+	Target_Type string
+	Target_Id   int
+	Before      string
+	After       string
+	Ip          string
+	User_Agent  string
+	Created_At  time.Time
+}
@@ -0,0 +1,127 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type AuditRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, auditLog AuditLog)
+	FindAll(ctx context.Context, tx *sql.Tx, filter AuditLogFilterRequest, limit, offset int) []AuditLog
+	CountAll(ctx context.Context, tx *sql.Tx, filter AuditLogFilterRequest) int
+}
+
+type AuditRepositoryImpl struct {
+}
+
+func NewAuditRepository() AuditRepository {
+	return &AuditRepositoryImpl{}
+}
+
+func (repository *AuditRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, auditLog AuditLog) {
+	query := "INSERT INTO audit_log(actor_id, action, target_type, target_id, before_data, after_data, ip, user_agent) VALUES (?, ?, ?, ?, ?, ?, ?, ?)"
+
+	before := sql.NullString{String: auditLog.Before, Valid: auditLog.Before != ""}
+	after := sql.NullString{String: auditLog.After, Valid: auditLog.After != ""}
+
+	_, err := tx.ExecContext(ctx, query, auditLog.Actor_Id, auditLog.Action, auditLog.Target_Type, auditLog.Target_Id, before, after, auditLog.Ip, auditLog.User_Agent)
+	helpers.PanicError(err, "failed to exec query insert audit log")
+}
+
+func auditLogFilterQuery(filter AuditLogFilterRequest) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.Actor_Id > 0 {
+		conditions = append(conditions, "actor_id = ?")
+		args = append(args, filter.Actor_Id)
+	}
+
+	if filter.Action != "" {
+		conditions = append(conditions, "action = ?")
+		args = append(args, filter.Action)
+	}
+
+	if filter.Target_Type != "" {
+		conditions = append(conditions, "target_type = ?")
+		args = append(args, filter.Target_Type)
+	}
+
+	if filter.Target_Id > 0 {
+		conditions = append(conditions, "target_id = ?")
+		args = append(args, filter.Target_Id)
+	}
+
+	if !filter.Created_From.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.Created_From)
+	}
+
+	if !filter.Created_To.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, filter.Created_To)
+	}
+
+	if len(conditions) == 0 {
+		return "true", args
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func (repository *AuditRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, filter AuditLogFilterRequest, limit, offset int) []AuditLog {
+	where, args := auditLogFilterQuery(filter)
+
+	query := "SELECT id, actor_id, action, target_type, target_id, before_data, after_data, ip, user_agent, created_at FROM audit_log WHERE " + where + " ORDER BY id DESC"
+
+	if limit > 0 {
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, limit, offset)
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	helpers.PanicError(err, "failed to query audit logs")
+
+	defer rows.Close()
+
+	var auditLogs []AuditLog
+
+	for rows.Next() {
+		var auditLog AuditLog
+		var before sql.NullString
+		var after sql.NullString
+
+		err := rows.Scan(&auditLog.Id, &auditLog.Actor_Id, &auditLog.Action, &auditLog.Target_Type, &auditLog.Target_Id, &before, &after, &auditLog.Ip, &auditLog.User_Agent, &auditLog.Created_At)
+		helpers.PanicError(err, "failed to scan audit log")
+
+		auditLog.Before = before.String
+		auditLog.After = after.String
+
+		auditLogs = append(auditLogs, auditLog)
+	}
+
+	return auditLogs
+}
+
+func (repository *AuditRepositoryImpl) CountAll(ctx context.Context, tx *sql.Tx, filter AuditLogFilterRequest) int {
+	where, args := auditLogFilterQuery(filter)
+
+	query := "SELECT COUNT(*) FROM audit_log WHERE " + where
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	helpers.PanicError(err, "failed to query count audit logs")
+
+	defer rows.Close()
+
+	var countAuditLogs int
+
+	if rows.Next() {
+		err := rows.Scan(&countAuditLogs)
+		helpers.PanicError(err, "failed to scan count audit logs")
+	}
+
+	return countAuditLogs
+}
@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type AuditService interface {
+	FindAll(ctx context.Context, filter AuditLogFilterRequest, limit, offset int, isAdmin bool) ([]AuditLogResponse, int)
+	Export(ctx context.Context, filter AuditLogFilterRequest, isAdmin bool) []AuditLog
+}
+
+type AuditServiceImpl struct {
+	repository AuditRepository
+	db         *sql.DB
+}
+
+func NewAuditService(repository AuditRepository, db *sql.DB) AuditService {
+	return &AuditServiceImpl{
+		repository: repository,
+		db:         db,
+	}
+}
+
+func (service *AuditServiceImpl) FindAll(ctx context.Context, filter AuditLogFilterRequest, limit, offset int, isAdmin bool) ([]AuditLogResponse, int) {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can get audit logs"))
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	auditLogs := service.repository.FindAll(ctx, tx, filter, limit, offset)
+	countAuditLogs := service.repository.CountAll(ctx, tx, filter)
+
+	var auditLogResponses []AuditLogResponse
+
+	for _, auditLog := range auditLogs {
+		auditLogResponses = append(auditLogResponses, ToAuditLogResponse(auditLog))
+	}
+
+	return auditLogResponses, countAuditLogs
+}
+
+func (service *AuditServiceImpl) Export(ctx context.Context, filter AuditLogFilterRequest, isAdmin bool) []AuditLog {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can export audit logs"))
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	return service.repository.FindAll(ctx, tx, filter, 0, 0)
+}
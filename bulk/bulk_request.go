@@ -0,0 +1,48 @@
+package bulk
+
+import "time"
+
+type PostBulkFilter struct {
+	User_Id      int       `json:"user_id"`
+	Category_Id  int       `json:"category_id"`
+	Query        string    `json:"query"`
+	Created_From time.Time `json:"created_from"`
+	Created_To   time.Time `json:"created_to"`
+}
+
+func (filter PostBulkFilter) IsEmpty() bool {
+	return filter == PostBulkFilter{}
+}
+
+type PostBulkRequest struct {
+	Admin_Id    int            `json:"admin_id" validate:"required"`
+	Action      string         `json:"action" validate:"required,oneof=soft_delete restore unpublish recategorize"`
+	Ids         []int          `json:"ids" validate:"max=1000,dive,required"`
+	Filter      PostBulkFilter `json:"filter"`
+	Category_Id int            `json:"category_id" validate:"required_if=Action recategorize"`
+	Dry_Run     bool           `json:"dry_run"`
+	Atomic      bool           `json:"atomic"`
+}
+
+type UserBulkFilter struct {
+	Role         string    `json:"role"`
+	Query        string    `json:"query"`
+	Created_From time.Time `json:"created_from"`
+	Created_To   time.Time `json:"created_to"`
+}
+
+func (filter UserBulkFilter) IsEmpty() bool {
+	return filter == UserBulkFilter{}
+}
+
+type UserBulkRequest struct {
+	Admin_Id       int            `json:"admin_id" validate:"required"`
+	Action         string         `json:"action" validate:"required,oneof=suspend delete"`
+	Ids            []int          `json:"ids" validate:"max=1000,dive,required"`
+	Filter         UserBulkFilter `json:"filter"`
+	Reason         string         `json:"reason" validate:"required_if=Action suspend,max=500"`
+	Duration_Hours int            `json:"duration_hours" validate:"omitempty,min=1"`
+	Permanent      bool           `json:"permanent"`
+	Dry_Run        bool           `json:"dry_run"`
+	Atomic         bool           `json:"atomic"`
+}
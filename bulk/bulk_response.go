@@ -0,0 +1,35 @@
+package bulk
+
+type BulkItemResult struct {
+	Id     int    `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+type BulkResponse struct {
+	Action    string           `json:"action"`
+	Dry_Run   bool             `json:"dry_run"`
+	Atomic    bool             `json:"atomic"`
+	Matched   int              `json:"matched"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkItemResult `json:"results"`
+}
+
+type PostTargetResponse struct {
+	Id          int  `json:"id"`
+	User_Id     int  `json:"user_id"`
+	Category_Id int  `json:"category_id"`
+	Published   bool `json:"published"`
+	Deleted     bool `json:"deleted"`
+}
+
+func ToPostTargetResponse(post PostTarget) PostTargetResponse {
+	return PostTargetResponse{
+		Id:          post.Id,
+		User_Id:     post.User_Id,
+		Category_Id: post.Category_Id,
+		Published:   post.Published,
+		Deleted:     post.Deleted,
+	}
+}
@@ -0,0 +1,61 @@
+package bulk
+
+import (
+	"net/http"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/julienschmidt/httprouter"
+)
+
+type BulkController interface {
+	BulkPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	BulkUserHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
+
+type BulkControllerImpl struct {
+	service BulkService
+}
+
+func NewBulkController(service BulkService) BulkController {
+	return &BulkControllerImpl{
+		service: service,
+	}
+}
+
+func (controller *BulkControllerImpl) BulkPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	var bulkRequest PostBulkRequest
+	helpers.DecodeJSONFromRequest(request, &bulkRequest)
+
+	bulkRequest.Admin_Id = helpers.GetUserId(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	result := controller.service.Posts(request.Context(), bulkRequest, isAdmin)
+
+	bulkResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, bulkResponse)
+}
+
+func (controller *BulkControllerImpl) BulkUserHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	var bulkRequest UserBulkRequest
+	helpers.DecodeJSONFromRequest(request, &bulkRequest)
+
+	bulkRequest.Admin_Id = helpers.GetUserId(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	result := controller.service.Users(request.Context(), bulkRequest, isAdmin)
+
+	bulkResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, bulkResponse)
+}
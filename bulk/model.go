@@ -0,0 +1,37 @@
+package bulk
+
+import "time"
+
+const (
+	ActionSoftDelete   = "soft_delete"
+	ActionRestore      = "restore"
+	ActionUnpublish    = "unpublish"
+	ActionRecategorize = "recategorize"
+	ActionSuspend      = "suspend"
+	ActionDelete       = "delete"
+)
+
+const (
+	StatusApplied    = "applied"
+	StatusFailed     = "failed"
+	StatusRolledBack = "rolled_back"
+)
+
+// MaxTargets caps how many rows a single run may touch, so a loose filter
+// cannot wipe out the whole site by accident.
+const MaxTargets = 1000
+
+type PostTarget struct {
+	Id          int
+	User_Id     int
+	Category_Id int
+	Published   bool
+	Deleted     bool
+	Deleted_At  time.Time
+}
+
+type UserTarget struct {
+	Id        int
+	Username  string
+	Role_Name string
+}
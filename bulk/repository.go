@@ -0,0 +1,213 @@
+package bulk
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type BulkRepository interface {
+	FindPostIds(ctx context.Context, tx *sql.Tx, ids []int, filter PostBulkFilter) []int
+	FindPostTarget(ctx context.Context, tx *sql.Tx, postId int) PostTarget
+	SoftDeletePost(ctx context.Context, tx *sql.Tx, postId int)
+	RestorePost(ctx context.Context, tx *sql.Tx, postId int)
+	UnpublishPost(ctx context.Context, tx *sql.Tx, postId int)
+	UpdatePostCategory(ctx context.Context, tx *sql.Tx, postId, categoryId int)
+	CategoryExists(ctx context.Context, tx *sql.Tx, categoryId int) bool
+	FindUserIds(ctx context.Context, tx *sql.Tx, ids []int, filter UserBulkFilter) []int
+	FindUserTarget(ctx context.Context, tx *sql.Tx, userId int) UserTarget
+}
+
+type BulkRepositoryImpl struct {
+}
+
+func NewBulkRepository() BulkRepository {
+	return &BulkRepositoryImpl{}
+}
+
+// FindPostIds includes soft-deleted posts so they can be restored. One id past
+// MaxTargets is fetched so the service can tell an oversized run apart.
+func (repository *BulkRepositoryImpl) FindPostIds(ctx context.Context, tx *sql.Tx, ids []int, filter PostBulkFilter) []int {
+	conditions, args := idsQuery("p.id", ids)
+
+	if filter.User_Id != 0 {
+		conditions = append(conditions, "p.user_id = ?")
+		args = append(args, filter.User_Id)
+	}
+
+	if filter.Category_Id != 0 {
+		conditions = append(conditions, "p.category_id = ?")
+		args = append(args, filter.Category_Id)
+	}
+
+	if filter.Query != "" {
+		conditions = append(conditions, "p.title LIKE ?")
+		args = append(args, helpers.LikePattern(filter.Query))
+	}
+
+	if !filter.Created_From.IsZero() {
+		conditions = append(conditions, "p.created_at >= ?")
+		args = append(args, filter.Created_From)
+	}
+
+	if !filter.Created_To.IsZero() {
+		conditions = append(conditions, "p.created_at < ?")
+		args = append(args, filter.Created_To)
+	}
+
+	query := "SELECT p.id FROM post p WHERE " + strings.Join(conditions, " AND ") + " ORDER BY p.id LIMIT ?"
+	args = append(args, MaxTargets+1)
+
+	return repository.findIds(ctx, tx, query, args, "post")
+}
+
+func (repository *BulkRepositoryImpl) FindPostTarget(ctx context.Context, tx *sql.Tx, postId int) PostTarget {
+	query := "SELECT id, user_id, category_id, is_published, is_deleted, deleted_at FROM post WHERE id = ? FOR UPDATE"
+
+	rows, err := tx.QueryContext(ctx, query, postId)
+	helpers.PanicError(err, "failed to query bulk post")
+
+	defer rows.Close()
+
+	var post PostTarget
+	var deletedAt sql.NullTime
+
+	if rows.Next() {
+		err := rows.Scan(&post.Id, &post.User_Id, &post.Category_Id, &post.Published, &post.Deleted, &deletedAt)
+		helpers.PanicError(err, "failed to scan bulk post")
+
+		if deletedAt.Valid {
+			post.Deleted_At = deletedAt.Time
+		} else {
+			post.Deleted_At = time.Time{}
+		}
+	} else {
+		panic(exception.NewNotFoundError("post not found"))
+	}
+
+	return post
+}
+
+func (repository *BulkRepositoryImpl) SoftDeletePost(ctx context.Context, tx *sql.Tx, postId int) {
+	query := "UPDATE post SET is_deleted = true, deleted_at = NOW() WHERE id = ?"
+	_, err := tx.ExecContext(ctx, query, postId)
+	helpers.PanicError(err, "failed to exec query bulk delete post")
+}
+
+func (repository *BulkRepositoryImpl) RestorePost(ctx context.Context, tx *sql.Tx, postId int) {
+	query := "UPDATE post SET is_deleted = false, deleted_at = NULL WHERE id = ?"
+	_, err := tx.ExecContext(ctx, query, postId)
+	helpers.PanicError(err, "failed to exec query bulk restore post")
+}
+
+func (repository *BulkRepositoryImpl) UnpublishPost(ctx context.Context, tx *sql.Tx, postId int) {
+	query := "UPDATE post SET is_published = false, published_at = NULL WHERE id = ?"
+	_, err := tx.ExecContext(ctx, query, postId)
+	helpers.PanicError(err, "failed to exec query bulk unpublish post")
+}
+
+func (repository *BulkRepositoryImpl) UpdatePostCategory(ctx context.Context, tx *sql.Tx, postId, categoryId int) {
+	query := "UPDATE post SET category_id = ? WHERE id = ?"
+	_, err := tx.ExecContext(ctx, query, categoryId, postId)
+	helpers.PanicError(err, "failed to exec query bulk recategorize post")
+}
+
+func (repository *BulkRepositoryImpl) CategoryExists(ctx context.Context, tx *sql.Tx, categoryId int) bool {
+	query := "SELECT EXISTS(SELECT 1 FROM category WHERE id = ?)"
+
+	var exists bool
+	err := tx.QueryRowContext(ctx, query, categoryId).Scan(&exists)
+	helpers.PanicError(err, "failed to query bulk category")
+
+	return exists
+}
+
+func (repository *BulkRepositoryImpl) FindUserIds(ctx context.Context, tx *sql.Tx, ids []int, filter UserBulkFilter) []int {
+	conditions, args := idsQuery("u.id", ids)
+	conditions = append(conditions, "u.is_deleted = false")
+
+	if filter.Role != "" {
+		conditions = append(conditions, "u.role_id = (SELECT r.id FROM role r WHERE r.name = ?)")
+		args = append(args, filter.Role)
+	}
+
+	if filter.Query != "" {
+		keyword := helpers.LikePattern(filter.Query)
+		conditions = append(conditions, "(u.username LIKE ? OR u.email LIKE ?)")
+		args = append(args, keyword, keyword)
+	}
+
+	if !filter.Created_From.IsZero() {
+		conditions = append(conditions, "u.created_at >= ?")
+		args = append(args, filter.Created_From)
+	}
+
+	if !filter.Created_To.IsZero() {
+		conditions = append(conditions, "u.created_at < ?")
+		args = append(args, filter.Created_To)
+	}
+
+	query := "SELECT u.id FROM user u WHERE " + strings.Join(conditions, " AND ") + " ORDER BY u.id LIMIT ?"
+	args = append(args, MaxTargets+1)
+
+	return repository.findIds(ctx, tx, query, args, "user")
+}
+
+func (repository *BulkRepositoryImpl) FindUserTarget(ctx context.Context, tx *sql.Tx, userId int) UserTarget {
+	query := "SELECT u.id, u.username, r.name FROM user u JOIN role r ON r.id = u.role_id WHERE u.id = ? AND u.is_deleted = false FOR UPDATE"
+
+	rows, err := tx.QueryContext(ctx, query, userId)
+	helpers.PanicError(err, "failed to query bulk user")
+
+	defer rows.Close()
+
+	var user UserTarget
+
+	if rows.Next() {
+		err := rows.Scan(&user.Id, &user.Username, &user.Role_Name)
+		helpers.PanicError(err, "failed to scan bulk user")
+	} else {
+		panic(exception.NewNotFoundError("user not found"))
+	}
+
+	return user
+}
+
+func (repository *BulkRepositoryImpl) findIds(ctx context.Context, tx *sql.Tx, query string, args []interface{}, target string) []int {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	helpers.PanicError(err, "failed to query bulk "+target+" ids")
+
+	defer rows.Close()
+
+	var ids []int
+
+	for rows.Next() {
+		var id int
+		err := rows.Scan(&id)
+		helpers.PanicError(err, "failed to scan bulk "+target+" id")
+
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+func idsQuery(column string, ids []int) ([]string, []interface{}) {
+	if len(ids) == 0 {
+		return []string{"true"}, nil
+	}
+
+	placeholders := make([]string, len(ids))
+	args := make([]interface{}, len(ids))
+
+	for i, id := range ids {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	return []string{column + " IN (" + strings.Join(placeholders, ", ") + ")"}, args
+}
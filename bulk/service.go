@@ -0,0 +1,292 @@
+package bulk
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/suspension"
+	"github.com/hutamatr/GoBlogify/user"
+)
+
+type BulkService interface {
+	Posts(ctx context.Context, request PostBulkRequest, isAdmin bool) BulkResponse
+	Users(ctx context.Context, request UserBulkRequest, isAdmin bool) BulkResponse
+}
+
+type BulkServiceImpl struct {
+	repository           BulkRepository
+	userRepository       user.UserRepository
+	suspensionRepository suspension.SuspensionRepository
+	auditRepository      audit.AuditRepository
+	db                   *sql.DB
+	validator            *validator.Validate
+}
+
+func NewBulkService(repository BulkRepository, userRepository user.UserRepository, suspensionRepository suspension.SuspensionRepository, auditRepository audit.AuditRepository, db *sql.DB, validator *validator.Validate) BulkService {
+	return &BulkServiceImpl{
+		repository:           repository,
+		userRepository:       userRepository,
+		suspensionRepository: suspensionRepository,
+		auditRepository:      auditRepository,
+		db:                   db,
+		validator:            validator,
+	}
+}
+
+type applyFunc func(ctx context.Context, tx *sql.Tx, id int)
+
+func (service *BulkServiceImpl) Posts(ctx context.Context, request PostBulkRequest, isAdmin bool) BulkResponse {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can run bulk post operations"))
+	}
+
+	err := service.validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	if len(request.Ids) == 0 && request.Filter.IsEmpty() {
+		panic(exception.NewBadRequestError("ids or filter is required"))
+	}
+
+	ids := service.resolvePostIds(ctx, request)
+
+	apply := func(ctx context.Context, tx *sql.Tx, postId int) {
+		post := service.repository.FindPostTarget(ctx, tx, postId)
+
+		var action string
+
+		switch request.Action {
+		case ActionSoftDelete:
+			if post.Deleted {
+				panic(exception.NewBadRequestError("post is already deleted"))
+			}
+			service.repository.SoftDeletePost(ctx, tx, post.Id)
+			action = audit.ActionPostDelete
+		case ActionRestore:
+			if !post.Deleted {
+				panic(exception.NewBadRequestError("post is not deleted"))
+			}
+			service.repository.RestorePost(ctx, tx, post.Id)
+			action = audit.ActionPostRestore
+		case ActionUnpublish:
+			if post.Deleted {
+				panic(exception.NewBadRequestError("post is deleted"))
+			}
+			if !post.Published {
+				panic(exception.NewBadRequestError("post is not published"))
+			}
+			service.repository.UnpublishPost(ctx, tx, post.Id)
+			action = audit.ActionPostUnpublish
+		case ActionRecategorize:
+			if post.Category_Id == request.Category_Id {
+				panic(exception.NewBadRequestError("post is already in this category"))
+			}
+			service.repository.UpdatePostCategory(ctx, tx, post.Id, request.Category_Id)
+			action = audit.ActionPostRecategorize
+		}
+
+		updatedPost := service.repository.FindPostTarget(ctx, tx, post.Id)
+
+		service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, action, audit.TargetPost, post.Id, ToPostTargetResponse(post), ToPostTargetResponse(updatedPost)))
+	}
+
+	return service.run(ctx, request.Action, ids, request.Dry_Run, request.Atomic, apply)
+}
+
+func (service *BulkServiceImpl) Users(ctx context.Context, request UserBulkRequest, isAdmin bool) BulkResponse {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can run bulk user operations"))
+	}
+
+	err := service.validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	if len(request.Ids) == 0 && request.Filter.IsEmpty() {
+		panic(exception.NewBadRequestError("ids or filter is required"))
+	}
+
+	if request.Action == ActionSuspend && !request.Permanent && request.Duration_Hours == 0 {
+		panic(exception.NewBadRequestError("duration_hours or permanent is required to suspend users"))
+	}
+
+	ids := service.resolveUserIds(ctx, request)
+
+	apply := func(ctx context.Context, tx *sql.Tx, userId int) {
+		target := service.repository.FindUserTarget(ctx, tx, userId)
+
+		if target.Id == request.Admin_Id {
+			panic(exception.NewBadRequestError("admin cannot " + request.Action + " their own account"))
+		}
+
+		if target.Role_Name == "admin" {
+			panic(exception.NewBadRequestError("admin accounts cannot be changed in bulk"))
+		}
+
+		switch request.Action {
+		case ActionSuspend:
+			newSuspension := suspension.Suspension{
+				User_Id:  target.Id,
+				Admin_Id: request.Admin_Id,
+				Reason:   request.Reason,
+			}
+
+			if !request.Permanent {
+				newSuspension.Expires_At = time.Now().Add(time.Duration(request.Duration_Hours) * time.Hour)
+			}
+
+			createdSuspension := service.suspensionRepository.Save(ctx, tx, newSuspension)
+
+			service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionUserSuspend, audit.TargetUser, target.Id, nil, suspension.ToSuspensionResponse(createdSuspension)))
+		case ActionDelete:
+			before := user.ToUserAuditResponse(service.userRepository.FindOne(ctx, tx, target.Id, ""))
+
+			service.userRepository.DeleteRelations(ctx, tx, target.Id)
+			service.userRepository.Delete(ctx, tx, target.Id)
+
+			service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionUserDelete, audit.TargetUser, target.Id, before, nil))
+		}
+	}
+
+	return service.run(ctx, request.Action, ids, request.Dry_Run, request.Atomic, apply)
+}
+
+func (service *BulkServiceImpl) resolvePostIds(ctx context.Context, request PostBulkRequest) []int {
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	if request.Action == ActionRecategorize && !service.repository.CategoryExists(ctx, tx, request.Category_Id) {
+		panic(exception.NewNotFoundError("category not found"))
+	}
+
+	// Without a filter the ids are used as given, so unknown ids show up as
+	// failed items instead of quietly dropping out of the run.
+	if request.Filter.IsEmpty() {
+		return limitTargets(uniqueIds(request.Ids), "posts")
+	}
+
+	return limitTargets(service.repository.FindPostIds(ctx, tx, request.Ids, request.Filter), "posts")
+}
+
+func (service *BulkServiceImpl) resolveUserIds(ctx context.Context, request UserBulkRequest) []int {
+	if request.Filter.IsEmpty() {
+		return limitTargets(uniqueIds(request.Ids), "users")
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	return limitTargets(service.repository.FindUserIds(ctx, tx, request.Ids, request.Filter), "users")
+}
+
+// run applies the action to every id. An atomic run shares one transaction
+// and is rolled back as a whole when any item fails, otherwise every item
+// commits on its own. A dry run goes through the same steps and rolls back at
+// the end, so its results show exactly what a real run would do.
+func (service *BulkServiceImpl) run(ctx context.Context, action string, ids []int, dryRun, atomic bool, apply applyFunc) BulkResponse {
+	response := BulkResponse{
+		Action:  action,
+		Dry_Run: dryRun,
+		Atomic:  atomic,
+		Matched: len(ids),
+		Results: []BulkItemResult{},
+	}
+
+	if atomic {
+		tx, err := service.db.Begin()
+		helpers.PanicError(err, "failed to begin transaction")
+
+		for _, id := range ids {
+			response.add(service.applyItem(ctx, tx, id, apply))
+		}
+
+		if response.Failed > 0 {
+			for i := range response.Results {
+				if response.Results[i].Status == StatusApplied {
+					response.Results[i].Status = StatusRolledBack
+				}
+			}
+			response.Succeeded = 0
+		}
+
+		finishTx(tx, response.Failed > 0 || dryRun)
+
+		return response
+	}
+
+	for _, id := range ids {
+		tx, err := service.db.Begin()
+		helpers.PanicError(err, "failed to begin transaction")
+
+		result := service.applyItem(ctx, tx, id, apply)
+		response.add(result)
+
+		finishTx(tx, result.Status == StatusFailed || dryRun)
+	}
+
+	return response
+}
+
+func (service *BulkServiceImpl) applyItem(ctx context.Context, tx *sql.Tx, id int, apply applyFunc) (result BulkItemResult) {
+	result = BulkItemResult{Id: id, Status: StatusApplied}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result.Status = StatusFailed
+			result.Error = exception.Message(recovered)
+		}
+	}()
+
+	apply(ctx, tx, id)
+
+	return result
+}
+
+func (response *BulkResponse) add(result BulkItemResult) {
+	if result.Status == StatusFailed {
+		response.Failed++
+	} else {
+		response.Succeeded++
+	}
+
+	response.Results = append(response.Results, result)
+}
+
+func finishTx(tx *sql.Tx, rollback bool) {
+	if rollback {
+		err := tx.Rollback()
+		helpers.PanicError(err, "failed to rollback transaction")
+		return
+	}
+
+	err := tx.Commit()
+	helpers.PanicError(err, "failed to commit transaction")
+}
+
+func limitTargets(ids []int, target string) []int {
+	if len(ids) > MaxTargets {
+		panic(exception.NewBadRequestError("bulk operation matches more than " + strconv.Itoa(MaxTargets) + " " + target + ", narrow the selection"))
+	}
+
+	return ids
+}
+
+func uniqueIds(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	var unique []int
+
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	return unique
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/hutamatr/GoBlogify/audit"
 	"github.com/hutamatr/GoBlogify/exception"
 	"github.com/hutamatr/GoBlogify/helpers"
 )
@@ -18,16 +19,18 @@ type CategoryService interface {
 }
 
 type CategoryServiceImpl struct {
-	repository CategoryRepository
-	db         *sql.DB
-	validator  *validator.Validate
+	repository      CategoryRepository
+	auditRepository audit.AuditRepository
+	db              *sql.DB
+	validator       *validator.Validate
 }
 
-func NewCategoryService(categoryRepository CategoryRepository, db *sql.DB, validator *validator.Validate) CategoryService {
+func NewCategoryService(categoryRepository CategoryRepository, auditRepository audit.AuditRepository, db *sql.DB, validator *validator.Validate) CategoryService {
 	return &CategoryServiceImpl{
-		repository: categoryRepository,
-		db:         db,
-		validator:  validator,
+		repository:      categoryRepository,
+		auditRepository: auditRepository,
+		db:              db,
+		validator:       validator,
 	}
 }
 
@@ -49,6 +52,8 @@ func (service *CategoryServiceImpl) Create(ctx context.Context, request Category
 
 	createdCategory := service.repository.Save(ctx, tx, newCategory)
 
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionCategoryCreate, audit.TargetCategory, createdCategory.Id, nil, ToCategoryResponse(createdCategory)))
+
 	return ToCategoryResponse(createdCategory)
 }
 
@@ -96,11 +101,14 @@ func (service *CategoryServiceImpl) Update(ctx context.Context, request Category
 	defer helpers.TxRollbackCommit(tx)
 
 	categoryData := service.repository.FindById(ctx, tx, request.Id)
+	before := ToCategoryResponse(categoryData)
 
 	categoryData.Name = request.Name
 
 	updatedCategory := service.repository.Update(ctx, tx, categoryData)
 
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionCategoryUpdate, audit.TargetCategory, updatedCategory.Id, before, ToCategoryResponse(updatedCategory)))
+
 	return ToCategoryResponse(updatedCategory)
 }
 
@@ -113,5 +121,9 @@ func (service *CategoryServiceImpl) Delete(ctx context.Context, categoryId int,
 	helpers.PanicError(err, "failed to begin transaction")
 	defer helpers.TxRollbackCommit(tx)
 
+	category := service.repository.FindById(ctx, tx, categoryId)
+
 	service.repository.Delete(ctx, tx, categoryId)
+
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionCategoryDelete, audit.TargetCategory, categoryId, ToCategoryResponse(category), nil))
 }
@@ -10,3 +10,14 @@ type CommentUpdateRequest struct {
 	Id      int    `json:"id" validate:"required"`
 	Content string `json:"content" validate:"required,min=1,max=500"`
 }
+
+type CommentModerationFilterRequest struct {
+	Status  string `json:"status" validate:"omitempty,oneof=pending approved spam hidden"`
+	Post_Id int    `json:"post_id"`
+}
+
+type CommentBulkModerationRequest struct {
+	Ids          []int  `json:"ids" validate:"required,min=1,max=100,dive,required"`
+	Status       string `json:"status" validate:"required,oneof=pending approved spam hidden"`
+	Moderator_Id int    `json:"moderator_id" validate:"required"`
+}
@@ -7,23 +7,63 @@ import (
 )
 
 type CommentResponse struct {
-	Id         int                      `json:"id"`
-	Post_Id    int                      `json:"post_id"`
-	User_Id    int                      `json:"user_id"`
-	Content    string                   `json:"content"`
-	Created_At time.Time                `json:"created_at"`
-	Updated_At time.Time                `json:"updated_at"`
-	User       user.UserCommentResponse `json:"user"`
+	Id                int                      `json:"id"`
+	Post_Id           int                      `json:"post_id"`
+	User_Id           int                      `json:"user_id"`
+	Content           string                   `json:"content"`
+	Moderation_Status string                   `json:"moderation_status"`
+	Created_At        time.Time                `json:"created_at"`
+	Updated_At        time.Time                `json:"updated_at"`
+	User              user.UserCommentResponse `json:"user"`
 }
 
 func ToCommentResponse(comment CommentJoin) CommentResponse {
 	return CommentResponse{
-		Id:         comment.Id,
-		Post_Id:    comment.Post_Id,
-		User_Id:    comment.User_Id,
-		Content:    comment.Content,
-		Created_At: comment.Created_At,
-		Updated_At: comment.Updated_At,
-		User:       user.ToUserCommentResponse(comment.User),
+		Id:                comment.Id,
+		Post_Id:           comment.Post_Id,
+		User_Id:           comment.User_Id,
+		Content:           comment.Content,
+		Moderation_Status: comment.Moderation_Status,
+		Created_At:        comment.Created_At,
+		Updated_At:        comment.Updated_At,
+		User:              user.ToUserCommentResponse(comment.User),
 	}
 }
+
+type CommentModerationResponse struct {
+	Id                int                      `json:"id"`
+	Post_Id           int                      `json:"post_id"`
+	User_Id           int                      `json:"user_id"`
+	Content           string                   `json:"content"`
+	Moderation_Status string                   `json:"moderation_status"`
+	Spam_Score        float64                  `json:"spam_score"`
+	Created_At        time.Time                `json:"created_at"`
+	Updated_At        time.Time                `json:"updated_at"`
+	User              user.UserCommentResponse `json:"user"`
+}
+
+func ToCommentModerationResponse(comment CommentJoin) CommentModerationResponse {
+	return CommentModerationResponse{
+		Id:                comment.Id,
+		Post_Id:           comment.Post_Id,
+		User_Id:           comment.User_Id,
+		Content:           comment.Content,
+		Moderation_Status: comment.Moderation_Status,
+		Spam_Score:        comment.Spam_Score,
+		Created_At:        comment.Created_At,
+		Updated_At:        comment.Updated_At,
+		User:              user.ToUserCommentResponse(comment.User),
+	}
+}
+
+type CommentModerationResult struct {
+	Id     int    `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+type CommentBulkModerationResponse struct {
+	Results   []CommentModerationResult `json:"results"`
+	Succeeded int                       `json:"succeeded"`
+	Failed    int                       `json:"failed"`
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/hutamatr/GoBlogify/exception"
 	"github.com/hutamatr/GoBlogify/helpers"
 	"github.com/julienschmidt/httprouter"
 )
@@ -14,6 +15,8 @@ type CommentController interface {
 	FindCommentByIdHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	UpdateCommentHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	DeleteCommentHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAllModerationCommentHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	BulkModerateCommentHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 }
 
 type CommentControllerImpl struct {
@@ -30,7 +33,11 @@ func (controller *CommentControllerImpl) CreateCommentHandler(writer http.Respon
 	var CommentRequest CommentCreateRequest
 	helpers.DecodeJSONFromRequest(request, &CommentRequest)
 
-	comment := controller.service.Create(request.Context(), CommentRequest)
+	CommentRequest.User_Id = helpers.GetUserId(request)
+	isModerator := helpers.IsModerator(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	comment := controller.service.Create(request.Context(), CommentRequest, isModerator, isAdmin)
 
 	CommentResponse := helpers.ResponseJSON{
 		Code:   http.StatusCreated,
@@ -47,9 +54,12 @@ func (controller *CommentControllerImpl) FindCommentsByPostHandler(writer http.R
 	postId, err := strconv.Atoi(id)
 	helpers.PanicError(err, "Invalid Post Id")
 
+	userId := helpers.GetUserId(request)
+	isModerator := helpers.IsModerator(request)
+	isAdmin := helpers.IsAdmin(request)
 	limit, offset := helpers.GetLimitOffset(request)
 
-	comments, countComments := controller.service.FindCommentsByPost(request.Context(), postId, limit, offset)
+	comments, countComments := controller.service.FindCommentsByPost(request.Context(), postId, userId, isModerator, isAdmin, limit, offset)
 
 	CommentResponse := helpers.ResponseJSON{
 		Code:   http.StatusOK,
@@ -72,7 +82,11 @@ func (controller *CommentControllerImpl) FindCommentByIdHandler(writer http.Resp
 
 	helpers.PanicError(err, "Invalid Comment Id")
 
-	comment := controller.service.FindById(request.Context(), commentId)
+	userId := helpers.GetUserId(request)
+	isModerator := helpers.IsModerator(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	comment := controller.service.FindById(request.Context(), commentId, userId, isModerator, isAdmin)
 
 	CommentResponse := helpers.ResponseJSON{
 		Code:   http.StatusOK,
@@ -122,3 +136,57 @@ func (controller *CommentControllerImpl) DeleteCommentHandler(writer http.Respon
 	writer.WriteHeader(http.StatusOK)
 	helpers.EncodeJSONFromResponse(writer, CommentResponse)
 }
+
+func (controller *CommentControllerImpl) FindAllModerationCommentHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	userId := helpers.GetUserId(request)
+	isModerator := helpers.IsModerator(request)
+	limit, offset := helpers.GetLimitOffset(request)
+	query := request.URL.Query()
+
+	filter := CommentModerationFilterRequest{
+		Status: query.Get("status"),
+	}
+
+	if postId := query.Get("post_id"); postId != "" {
+		id, err := strconv.Atoi(postId)
+		if err != nil {
+			panic(exception.NewBadRequestError("invalid post_id"))
+		}
+		filter.Post_Id = id
+	}
+
+	comments, countComments := controller.service.FindAllForModeration(request.Context(), filter, limit, offset, userId, isModerator)
+
+	CommentResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"comments": comments,
+			"limit":    limit,
+			"offset":   offset,
+			"total":    countComments,
+		},
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, CommentResponse)
+}
+
+func (controller *CommentControllerImpl) BulkModerateCommentHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	var moderationRequest CommentBulkModerationRequest
+	helpers.DecodeJSONFromRequest(request, &moderationRequest)
+
+	moderationRequest.Moderator_Id = helpers.GetUserId(request)
+	isModerator := helpers.IsModerator(request)
+
+	result := controller.service.BulkModerate(request.Context(), moderationRequest, isModerator)
+
+	CommentResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   result,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, CommentResponse)
+}
@@ -6,13 +6,23 @@ import (
 	"github.com/hutamatr/GoBlogify/user"
 )
 
+const (
+	ModerationPending  = "pending"
+	ModerationApproved = "approved"
+	ModerationSpam     = "spam"
+	ModerationHidden   = "hidden"
+)
+
 type Comment struct {
-	Id         int
-	Post_Id    int
-	User_Id    int
-	Content    string
-	Created_At time.Time
-	Updated_At time.Time
+	Id                int
+	Post_Id           int
+	User_Id           int
+	Content           string
+	Moderation_Status string
+	Spam_Score        float64
+	Spam_Label        string
+	Created_At        time.Time
+	Updated_At        time.Time
 }
 
 type CommentCreateOrUpdate struct {
@@ -23,11 +33,20 @@ type CommentCreateOrUpdate struct {
 }
 
 type CommentJoin struct {
-	Id         int
-	Post_Id    int
-	User_Id    int
-	Content    string
-	Created_At time.Time
-	Updated_At time.Time
-	User       user.User
+	Id                int
+	Post_Id           int
+	User_Id           int
+	Content           string
+	Moderation_Status string
+	Spam_Score        float64
+	Spam_Label        string
+	Created_At        time.Time
+	Updated_At        time.Time
+	User              user.User
+}
+
+type PostPolicy struct {
+	Post_Id        int
+	Author_Id      int
+	Comment_Policy string
 }
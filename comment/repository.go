@@ -3,6 +3,7 @@ package comment
 import (
 	"context"
 	"database/sql"
+	"strings"
 
 	"github.com/hutamatr/GoBlogify/exception"
 	"github.com/hutamatr/GoBlogify/helpers"
@@ -15,6 +16,11 @@ type CommentRepository interface {
 	Update(ctx context.Context, tx *sql.Tx, comment Comment) CommentJoin
 	Delete(ctx context.Context, tx *sql.Tx, commentId int)
 	CountCommentsByPost(ctx context.Context, tx *sql.Tx, postId int) int
+	FindAllForModeration(ctx context.Context, tx *sql.Tx, filter CommentModerationFilterRequest, limit, offset int) []CommentJoin
+	CountForModeration(ctx context.Context, tx *sql.Tx, filter CommentModerationFilterRequest) int
+	UpdateModeration(ctx context.Context, tx *sql.Tx, commentId int, status string, moderatorId int, label string)
+	FindPostPolicy(ctx context.Context, tx *sql.Tx, postId int) PostPolicy
+	IsFollower(ctx context.Context, tx *sql.Tx, followerId, followedId int) bool
 }
 
 type CommentRepositoryImpl struct {
@@ -25,9 +31,14 @@ func NewCommentRepository() CommentRepository {
 }
 
 func (repository *CommentRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, comment Comment) CommentJoin {
-	queryInsert := "INSERT INTO comment(post_id, user_id, content) VALUES(?, ?, ?)"
+	moderationStatus := comment.Moderation_Status
+	if moderationStatus == "" {
+		moderationStatus = ModerationApproved
+	}
+
+	queryInsert := "INSERT INTO comment(post_id, user_id, content, moderation_status, spam_score) VALUES(?, ?, ?, ?, ?)"
 
-	result, err := tx.ExecContext(ctx, queryInsert, comment.Post_Id, comment.User_Id, comment.Content)
+	result, err := tx.ExecContext(ctx, queryInsert, comment.Post_Id, comment.User_Id, comment.Content, moderationStatus, comment.Spam_Score)
 
 	helpers.PanicError(err, "failed to exec query insert comment")
 
@@ -41,11 +52,14 @@ func (repository *CommentRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, c
 }
 
 func (repository *CommentRepositoryImpl) FindCommentsByPost(ctx context.Context, tx *sql.Tx, postId, limit, offset int) []CommentJoin {
-	query := `SELECT c.id, c.content, c.post_id, c.user_id, c.created_at, c.updated_at, u.id, u.username, u.email 
+	query := `SELECT c.id, c.content, c.post_id, c.user_id, c.moderation_status, c.created_at, c.updated_at, u.id, u.username, u.email 
 	FROM user u 
 	JOIN comment c 
 	ON u.id = c.user_id 
-	WHERE c.post_id = ? LIMIT ? OFFSET ?`
+	WHERE c.post_id = ? 
+	AND c.is_deleted = false AND c.is_hidden = false AND c.moderation_status = 'approved' 
+	AND u.is_deleted = false 
+	AND u.is_deactivated = false LIMIT ? OFFSET ?`
 
 	rows, err := tx.QueryContext(ctx, query, postId, limit, offset)
 
@@ -57,7 +71,7 @@ func (repository *CommentRepositoryImpl) FindCommentsByPost(ctx context.Context,
 
 	for rows.Next() {
 		var comment CommentJoin
-		err := rows.Scan(&comment.Id, &comment.Content, &comment.Post_Id, &comment.User_Id, &comment.Created_At, &comment.Updated_At, &comment.User.Id, &comment.User.Username, &comment.User.Email)
+		err := rows.Scan(&comment.Id, &comment.Content, &comment.Post_Id, &comment.User_Id, &comment.Moderation_Status, &comment.Created_At, &comment.Updated_At, &comment.User.Id, &comment.User.Username, &comment.User.Email)
 		helpers.PanicError(err, "failed to scan comments by post")
 
 		comments = append(comments, comment)
@@ -67,7 +81,7 @@ func (repository *CommentRepositoryImpl) FindCommentsByPost(ctx context.Context,
 }
 
 func (repository *CommentRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, commentId int) CommentJoin {
-	query := "SELECT c.id, c.content, c.post_id, c.user_id, c.created_at, c.updated_at, u.id, u.username, u.email FROM user u JOIN comment c ON u.id = c.user_id WHERE c.id = ?"
+	query := "SELECT c.id, c.content, c.post_id, c.user_id, c.moderation_status, c.spam_score, c.spam_label, c.created_at, c.updated_at, u.id, u.username, u.email FROM user u JOIN comment c ON u.id = c.user_id WHERE c.id = ? AND c.is_deleted = false AND c.is_hidden = false AND u.is_deleted = false AND u.is_deactivated = false"
 
 	rows, err := tx.QueryContext(ctx, query, commentId)
 
@@ -76,11 +90,18 @@ func (repository *CommentRepositoryImpl) FindById(ctx context.Context, tx *sql.T
 	defer rows.Close()
 
 	var comment CommentJoin
+	var spamLabel sql.NullString
 
 	if rows.Next() {
-		err := rows.Scan(&comment.Id, &comment.Content, &comment.Post_Id, &comment.User_Id, &comment.Created_At, &comment.Updated_At, &comment.User.Id, &comment.User.Username, &comment.User.Email)
+		err := rows.Scan(&comment.Id, &comment.Content, &comment.Post_Id, &comment.User_Id, &comment.Moderation_Status, &comment.Spam_Score, &spamLabel, &comment.Created_At, &comment.Updated_At, &comment.User.Id, &comment.User.Username, &comment.User.Email)
 
 		helpers.PanicError(err, "failed to scan comment by id")
+
+		if spamLabel.Valid {
+			comment.Spam_Label = spamLabel.String
+		} else {
+			comment.Spam_Label = ""
+		}
 	} else {
 		panic(exception.NewNotFoundError("comment not found"))
 	}
@@ -89,9 +110,9 @@ func (repository *CommentRepositoryImpl) FindById(ctx context.Context, tx *sql.T
 }
 
 func (repository *CommentRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, comment Comment) CommentJoin {
-	query := "UPDATE comment SET content = ? WHERE id = ?"
+	query := "UPDATE comment SET content = ?, moderation_status = COALESCE(NULLIF(?, ''), moderation_status), spam_score = ?, spam_label = NULLIF(?, '') WHERE id = ? AND is_deleted = false"
 
-	_, err := tx.ExecContext(ctx, query, comment.Content, comment.Id)
+	_, err := tx.ExecContext(ctx, query, comment.Content, comment.Moderation_Status, comment.Spam_Score, comment.Spam_Label, comment.Id)
 
 	helpers.PanicError(err, "failed to exec query update comment")
 
@@ -117,7 +138,7 @@ func (repository *CommentRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx,
 }
 
 func (repository *CommentRepositoryImpl) CountCommentsByPost(ctx context.Context, tx *sql.Tx, postId int) int {
-	query := "SELECT COUNT(*) FROM comment WHERE post_id = ?"
+	query := "SELECT COUNT(*) FROM comment c JOIN user u ON u.id = c.user_id WHERE c.post_id = ? AND c.is_deleted = false AND c.is_hidden = false AND c.moderation_status = 'approved' AND u.is_deleted = false AND u.is_deactivated = false"
 
 	rows, err := tx.QueryContext(ctx, query, postId)
 
@@ -134,3 +155,113 @@ func (repository *CommentRepositoryImpl) CountCommentsByPost(ctx context.Context
 
 	return countComments
 }
+
+func commentModerationFilterQuery(filter CommentModerationFilterRequest) (string, []interface{}) {
+	status := filter.Status
+	if status == "" {
+		status = ModerationPending
+	}
+
+	conditions := []string{"c.moderation_status = ?"}
+	args := []interface{}{status}
+
+	if filter.Post_Id != 0 {
+		conditions = append(conditions, "c.post_id = ?")
+		args = append(args, filter.Post_Id)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func (repository *CommentRepositoryImpl) FindAllForModeration(ctx context.Context, tx *sql.Tx, filter CommentModerationFilterRequest, limit, offset int) []CommentJoin {
+	where, args := commentModerationFilterQuery(filter)
+
+	query := `SELECT c.id, c.content, c.post_id, c.user_id, c.moderation_status, c.spam_score, c.created_at, c.updated_at, u.id, u.username, u.email 
+	FROM user u 
+	JOIN comment c 
+	ON u.id = c.user_id 
+	WHERE ` + where + ` 
+	AND c.is_deleted = false AND c.is_hidden = false 
+	AND u.is_deleted = false 
+	ORDER BY c.spam_score DESC, c.created_at ASC, c.id ASC LIMIT ? OFFSET ?`
+
+	rows, err := tx.QueryContext(ctx, query, append(args, limit, offset)...)
+
+	helpers.PanicError(err, "failed to query comments for moderation")
+
+	defer rows.Close()
+
+	var comments []CommentJoin
+
+	for rows.Next() {
+		var comment CommentJoin
+		err := rows.Scan(&comment.Id, &comment.Content, &comment.Post_Id, &comment.User_Id, &comment.Moderation_Status, &comment.Spam_Score, &comment.Created_At, &comment.Updated_At, &comment.User.Id, &comment.User.Username, &comment.User.Email)
+		helpers.PanicError(err, "failed to scan comments for moderation")
+
+		comments = append(comments, comment)
+	}
+
+	return comments
+}
+
+func (repository *CommentRepositoryImpl) CountForModeration(ctx context.Context, tx *sql.Tx, filter CommentModerationFilterRequest) int {
+	where, args := commentModerationFilterQuery(filter)
+
+	query := "SELECT COUNT(*) FROM comment c JOIN user u ON u.id = c.user_id WHERE " + where + " AND c.is_deleted = false AND c.is_hidden = false AND u.is_deleted = false"
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+
+	helpers.PanicError(err, "failed to query count comments for moderation")
+
+	defer rows.Close()
+
+	var countComments int
+
+	if rows.Next() {
+		err := rows.Scan(&countComments)
+		helpers.PanicError(err, "failed to scan count comments for moderation")
+	}
+
+	return countComments
+}
+
+func (repository *CommentRepositoryImpl) UpdateModeration(ctx context.Context, tx *sql.Tx, commentId int, status string, moderatorId int, label string) {
+	query := "UPDATE comment SET moderation_status = ?, spam_label = NULLIF(?, ''), moderated_by = ?, moderated_at = NOW() WHERE id = ? AND is_deleted = false"
+
+	_, err := tx.ExecContext(ctx, query, status, label, moderatorId, commentId)
+
+	helpers.PanicError(err, "failed to exec query update comment moderation")
+}
+
+func (repository *CommentRepositoryImpl) FindPostPolicy(ctx context.Context, tx *sql.Tx, postId int) PostPolicy {
+	query := "SELECT id, user_id, comment_policy FROM post WHERE id = ? AND is_deleted = false AND is_hidden = false"
+
+	rows, err := tx.QueryContext(ctx, query, postId)
+
+	helpers.PanicError(err, "failed to query post comment policy")
+
+	defer rows.Close()
+
+	var policy PostPolicy
+
+	if rows.Next() {
+		err := rows.Scan(&policy.Post_Id, &policy.Author_Id, &policy.Comment_Policy)
+		helpers.PanicError(err, "failed to scan post comment policy")
+	} else {
+		panic(exception.NewNotFoundError("post not found"))
+	}
+
+	return policy
+}
+
+func (repository *CommentRepositoryImpl) IsFollower(ctx context.Context, tx *sql.Tx, followerId, followedId int) bool {
+	query := "SELECT id FROM follow WHERE follower_id = ? AND followed_id = ? LIMIT 1"
+
+	rows, err := tx.QueryContext(ctx, query, followerId, followedId)
+
+	helpers.PanicError(err, "failed to query follower")
+
+	defer rows.Close()
+
+	return rows.Next()
+}
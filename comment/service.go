@@ -5,33 +5,49 @@ import (
 	"database/sql"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/contentfilter"
 	"github.com/hutamatr/GoBlogify/exception"
 	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/post"
+	"github.com/hutamatr/GoBlogify/spam"
 )
 
 type CommentService interface {
-	Create(ctx context.Context, request CommentCreateRequest) CommentResponse
-	FindCommentsByPost(ctx context.Context, postId, limit, offset int) ([]CommentResponse, int)
-	FindById(ctx context.Context, commentId int) CommentResponse
+	Create(ctx context.Context, request CommentCreateRequest, isModerator, isAdmin bool) CommentResponse
+	FindCommentsByPost(ctx context.Context, postId, userId int, isModerator, isAdmin bool, limit, offset int) ([]CommentResponse, int)
+	FindById(ctx context.Context, commentId, userId int, isModerator, isAdmin bool) CommentResponse
 	Update(ctx context.Context, request CommentUpdateRequest) CommentResponse
 	Delete(ctx context.Context, commentId int)
+	FindAllForModeration(ctx context.Context, filter CommentModerationFilterRequest, limit, offset, userId int, isModerator bool) ([]CommentModerationResponse, int)
+	BulkModerate(ctx context.Context, request CommentBulkModerationRequest, isModerator bool) CommentBulkModerationResponse
 }
 
 type CommentServiceImpl struct {
-	repository CommentRepository
-	db         *sql.DB
-	validator  *validator.Validate
+	repository      CommentRepository
+	postRepository  post.PostRepository
+	pipeline        contentfilter.Pipeline
+	scorer          spam.SpamScorer
+	auditRepository audit.AuditRepository
+	db              *sql.DB
+	validator       *validator.Validate
 }
 
-func NewCommentService(commentRepository CommentRepository, db *sql.DB, validator *validator.Validate) CommentService {
+func NewCommentService(commentRepository CommentRepository, postRepository post.PostRepository, pipeline contentfilter.Pipeline, scorer spam.SpamScorer, auditRepository audit.AuditRepository, db *sql.DB, validator *validator.Validate) CommentService {
 	return &CommentServiceImpl{
-		repository: commentRepository,
-		db:         db,
-		validator:  validator,
+		repository:      commentRepository,
+		postRepository:  postRepository,
+		pipeline:        pipeline,
+		scorer:          scorer,
+		auditRepository: auditRepository,
+		db:              db,
+		validator:       validator,
 	}
 }
 
-func (service *CommentServiceImpl) Create(ctx context.Context, request CommentCreateRequest) CommentResponse {
+// Create, FindCommentsByPost and FindById only serve posts the caller may
+// read, so comments cannot be used to reach drafts or restricted posts.
+func (service *CommentServiceImpl) Create(ctx context.Context, request CommentCreateRequest, isModerator, isAdmin bool) CommentResponse {
 	err := service.validator.Struct(request)
 	helpers.PanicError(err, "invalid request")
 
@@ -39,10 +55,18 @@ func (service *CommentServiceImpl) Create(ctx context.Context, request CommentCr
 	helpers.PanicError(err, "failed to begin transaction")
 	defer helpers.TxRollbackCommit(tx)
 
+	post.FindReadable(ctx, tx, service.postRepository, request.Post_Id, request.User_Id, isModerator, isAdmin)
+
+	filtered, flagged := service.pipeline.Check(ctx, tx, request.Content)
+	policy := service.repository.FindPostPolicy(ctx, tx, request.Post_Id)
+	status, score := service.moderationStatus(ctx, tx, request.User_Id, policy, filtered[0], flagged)
+
 	newComment := Comment{
-		Post_Id: request.Post_Id,
-		User_Id: request.User_Id,
-		Content: request.Content,
+		Post_Id:           request.Post_Id,
+		User_Id:           request.User_Id,
+		Content:           filtered[0],
+		Moderation_Status: status,
+		Spam_Score:        score,
 	}
 
 	createdComment := service.repository.Save(ctx, tx, newComment)
@@ -50,11 +74,13 @@ func (service *CommentServiceImpl) Create(ctx context.Context, request CommentCr
 	return ToCommentResponse(createdComment)
 }
 
-func (service *CommentServiceImpl) FindCommentsByPost(ctx context.Context, postId, limit, offset int) ([]CommentResponse, int) {
+func (service *CommentServiceImpl) FindCommentsByPost(ctx context.Context, postId, userId int, isModerator, isAdmin bool, limit, offset int) ([]CommentResponse, int) {
 	tx, err := service.db.Begin()
 	helpers.PanicError(err, "failed to begin transaction")
 	defer helpers.TxRollbackCommit(tx)
 
+	post.FindReadable(ctx, tx, service.postRepository, postId, userId, isModerator, isAdmin)
+
 	comments := service.repository.FindCommentsByPost(ctx, tx, postId, limit, offset)
 	countComments := service.repository.CountCommentsByPost(ctx, tx, postId)
 
@@ -71,13 +97,19 @@ func (service *CommentServiceImpl) FindCommentsByPost(ctx context.Context, postI
 	return commentsData, countComments
 }
 
-func (service *CommentServiceImpl) FindById(ctx context.Context, commentId int) CommentResponse {
+func (service *CommentServiceImpl) FindById(ctx context.Context, commentId, userId int, isModerator, isAdmin bool) CommentResponse {
 	tx, err := service.db.Begin()
 	helpers.PanicError(err, "failed to begin transaction")
 	defer helpers.TxRollbackCommit(tx)
 
 	comment := service.repository.FindById(ctx, tx, commentId)
 
+	if comment.Moderation_Status != ModerationApproved && comment.User_Id != userId && !isModerator {
+		panic(exception.NewNotFoundError("comment not found"))
+	}
+
+	post.FindReadable(ctx, tx, service.postRepository, comment.Post_Id, userId, isModerator, isAdmin)
+
 	return ToCommentResponse(comment)
 }
 
@@ -89,11 +121,33 @@ func (service *CommentServiceImpl) Update(ctx context.Context, request CommentUp
 	helpers.PanicError(err, "failed to begin transaction")
 	defer helpers.TxRollbackCommit(tx)
 
-	service.repository.FindById(ctx, tx, request.Id)
+	comment := service.repository.FindById(ctx, tx, request.Id)
+
+	filtered, flagged := service.pipeline.Check(ctx, tx, request.Content)
+	policy := service.repository.FindPostPolicy(ctx, tx, comment.Post_Id)
+	status, score := service.moderationStatus(ctx, tx, comment.User_Id, policy, filtered[0], flagged)
+
+	// An edit can send a comment back for review but never clears a decision
+	// a moderator already made.
+	if status == ModerationApproved || comment.Moderation_Status == ModerationHidden {
+		status = ""
+	}
+
+	// The scorer learned the old text under its label. Once the text changes
+	// that lesson is taken back, otherwise a later relabel would untrain
+	// tokens it never learned and leave the old ones behind.
+	label := comment.Spam_Label
+	if filtered[0] != comment.Content {
+		service.retrain(ctx, tx, comment, "")
+		label = ""
+	}
 
 	updatedCommentData := Comment{
-		Id:      request.Id,
-		Content: request.Content,
+		Id:                request.Id,
+		Content:           filtered[0],
+		Moderation_Status: status,
+		Spam_Score:        score,
+		Spam_Label:        label,
 	}
 
 	updatedComment := service.repository.Update(ctx, tx, updatedCommentData)
@@ -108,3 +162,141 @@ func (service *CommentServiceImpl) Delete(ctx context.Context, commentId int) {
 
 	service.repository.Delete(ctx, tx, commentId)
 }
+
+func (service *CommentServiceImpl) FindAllForModeration(ctx context.Context, filter CommentModerationFilterRequest, limit, offset, userId int, isModerator bool) ([]CommentModerationResponse, int) {
+	err := service.validator.Struct(filter)
+	helpers.PanicError(err, "invalid request")
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	if !isModerator {
+		if filter.Post_Id == 0 || service.repository.FindPostPolicy(ctx, tx, filter.Post_Id).Author_Id != userId {
+			panic(exception.NewBadRequestError("only moderators or the post author can get the comment moderation queue"))
+		}
+	}
+
+	comments := service.repository.FindAllForModeration(ctx, tx, filter, limit, offset)
+	countComments := service.repository.CountForModeration(ctx, tx, filter)
+
+	var commentsData []CommentModerationResponse
+
+	for _, comment := range comments {
+		commentsData = append(commentsData, ToCommentModerationResponse(comment))
+	}
+
+	return commentsData, countComments
+}
+
+// BulkModerate applies the same decision to every comment in its own
+// transaction, so one missing or forbidden comment does not undo the rest.
+func (service *CommentServiceImpl) BulkModerate(ctx context.Context, request CommentBulkModerationRequest, isModerator bool) CommentBulkModerationResponse {
+	err := service.validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	var response CommentBulkModerationResponse
+
+	for _, commentId := range request.Ids {
+		result := service.moderateOne(ctx, commentId, request.Status, request.Moderator_Id, isModerator)
+
+		if result.Error == "" {
+			response.Succeeded++
+		} else {
+			response.Failed++
+		}
+
+		response.Results = append(response.Results, result)
+	}
+
+	return response
+}
+
+func (service *CommentServiceImpl) moderateOne(ctx context.Context, commentId int, status string, moderatorId int, isModerator bool) (result CommentModerationResult) {
+	result = CommentModerationResult{Id: commentId, Status: status}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result.Status = ""
+			result.Error = exception.Message(recovered)
+		}
+	}()
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	comment := service.repository.FindById(ctx, tx, commentId)
+
+	if !isModerator && service.repository.FindPostPolicy(ctx, tx, comment.Post_Id).Author_Id != moderatorId {
+		panic(exception.NewBadRequestError("only moderators or the post author can moderate this comment"))
+	}
+
+	// Only moderator decisions teach the spam scorer, post authors may have
+	// their own reasons for holding back a comment.
+	label := comment.Spam_Label
+	if isModerator {
+		label = trainingLabel(status)
+		service.retrain(ctx, tx, comment, label)
+	}
+
+	service.repository.UpdateModeration(ctx, tx, comment.Id, status, moderatorId, label)
+
+	moderatedComment := service.repository.FindById(ctx, tx, comment.Id)
+
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionCommentModerate, audit.TargetComment, comment.Id, ToCommentModerationResponse(comment), ToCommentModerationResponse(moderatedComment)))
+
+	return result
+}
+
+// moderationStatus decides the state of a new or edited comment. Authors
+// commenting on their own post are always approved, then the spam scorer, the
+// content filter and finally the post's comment policy get a say.
+func (service *CommentServiceImpl) moderationStatus(ctx context.Context, tx *sql.Tx, userId int, policy PostPolicy, content string, flagged bool) (string, float64) {
+	if userId == policy.Author_Id {
+		return ModerationApproved, 0
+	}
+
+	score := service.scorer.Score(ctx, tx, content)
+	threshold := helpers.EnvFloat(helpers.NewEnv().Spam.Threshold, 0.9)
+
+	switch {
+	case score >= threshold:
+		return ModerationSpam, score
+	case flagged:
+		return ModerationPending, score
+	case policy.Comment_Policy == post.CommentPolicyHoldAll:
+		return ModerationPending, score
+	case policy.Comment_Policy == post.CommentPolicyHoldLinks && helpers.ContainsLink(content):
+		return ModerationPending, score
+	case policy.Comment_Policy == post.CommentPolicyApproveFollowers && !service.repository.IsFollower(ctx, tx, userId, policy.Author_Id):
+		return ModerationPending, score
+	}
+
+	return ModerationApproved, score
+}
+
+func (service *CommentServiceImpl) retrain(ctx context.Context, tx *sql.Tx, comment CommentJoin, label string) {
+	if comment.Spam_Label == label {
+		return
+	}
+
+	if comment.Spam_Label != "" {
+		service.scorer.Untrain(ctx, tx, comment.Content, comment.Spam_Label)
+	}
+
+	if label != "" {
+		service.scorer.Train(ctx, tx, comment.Content, label)
+	}
+}
+
+func trainingLabel(status string) string {
+	switch status {
+	case ModerationSpam:
+		return spam.LabelSpam
+	case ModerationApproved:
+		return spam.LabelHam
+	default:
+		return ""
+	}
+}
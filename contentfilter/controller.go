@@ -0,0 +1,127 @@
+package contentfilter
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/julienschmidt/httprouter"
+)
+
+type FilterRuleController interface {
+	CreateFilterRuleHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAllFilterRuleHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindByIdFilterRuleHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	UpdateFilterRuleHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	DeleteFilterRuleHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
+
+type FilterRuleControllerImpl struct {
+	service FilterRuleService
+}
+
+func NewFilterRuleController(service FilterRuleService) FilterRuleController {
+	return &FilterRuleControllerImpl{
+		service: service,
+	}
+}
+
+func (controller *FilterRuleControllerImpl) CreateFilterRuleHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	var ruleRequest FilterRuleCreateRequest
+	helpers.DecodeJSONFromRequest(request, &ruleRequest)
+
+	isAdmin := helpers.IsAdmin(request)
+
+	rule := controller.service.Create(request.Context(), ruleRequest, isAdmin)
+
+	ruleResponse := helpers.ResponseJSON{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   rule,
+	}
+
+	writer.WriteHeader(http.StatusCreated)
+	helpers.EncodeJSONFromResponse(writer, ruleResponse)
+}
+
+func (controller *FilterRuleControllerImpl) FindAllFilterRuleHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	isAdmin := helpers.IsAdmin(request)
+	limit, offset := helpers.GetLimitOffset(request)
+	kind := request.URL.Query().Get("kind")
+
+	rules, countRules := controller.service.FindAll(request.Context(), kind, limit, offset, isAdmin)
+
+	ruleResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"rules":  rules,
+			"limit":  limit,
+			"offset": offset,
+			"total":  countRules,
+		},
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, ruleResponse)
+}
+
+func (controller *FilterRuleControllerImpl) FindByIdFilterRuleHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("ruleId")
+	ruleId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid Filter Rule Id")
+
+	isAdmin := helpers.IsAdmin(request)
+
+	rule := controller.service.FindById(request.Context(), ruleId, isAdmin)
+
+	ruleResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   rule,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, ruleResponse)
+}
+
+func (controller *FilterRuleControllerImpl) UpdateFilterRuleHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	var ruleRequest FilterRuleUpdateRequest
+	helpers.DecodeJSONFromRequest(request, &ruleRequest)
+
+	id := params.ByName("ruleId")
+	ruleId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid Filter Rule Id")
+
+	ruleRequest.Id = ruleId
+	isAdmin := helpers.IsAdmin(request)
+
+	rule := controller.service.Update(request.Context(), ruleRequest, isAdmin)
+
+	ruleResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "UPDATED",
+		Data:   rule,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, ruleResponse)
+}
+
+func (controller *FilterRuleControllerImpl) DeleteFilterRuleHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("ruleId")
+	ruleId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid Filter Rule Id")
+
+	isAdmin := helpers.IsAdmin(request)
+
+	controller.service.Delete(request.Context(), ruleId, isAdmin)
+
+	ruleResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "DELETED",
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, ruleResponse)
+}
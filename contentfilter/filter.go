@@ -0,0 +1,145 @@
+package contentfilter
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hutamatr/GoBlogify/exception"
+)
+
+// ContentFilter inspects a piece of user supplied text. Filters may rewrite
+// the text, reject it outright or ask for it to be reviewed by a moderator.
+type ContentFilter interface {
+	Apply(ctx context.Context, tx *sql.Tx, text string) Result
+}
+
+type Pipeline interface {
+	Run(ctx context.Context, tx *sql.Tx, text string) Result
+	Check(ctx context.Context, tx *sql.Tx, texts ...string) ([]string, bool)
+}
+
+type PipelineImpl struct {
+	filters []ContentFilter
+}
+
+func NewPipeline(filters ...ContentFilter) Pipeline {
+	return &PipelineImpl{
+		filters: filters,
+	}
+}
+
+// NewDefaultPipeline runs the word blocklist before the regex rules, so a
+// word that is masked is no longer seen by the regex filter.
+func NewDefaultPipeline(repository FilterRuleRepository) Pipeline {
+	return NewPipeline(NewWordFilter(repository), NewRegexFilter(repository))
+}
+
+// Run passes text through every filter in order and stops at the first
+// rejection.
+func (pipeline *PipelineImpl) Run(ctx context.Context, tx *sql.Tx, text string) Result {
+	result := Result{Text: text}
+
+	for _, filter := range pipeline.filters {
+		filtered := filter.Apply(ctx, tx, result.Text)
+
+		result.Text = filtered.Text
+		result.Moderate = result.Moderate || filtered.Moderate
+		result.Rule_Ids = append(result.Rule_Ids, filtered.Rule_Ids...)
+
+		if filtered.Rejected {
+			result.Rejected = true
+			return result
+		}
+	}
+
+	return result
+}
+
+// Check runs each text through the pipeline and panics with a bad request when
+// any of them is rejected. It returns the filtered texts in the same order and
+// whether any rule asked for moderation.
+func (pipeline *PipelineImpl) Check(ctx context.Context, tx *sql.Tx, texts ...string) ([]string, bool) {
+	filteredTexts := make([]string, len(texts))
+	moderate := false
+
+	for i, text := range texts {
+		result := pipeline.Run(ctx, tx, text)
+
+		if result.Rejected {
+			panic(exception.NewBadRequestError("content was rejected by the content filter"))
+		}
+
+		filteredTexts[i] = result.Text
+		moderate = moderate || result.Moderate
+	}
+
+	return filteredTexts, moderate
+}
+
+// applyRules matches every compiled rule against text and applies its action.
+// Rules that fail to compile are skipped so one bad pattern cannot block all
+// posting.
+func applyRules(text string, rules []Rule, compile func(pattern string) (*regexp.Regexp, error)) Result {
+	result := Result{Text: text}
+
+	for _, rule := range rules {
+		expression, err := compile(rule.Pattern)
+		if err != nil || !expression.MatchString(result.Text) {
+			continue
+		}
+
+		result.Rule_Ids = append(result.Rule_Ids, rule.Id)
+
+		switch rule.Action {
+		case ActionReject:
+			result.Rejected = true
+			return result
+		case ActionMask:
+			result.Text = maskAll(expression, result.Text)
+		case ActionModerate:
+			result.Moderate = true
+		}
+	}
+
+	return result
+}
+
+// maskAll replaces every match with asterisks. When the expression has a
+// group named "mask" only that group is replaced, which lets the word filter
+// match on surrounding boundaries without masking them. Matching is repeated
+// because boundaries shared by adjacent matches are consumed by the first one.
+func maskAll(expression *regexp.Regexp, text string) string {
+	group := expression.SubexpIndex("mask")
+
+	for {
+		matches := expression.FindAllStringSubmatchIndex(text, -1)
+		if len(matches) == 0 {
+			return text
+		}
+
+		var builder strings.Builder
+		last := 0
+
+		for _, match := range matches {
+			start, end := match[0], match[1]
+			if group > 0 && match[2*group] >= 0 {
+				start, end = match[2*group], match[2*group+1]
+			}
+
+			builder.WriteString(text[last:start])
+			builder.WriteString(strings.Repeat("*", utf8.RuneCountInString(text[start:end])))
+			last = end
+		}
+
+		builder.WriteString(text[last:])
+
+		masked := builder.String()
+		if masked == text {
+			return text
+		}
+		text = masked
+	}
+}
@@ -0,0 +1,15 @@
+package contentfilter
+
+type FilterRuleCreateRequest struct {
+	Kind    string `json:"kind" validate:"required,oneof=word regex"`
+	Pattern string `json:"pattern" validate:"required,min=1,max=255"`
+	Action  string `json:"action" validate:"required,oneof=reject mask moderate"`
+}
+
+type FilterRuleUpdateRequest struct {
+	Id      int    `json:"id" validate:"required"`
+	Kind    string `json:"kind" validate:"required,oneof=word regex"`
+	Pattern string `json:"pattern" validate:"required,min=1,max=255"`
+	Action  string `json:"action" validate:"required,oneof=reject mask moderate"`
+	Enabled bool   `json:"enabled"`
+}
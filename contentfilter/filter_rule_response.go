@@ -0,0 +1,25 @@
+package contentfilter
+
+import "time"
+
+type FilterRuleResponse struct {
+	Id         int       `json:"id"`
+	Kind       string    `json:"kind"`
+	Pattern    string    `json:"pattern"`
+	Action     string    `json:"action"`
+	Enabled    bool      `json:"enabled"`
+	Created_At time.Time `json:"created_at"`
+	Updated_At time.Time `json:"updated_at"`
+}
+
+func ToFilterRuleResponse(rule Rule) FilterRuleResponse {
+	return FilterRuleResponse{
+		Id:         rule.Id,
+		Kind:       rule.Kind,
+		Pattern:    rule.Pattern,
+		Action:     rule.Action,
+		Enabled:    rule.Enabled,
+		Created_At: rule.Created_At,
+		Updated_At: rule.Updated_At,
+	}
+}
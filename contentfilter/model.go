@@ -0,0 +1,33 @@
+package contentfilter
+
+import "time"
+
+const (
+	KindWord  = "word"
+	KindRegex = "regex"
+)
+
+const (
+	ActionReject   = "reject"
+	ActionMask     = "mask"
+	ActionModerate = "moderate"
+)
+
+type Rule struct {
+	Id         int
+	Kind       string
+	Pattern    string
+	Action     string
+	Enabled    bool
+	Created_At time.Time
+	Updated_At time.Time
+}
+
+// Result is what a filter hands to the next one in the pipeline. Text is the
+// possibly masked text, and Rule_Ids lists every rule that matched.
+type Result struct {
+	Text     string
+	Rejected bool
+	Moderate bool
+	Rule_Ids []int
+}
@@ -0,0 +1,24 @@
+package contentfilter
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+)
+
+// RegexFilter matches rules written as Go regular expressions.
+type RegexFilter struct {
+	repository FilterRuleRepository
+}
+
+func NewRegexFilter(repository FilterRuleRepository) ContentFilter {
+	return &RegexFilter{
+		repository: repository,
+	}
+}
+
+func (filter *RegexFilter) Apply(ctx context.Context, tx *sql.Tx, text string) Result {
+	rules := filter.repository.FindAllEnabled(ctx, tx, KindRegex)
+
+	return applyRules(text, rules, regexp.Compile)
+}
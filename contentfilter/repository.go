@@ -0,0 +1,151 @@
+package contentfilter
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type FilterRuleRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, rule Rule) Rule
+	FindAll(ctx context.Context, tx *sql.Tx, kind string, limit, offset int) []Rule
+	FindAllEnabled(ctx context.Context, tx *sql.Tx, kind string) []Rule
+	FindById(ctx context.Context, tx *sql.Tx, ruleId int) Rule
+	Update(ctx context.Context, tx *sql.Tx, rule Rule) Rule
+	Delete(ctx context.Context, tx *sql.Tx, ruleId int)
+	CountAll(ctx context.Context, tx *sql.Tx, kind string) int
+	Exists(ctx context.Context, tx *sql.Tx, kind, pattern string, excludeId int) bool
+}
+
+type FilterRuleRepositoryImpl struct {
+}
+
+func NewFilterRuleRepository() FilterRuleRepository {
+	return &FilterRuleRepositoryImpl{}
+}
+
+const filterRuleColumns = "id, kind, pattern, action, is_enabled, created_at, updated_at"
+
+func (repository *FilterRuleRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, rule Rule) Rule {
+	query := "INSERT INTO filter_rule(kind, pattern, action, is_enabled) VALUES (?, ?, ?, ?)"
+
+	result, err := tx.ExecContext(ctx, query, rule.Kind, rule.Pattern, rule.Action, rule.Enabled)
+	helpers.PanicError(err, "failed to exec query insert filter rule")
+
+	id, err := result.LastInsertId()
+	helpers.PanicError(err, "failed to get last insert id filter rule")
+
+	return repository.FindById(ctx, tx, int(id))
+}
+
+func (repository *FilterRuleRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, kind string, limit, offset int) []Rule {
+	query := "SELECT " + filterRuleColumns + " FROM filter_rule WHERE (? = '' OR kind = ?) ORDER BY id ASC LIMIT ? OFFSET ?"
+
+	rows, err := tx.QueryContext(ctx, query, kind, kind, limit, offset)
+	helpers.PanicError(err, "failed to query filter rules")
+
+	defer rows.Close()
+
+	var rules []Rule
+
+	for rows.Next() {
+		rules = append(rules, scanRule(rows))
+	}
+
+	return rules
+}
+
+func (repository *FilterRuleRepositoryImpl) FindAllEnabled(ctx context.Context, tx *sql.Tx, kind string) []Rule {
+	query := "SELECT " + filterRuleColumns + " FROM filter_rule WHERE is_enabled = true AND kind = ? ORDER BY id ASC"
+
+	rows, err := tx.QueryContext(ctx, query, kind)
+	helpers.PanicError(err, "failed to query enabled filter rules")
+
+	defer rows.Close()
+
+	var rules []Rule
+
+	for rows.Next() {
+		rules = append(rules, scanRule(rows))
+	}
+
+	return rules
+}
+
+func (repository *FilterRuleRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, ruleId int) Rule {
+	query := "SELECT " + filterRuleColumns + " FROM filter_rule WHERE id = ?"
+
+	rows, err := tx.QueryContext(ctx, query, ruleId)
+	helpers.PanicError(err, "failed to query filter rule")
+
+	defer rows.Close()
+
+	if rows.Next() {
+		return scanRule(rows)
+	}
+
+	panic(exception.NewNotFoundError("filter rule not found"))
+}
+
+func (repository *FilterRuleRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, rule Rule) Rule {
+	query := "UPDATE filter_rule SET kind = ?, pattern = ?, action = ?, is_enabled = ? WHERE id = ?"
+
+	_, err := tx.ExecContext(ctx, query, rule.Kind, rule.Pattern, rule.Action, rule.Enabled, rule.Id)
+	helpers.PanicError(err, "failed to exec query update filter rule")
+
+	return repository.FindById(ctx, tx, rule.Id)
+}
+
+func (repository *FilterRuleRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, ruleId int) {
+	query := "DELETE FROM filter_rule WHERE id = ?"
+
+	result, err := tx.ExecContext(ctx, query, ruleId)
+	helpers.PanicError(err, "failed to exec query delete filter rule")
+
+	resultRows, err := result.RowsAffected()
+	helpers.PanicError(err, "failed to display rows affected delete filter rule")
+
+	if resultRows == 0 {
+		panic(exception.NewNotFoundError("filter rule not found"))
+	}
+}
+
+func (repository *FilterRuleRepositoryImpl) CountAll(ctx context.Context, tx *sql.Tx, kind string) int {
+	query := "SELECT COUNT(*) FROM filter_rule WHERE (? = '' OR kind = ?)"
+
+	rows, err := tx.QueryContext(ctx, query, kind, kind)
+	helpers.PanicError(err, "failed to query count filter rules")
+
+	defer rows.Close()
+
+	var countRules int
+
+	if rows.Next() {
+		err := rows.Scan(&countRules)
+		helpers.PanicError(err, "failed to scan count filter rules")
+	}
+
+	return countRules
+}
+
+func (repository *FilterRuleRepositoryImpl) Exists(ctx context.Context, tx *sql.Tx, kind, pattern string, excludeId int) bool {
+	query := "SELECT id FROM filter_rule WHERE kind = ? AND pattern = ? AND id != ? LIMIT 1"
+
+	rows, err := tx.QueryContext(ctx, query, kind, pattern, excludeId)
+	helpers.PanicError(err, "failed to query filter rule exists")
+
+	defer rows.Close()
+
+	return rows.Next()
+}
+
+func scanRule(rows *sql.Rows) Rule {
+	var rule Rule
+
+	err := rows.Scan(&rule.Id, &rule.Kind, &rule.Pattern, &rule.Action, &rule.Enabled, &rule.Created_At, &rule.Updated_At)
+	helpers.PanicError(err, "failed to scan filter rule")
+
+	return rule
+}
@@ -0,0 +1,164 @@
+package contentfilter
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type FilterRuleService interface {
+	Create(ctx context.Context, request FilterRuleCreateRequest, isAdmin bool) FilterRuleResponse
+	FindAll(ctx context.Context, kind string, limit, offset int, isAdmin bool) ([]FilterRuleResponse, int)
+	FindById(ctx context.Context, ruleId int, isAdmin bool) FilterRuleResponse
+	Update(ctx context.Context, request FilterRuleUpdateRequest, isAdmin bool) FilterRuleResponse
+	Delete(ctx context.Context, ruleId int, isAdmin bool)
+}
+
+type FilterRuleServiceImpl struct {
+	repository      FilterRuleRepository
+	auditRepository audit.AuditRepository
+	db              *sql.DB
+	validator       *validator.Validate
+}
+
+func NewFilterRuleService(repository FilterRuleRepository, auditRepository audit.AuditRepository, db *sql.DB, validator *validator.Validate) FilterRuleService {
+	return &FilterRuleServiceImpl{
+		repository:      repository,
+		auditRepository: auditRepository,
+		db:              db,
+		validator:       validator,
+	}
+}
+
+func (service *FilterRuleServiceImpl) Create(ctx context.Context, request FilterRuleCreateRequest, isAdmin bool) FilterRuleResponse {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can create filter rule"))
+	}
+
+	err := service.validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	validatePattern(request.Kind, request.Pattern)
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	if service.repository.Exists(ctx, tx, request.Kind, request.Pattern, 0) {
+		panic(exception.NewBadRequestError("filter rule already exists"))
+	}
+
+	createdRule := service.repository.Save(ctx, tx, Rule{
+		Kind:    request.Kind,
+		Pattern: request.Pattern,
+		Action:  request.Action,
+		Enabled: true,
+	})
+
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionFilterRuleCreate, audit.TargetFilterRule, createdRule.Id, nil, ToFilterRuleResponse(createdRule)))
+
+	return ToFilterRuleResponse(createdRule)
+}
+
+func (service *FilterRuleServiceImpl) FindAll(ctx context.Context, kind string, limit, offset int, isAdmin bool) ([]FilterRuleResponse, int) {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can get filter rules"))
+	}
+
+	if kind != "" && kind != KindWord && kind != KindRegex {
+		panic(exception.NewBadRequestError("invalid filter rule kind"))
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	rules := service.repository.FindAll(ctx, tx, kind, limit, offset)
+	countRules := service.repository.CountAll(ctx, tx, kind)
+
+	var rulesData []FilterRuleResponse
+
+	for _, rule := range rules {
+		rulesData = append(rulesData, ToFilterRuleResponse(rule))
+	}
+
+	return rulesData, countRules
+}
+
+func (service *FilterRuleServiceImpl) FindById(ctx context.Context, ruleId int, isAdmin bool) FilterRuleResponse {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can get filter rule"))
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	rule := service.repository.FindById(ctx, tx, ruleId)
+
+	return ToFilterRuleResponse(rule)
+}
+
+func (service *FilterRuleServiceImpl) Update(ctx context.Context, request FilterRuleUpdateRequest, isAdmin bool) FilterRuleResponse {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can update filter rule"))
+	}
+
+	err := service.validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	validatePattern(request.Kind, request.Pattern)
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	rule := service.repository.FindById(ctx, tx, request.Id)
+	before := ToFilterRuleResponse(rule)
+
+	if service.repository.Exists(ctx, tx, request.Kind, request.Pattern, rule.Id) {
+		panic(exception.NewBadRequestError("filter rule already exists"))
+	}
+
+	rule.Kind = request.Kind
+	rule.Pattern = request.Pattern
+	rule.Action = request.Action
+	rule.Enabled = request.Enabled
+
+	updatedRule := service.repository.Update(ctx, tx, rule)
+
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionFilterRuleUpdate, audit.TargetFilterRule, updatedRule.Id, before, ToFilterRuleResponse(updatedRule)))
+
+	return ToFilterRuleResponse(updatedRule)
+}
+
+func (service *FilterRuleServiceImpl) Delete(ctx context.Context, ruleId int, isAdmin bool) {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can delete filter rule"))
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	rule := service.repository.FindById(ctx, tx, ruleId)
+
+	service.repository.Delete(ctx, tx, ruleId)
+
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionFilterRuleDelete, audit.TargetFilterRule, rule.Id, ToFilterRuleResponse(rule), nil))
+}
+
+func validatePattern(kind, pattern string) {
+	if kind != KindRegex {
+		return
+	}
+
+	if _, err := regexp.Compile(pattern); err != nil {
+		panic(exception.NewBadRequestError("invalid regex pattern: " + err.Error()))
+	}
+}
@@ -0,0 +1,29 @@
+package contentfilter
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+)
+
+// WordFilter matches blocklisted words case-insensitively and only as whole
+// words, so "ass" does not match "class".
+type WordFilter struct {
+	repository FilterRuleRepository
+}
+
+func NewWordFilter(repository FilterRuleRepository) ContentFilter {
+	return &WordFilter{
+		repository: repository,
+	}
+}
+
+func (filter *WordFilter) Apply(ctx context.Context, tx *sql.Tx, text string) Result {
+	rules := filter.repository.FindAllEnabled(ctx, tx, KindWord)
+
+	return applyRules(text, rules, compileWord)
+}
+
+func compileWord(word string) (*regexp.Regexp, error) {
+	return regexp.Compile(`(?i)(^|[^\p{L}\p{N}_])(?P<mask>` + regexp.QuoteMeta(word) + `)($|[^\p{L}\p{N}_])`)
+}
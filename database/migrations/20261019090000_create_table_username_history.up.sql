@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS username_history(
+  id INT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  user_id INT UNSIGNED NOT NULL,
+  username VARCHAR(255) NOT NULL,
+  reserved_until TIMESTAMP NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES user(id),
+  INDEX (username)
+) ENGINE = InnoDB;
\ No newline at end of file
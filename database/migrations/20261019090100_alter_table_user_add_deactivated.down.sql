@@ -0,0 +1,3 @@
+ALTER TABLE user
+  DROP COLUMN is_deactivated,
+  DROP COLUMN deactivated_at;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE user
+  ADD COLUMN is_deactivated BOOLEAN NOT NULL DEFAULT false AFTER is_deleted,
+  ADD COLUMN deactivated_at TIMESTAMP NULL AFTER deleted_at;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE comment
+  DROP COLUMN is_deleted,
+  DROP COLUMN deleted_at;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE comment
+  ADD COLUMN is_deleted BOOLEAN NOT NULL DEFAULT false AFTER content,
+  ADD COLUMN deleted_at TIMESTAMP NULL AFTER updated_at;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS erasure;
\ No newline at end of file
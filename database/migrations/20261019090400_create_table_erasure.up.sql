@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS erasure(
+  id INT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  user_id INT UNSIGNED NOT NULL,
+  post_policy VARCHAR(20) NOT NULL,
+  status VARCHAR(20) NOT NULL DEFAULT 'pending',
+  posts_affected INT UNSIGNED NOT NULL DEFAULT 0,
+  comments_reassigned INT UNSIGNED NOT NULL DEFAULT 0,
+  follows_removed INT UNSIGNED NOT NULL DEFAULT 0,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  completed_at TIMESTAMP NULL,
+  INDEX (user_id)
+) ENGINE = InnoDB;
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS user_suspension(
+  id INT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  user_id INT UNSIGNED NOT NULL,
+  admin_id INT UNSIGNED NOT NULL,
+  reason VARCHAR(500) NOT NULL,
+  expires_at TIMESTAMP NULL,
+  lifted_at TIMESTAMP NULL,
+  lifted_by INT UNSIGNED,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES user(id),
+  FOREIGN KEY (admin_id) REFERENCES user(id),
+  FOREIGN KEY (lifted_by) REFERENCES user(id)
+) ENGINE = InnoDB;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE user
+  ADD COLUMN tokens_revoked_at TIMESTAMP NULL AFTER deactivated_at;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS role_change;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS role_change(
+  id INT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  user_id INT UNSIGNED NOT NULL,
+  admin_id INT UNSIGNED NOT NULL,
+  old_role_id INT UNSIGNED NOT NULL,
+  old_role_name VARCHAR(255) NOT NULL,
+  new_role_id INT UNSIGNED NOT NULL,
+  new_role_name VARCHAR(255) NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES user(id),
+  FOREIGN KEY (admin_id) REFERENCES user(id)
+) ENGINE = InnoDB;
\ No newline at end of file
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS audit_log(
+  id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  actor_id INT UNSIGNED NOT NULL,
+  action VARCHAR(100) NOT NULL,
+  target_type VARCHAR(100) NOT NULL,
+  target_id INT UNSIGNED NOT NULL,
+  before_data JSON NULL,
+  after_data JSON NULL,
+  ip VARCHAR(45) NOT NULL,
+  user_agent VARCHAR(500) NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  INDEX (actor_id),
+  INDEX (action),
+  INDEX (target_type, target_id),
+  INDEX (created_at)
+) ENGINE = InnoDB;
\ No newline at end of file
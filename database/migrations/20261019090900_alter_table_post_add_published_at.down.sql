@@ -0,0 +1,3 @@
+ALTER TABLE post
+  DROP INDEX published_at,
+  DROP COLUMN published_at;
\ No newline at end of file
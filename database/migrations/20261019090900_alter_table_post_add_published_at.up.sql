@@ -0,0 +1,3 @@
+ALTER TABLE post
+  ADD COLUMN published_at TIMESTAMP NULL AFTER deleted_at,
+  ADD INDEX (published_at);
\ No newline at end of file
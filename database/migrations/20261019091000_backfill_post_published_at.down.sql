@@ -0,0 +1 @@
+UPDATE post SET published_at = NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+UPDATE post SET published_at = created_at WHERE is_published = true AND published_at IS NULL;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE post
+  DROP COLUMN is_hidden;
\ No newline at end of file
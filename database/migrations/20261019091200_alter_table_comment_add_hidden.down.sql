@@ -0,0 +1,2 @@
+ALTER TABLE comment
+  DROP COLUMN is_hidden;
\ No newline at end of file
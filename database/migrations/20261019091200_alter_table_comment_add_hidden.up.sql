@@ -0,0 +1,2 @@
+ALTER TABLE comment
+  ADD COLUMN is_hidden BOOLEAN NOT NULL DEFAULT false AFTER is_deleted;
\ No newline at end of file
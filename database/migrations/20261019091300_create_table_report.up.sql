@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS report(
+  id INT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  reporter_id INT UNSIGNED NOT NULL,
+  target_type VARCHAR(20) NOT NULL,
+  target_id INT UNSIGNED NOT NULL,
+  target_user_id INT UNSIGNED NOT NULL,
+  reason VARCHAR(50) NOT NULL,
+  note VARCHAR(1000) NOT NULL DEFAULT '',
+  status VARCHAR(20) NOT NULL DEFAULT 'open',
+  action VARCHAR(20),
+  resolution_note VARCHAR(1000),
+  resolved_by INT UNSIGNED,
+  resolved_at TIMESTAMP NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (reporter_id) REFERENCES user(id),
+  FOREIGN KEY (target_user_id) REFERENCES user(id),
+  FOREIGN KEY (resolved_by) REFERENCES user(id),
+  INDEX (status, created_at),
+  INDEX (target_type, target_id)
+) ENGINE = InnoDB;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE user
+  DROP COLUMN trust_level;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE user
+  ADD COLUMN trust_level VARCHAR(20) NOT NULL DEFAULT 'new' AFTER role_id;
\ No newline at end of file
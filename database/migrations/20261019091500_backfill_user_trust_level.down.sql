@@ -0,0 +1 @@
+UPDATE user SET trust_level = 'new';
\ No newline at end of file
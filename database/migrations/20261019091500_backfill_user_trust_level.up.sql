@@ -0,0 +1 @@
+UPDATE user SET trust_level = 'trusted';
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE post
+  DROP INDEX idx_post_moderation_status,
+  DROP COLUMN moderated_at,
+  DROP COLUMN moderated_by,
+  DROP COLUMN moderation_note,
+  DROP COLUMN moderation_status;
\ No newline at end of file
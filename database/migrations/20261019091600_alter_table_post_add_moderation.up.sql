@@ -0,0 +1,6 @@
+ALTER TABLE post
+  ADD COLUMN moderation_status VARCHAR(20) NOT NULL DEFAULT 'approved' AFTER is_hidden,
+  ADD COLUMN moderation_note VARCHAR(1000) NULL AFTER moderation_status,
+  ADD COLUMN moderated_by INT NULL AFTER moderation_note,
+  ADD COLUMN moderated_at TIMESTAMP NULL AFTER moderated_by,
+  ADD INDEX idx_post_moderation_status (moderation_status, created_at);
\ No newline at end of file
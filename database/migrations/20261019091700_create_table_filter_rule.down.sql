@@ -0,0 +1 @@
+DROP TABLE IF EXISTS filter_rule;
\ No newline at end of file
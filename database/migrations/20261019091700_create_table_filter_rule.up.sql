@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS filter_rule(
+  id INT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  kind VARCHAR(20) NOT NULL,
+  pattern VARCHAR(255) NOT NULL,
+  action VARCHAR(20) NOT NULL,
+  is_enabled BOOLEAN NOT NULL DEFAULT true,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  UNIQUE (kind, pattern),
+  INDEX (is_enabled, kind)
+) ENGINE = InnoDB;
\ No newline at end of file
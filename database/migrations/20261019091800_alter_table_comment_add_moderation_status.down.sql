@@ -0,0 +1,2 @@
+ALTER TABLE comment
+  DROP COLUMN moderation_status;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE comment
+  ADD COLUMN moderation_status VARCHAR(20) NOT NULL DEFAULT 'approved' AFTER is_hidden;
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE comment
+  DROP INDEX idx_comment_moderation_status,
+  DROP COLUMN moderated_at,
+  DROP COLUMN moderated_by,
+  DROP COLUMN spam_label,
+  DROP COLUMN spam_score;
\ No newline at end of file
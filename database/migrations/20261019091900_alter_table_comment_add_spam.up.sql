@@ -0,0 +1,6 @@
+ALTER TABLE comment
+  ADD COLUMN spam_score DOUBLE NOT NULL DEFAULT 0 AFTER moderation_status,
+  ADD COLUMN spam_label VARCHAR(10) NULL AFTER spam_score,
+  ADD COLUMN moderated_by INT UNSIGNED NULL AFTER spam_label,
+  ADD COLUMN moderated_at TIMESTAMP NULL AFTER moderated_by,
+  ADD INDEX idx_comment_moderation_status (moderation_status, created_at);
\ No newline at end of file
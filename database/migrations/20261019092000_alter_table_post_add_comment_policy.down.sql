@@ -0,0 +1,2 @@
+ALTER TABLE post
+  DROP COLUMN comment_policy;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE post
+  ADD COLUMN comment_policy VARCHAR(20) NOT NULL DEFAULT 'open' AFTER moderated_at;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS spam_token;
\ No newline at end of file
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS spam_token(
+  token VARCHAR(64) NOT NULL PRIMARY KEY,
+  spam_count INT NOT NULL DEFAULT 0,
+  ham_count INT NOT NULL DEFAULT 0,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
\ No newline at end of file
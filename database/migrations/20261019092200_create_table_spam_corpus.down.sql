@@ -0,0 +1 @@
+DROP TABLE IF EXISTS spam_corpus;
\ No newline at end of file
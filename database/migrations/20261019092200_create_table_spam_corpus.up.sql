@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS spam_corpus(
+  label VARCHAR(10) NOT NULL PRIMARY KEY,
+  documents INT NOT NULL DEFAULT 0,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
\ No newline at end of file
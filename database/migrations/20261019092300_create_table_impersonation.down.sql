@@ -0,0 +1 @@
+DROP TABLE IF EXISTS impersonation;
\ No newline at end of file
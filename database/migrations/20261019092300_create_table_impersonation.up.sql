@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS impersonation(
+  id INT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  admin_id INT UNSIGNED NOT NULL,
+  user_id INT UNSIGNED NOT NULL,
+  reason VARCHAR(500) NOT NULL,
+  expires_at TIMESTAMP NOT NULL,
+  ended_at TIMESTAMP NULL,
+  ended_by INT UNSIGNED,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  INDEX (admin_id, created_at),
+  FOREIGN KEY (admin_id) REFERENCES user(id),
+  FOREIGN KEY (user_id) REFERENCES user(id),
+  FOREIGN KEY (ended_by) REFERENCES user(id)
+) ENGINE = InnoDB;
\ No newline at end of file
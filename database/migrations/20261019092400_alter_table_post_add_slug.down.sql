@@ -0,0 +1 @@
+ALTER TABLE post DROP COLUMN slug;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE post ADD COLUMN slug VARCHAR(100) NULL AFTER title;
\ No newline at end of file
@@ -0,0 +1 @@
+UPDATE post SET slug = NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+UPDATE post SET slug = CONCAT_WS('-', NULLIF(TRIM(BOTH '-' FROM REGEXP_REPLACE(LOWER(LEFT(title, 60)), '[^a-z0-9]+', '-')), ''), id) WHERE slug IS NULL;
\ No newline at end of file
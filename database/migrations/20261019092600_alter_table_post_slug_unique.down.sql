@@ -0,0 +1,3 @@
+ALTER TABLE post
+  DROP INDEX idx_post_user_slug,
+  MODIFY slug VARCHAR(100) NULL;
\ No newline at end of file
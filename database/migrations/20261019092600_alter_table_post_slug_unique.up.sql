@@ -0,0 +1,3 @@
+ALTER TABLE post
+  MODIFY slug VARCHAR(100) NOT NULL,
+  ADD UNIQUE INDEX idx_post_user_slug (user_id, slug);
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS post_slug_history(
+  id INT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  post_id INT UNSIGNED NOT NULL,
+  user_id INT UNSIGNED NOT NULL,
+  slug VARCHAR(100) NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE INDEX idx_post_slug_history_user_slug (user_id, slug),
+  FOREIGN KEY (post_id) REFERENCES post(id) ON DELETE CASCADE
+) ENGINE = InnoDB;
\ No newline at end of file
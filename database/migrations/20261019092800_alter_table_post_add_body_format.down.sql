@@ -0,0 +1,4 @@
+ALTER TABLE post
+  DROP COLUMN body_toc,
+  DROP COLUMN body_html,
+  DROP COLUMN body_format;
\ No newline at end of file
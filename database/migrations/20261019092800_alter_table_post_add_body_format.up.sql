@@ -0,0 +1,4 @@
+ALTER TABLE post
+  ADD COLUMN body_format VARCHAR(20) NOT NULL DEFAULT 'plain' AFTER body,
+  ADD COLUMN body_html MEDIUMTEXT NULL AFTER body_format,
+  ADD COLUMN body_toc TEXT NULL AFTER body_html;
\ No newline at end of file
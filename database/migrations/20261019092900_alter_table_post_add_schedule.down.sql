@@ -0,0 +1,5 @@
+ALTER TABLE post
+  DROP INDEX unpublish_at,
+  DROP INDEX publish_at,
+  DROP COLUMN unpublish_at,
+  DROP COLUMN publish_at;
\ No newline at end of file
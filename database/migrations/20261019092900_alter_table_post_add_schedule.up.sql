@@ -0,0 +1,5 @@
+ALTER TABLE post
+  ADD COLUMN publish_at TIMESTAMP NULL AFTER published_at,
+  ADD COLUMN unpublish_at TIMESTAMP NULL AFTER publish_at,
+  ADD INDEX (publish_at),
+  ADD INDEX (unpublish_at);
\ No newline at end of file
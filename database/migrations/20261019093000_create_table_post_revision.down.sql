@@ -0,0 +1 @@
+DROP TABLE IF EXISTS post_revision;
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS post_revision(
+  id INT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  post_id INT UNSIGNED NOT NULL,
+  revision_number INT UNSIGNED NOT NULL,
+  user_id INT UNSIGNED NOT NULL,
+  title VARCHAR(255) NOT NULL,
+  body TEXT NOT NULL,
+  body_format VARCHAR(20) NOT NULL,
+  category_id INT UNSIGNED NOT NULL,
+  restored_from INT UNSIGNED,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE INDEX idx_post_revision_number (post_id, revision_number),
+  FOREIGN KEY (post_id) REFERENCES post(id) ON DELETE CASCADE,
+  FOREIGN KEY (user_id) REFERENCES user(id)
+) ENGINE = InnoDB;
\ No newline at end of file
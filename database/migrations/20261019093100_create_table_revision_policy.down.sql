@@ -0,0 +1 @@
+DROP TABLE IF EXISTS revision_policy;
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS revision_policy(
+  id TINYINT UNSIGNED NOT NULL PRIMARY KEY,
+  keep_last INT UNSIGNED NOT NULL DEFAULT 0,
+  max_age_days INT UNSIGNED NOT NULL DEFAULT 0,
+  updated_by INT UNSIGNED,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  FOREIGN KEY (updated_by) REFERENCES user(id)
+) ENGINE = InnoDB;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS tag;
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS post_tag(
+  post_id INT UNSIGNED NOT NULL,
+  tag_id INT UNSIGNED NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (post_id, tag_id),
+  INDEX (tag_id),
+  FOREIGN KEY (post_id) REFERENCES post(id) ON DELETE CASCADE,
+  FOREIGN KEY (tag_id) REFERENCES tag(id) ON DELETE CASCADE
+) ENGINE = InnoDB;
\ No newline at end of file
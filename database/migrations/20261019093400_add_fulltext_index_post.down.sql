@@ -0,0 +1,2 @@
+ALTER TABLE post
+  DROP INDEX idx_post_title_body;
\ No newline at end of file
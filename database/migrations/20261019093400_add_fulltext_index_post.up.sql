@@ -0,0 +1,2 @@
+ALTER TABLE post
+  ADD FULLTEXT INDEX idx_post_title_body (title, body);
\ No newline at end of file
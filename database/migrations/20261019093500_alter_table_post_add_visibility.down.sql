@@ -0,0 +1,3 @@
+ALTER TABLE post
+  DROP INDEX idx_post_visibility,
+  DROP COLUMN visibility;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE post
+  ADD COLUMN visibility VARCHAR(20) NOT NULL DEFAULT 'public' AFTER is_published,
+  ADD INDEX idx_post_visibility (visibility);
\ No newline at end of file
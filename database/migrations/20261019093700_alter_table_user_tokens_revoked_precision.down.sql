@@ -0,0 +1,2 @@
+ALTER TABLE user
+  MODIFY COLUMN tokens_revoked_at TIMESTAMP NULL;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE user
+  MODIFY COLUMN tokens_revoked_at TIMESTAMP(6) NULL;
\ No newline at end of file
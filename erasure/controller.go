@@ -0,0 +1,64 @@
+package erasure
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/julienschmidt/httprouter"
+)
+
+type ErasureController interface {
+	RequestErasureHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindErasureHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
+
+type ErasureControllerImpl struct {
+	service ErasureService
+}
+
+func NewErasureController(service ErasureService) ErasureController {
+	return &ErasureControllerImpl{
+		service: service,
+	}
+}
+
+func (controller *ErasureControllerImpl) RequestErasureHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("userId")
+	userId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid User Id")
+
+	callerId := helpers.GetUserId(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	erasure := controller.service.Request(request.Context(), userId, callerId, isAdmin)
+
+	erasureResponse := helpers.ResponseJSON{
+		Code:   http.StatusAccepted,
+		Status: "ACCEPTED",
+		Data:   erasure,
+	}
+
+	writer.WriteHeader(http.StatusAccepted)
+	helpers.EncodeJSONFromResponse(writer, erasureResponse)
+}
+
+func (controller *ErasureControllerImpl) FindErasureHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("userId")
+	userId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid User Id")
+
+	callerId := helpers.GetUserId(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	erasure := controller.service.FindLatest(request.Context(), userId, callerId, isAdmin)
+
+	erasureResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   erasure,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, erasureResponse)
+}
@@ -0,0 +1,33 @@
+package erasure
+
+import (
+	"time"
+)
+
+type ErasureResponse struct {
+	Id                  int       `json:"id"`
+	User_Id             int       `json:"user_id"`
+	Post_Policy         string    `json:"post_policy"`
+	Status              string    `json:"status"`
+	Posts_Affected      int       `json:"posts_affected"`
+	Comments_Reassigned int       `json:"comments_reassigned"`
+	Follows_Removed     int       `json:"follows_removed"`
+	Created_At          time.Time `json:"created_at"`
+	Updated_At          time.Time `json:"updated_at"`
+	Completed_At        time.Time `json:"completed_at"`
+}
+
+func ToErasureResponse(erasure Erasure) ErasureResponse {
+	return ErasureResponse{
+		Id:                  erasure.Id,
+		User_Id:             erasure.User_Id,
+		Post_Policy:         erasure.Post_Policy,
+		Status:              erasure.Status,
+		Posts_Affected:      erasure.Posts_Affected,
+		Comments_Reassigned: erasure.Comments_Reassigned,
+		Follows_Removed:     erasure.Follows_Removed,
+		Created_At:          erasure.Created_At,
+		Updated_At:          erasure.Updated_At,
+		Completed_At:        erasure.Completed_At,
+	}
+}
@@ -0,0 +1,37 @@
+package erasure
+
+import "time"
+
+type Erasure struct {
+	Id                  int
+	User_Id             int
+	Post_Policy         string
+	Status              string
+	Posts_Affected      int
+	Comments_Reassigned int
+	Follows_Removed     int
+	Created_At          time.Time
+	Updated_At          time.Time
+	Completed_At        time.Time
+}
+
+const (
+	StatusPending    = "pending"
+	StatusProcessing = "processing"
+	StatusCompleted  = "completed"
+	StatusFailed     = "failed"
+)
+
+const (
+	PostPolicyDelete    = "delete"
+	PostPolicyAnonymise = "anonymise"
+)
+
+const (
+	TombstoneUsername = "deleted-user"
+	TombstoneEmail    = "deleted-user@goblogify.invalid"
+)
+
+// Redacted replaces free text about an erased user that other records still
+// need to keep.
+const Redacted = "[redacted]"
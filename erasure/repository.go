@@ -0,0 +1,300 @@
+package erasure
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type ErasureRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, erasure Erasure) Erasure
+	FindById(ctx context.Context, tx *sql.Tx, erasureId int) Erasure
+	FindLatestByUser(ctx context.Context, tx *sql.Tx, userId int) Erasure
+	Update(ctx context.Context, tx *sql.Tx, erasure Erasure) Erasure
+	UserExists(ctx context.Context, tx *sql.Tx, userId int) bool
+	FindOrCreateTombstone(ctx context.Context, tx *sql.Tx) int
+	ReassignComments(ctx context.Context, tx *sql.Tx, userId, tombstoneId int) int
+	DeletePosts(ctx context.Context, tx *sql.Tx, userId int) int
+	ReassignPosts(ctx context.Context, tx *sql.Tx, userId, tombstoneId int) int
+	DeleteFollows(ctx context.Context, tx *sql.Tx, userId int) int
+	DeletePersonalRecords(ctx context.Context, tx *sql.Tx, userId int) []string
+	RedactRecords(ctx context.Context, tx *sql.Tx, userId int)
+	ScrubUser(ctx context.Context, tx *sql.Tx, userId int)
+}
+
+type ErasureRepositoryImpl struct {
+}
+
+func NewErasureRepository() ErasureRepository {
+	return &ErasureRepositoryImpl{}
+}
+
+func (repository *ErasureRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, erasure Erasure) Erasure {
+	query := "INSERT INTO erasure(user_id, post_policy, status) VALUES (?, ?, ?)"
+
+	result, err := tx.ExecContext(ctx, query, erasure.User_Id, erasure.Post_Policy, erasure.Status)
+	helpers.PanicError(err, "failed to exec query insert erasure")
+
+	id, err := result.LastInsertId()
+	helpers.PanicError(err, "failed to get last insert id erasure")
+
+	return repository.FindById(ctx, tx, int(id))
+}
+
+func (repository *ErasureRepositoryImpl) findOne(ctx context.Context, tx *sql.Tx, where string, arg interface{}) Erasure {
+	query := "SELECT id, user_id, post_policy, status, posts_affected, comments_reassigned, follows_removed, created_at, updated_at, completed_at FROM erasure WHERE " + where
+
+	rows, err := tx.QueryContext(ctx, query, arg)
+	helpers.PanicError(err, "failed to query erasure")
+
+	defer rows.Close()
+
+	var erasure Erasure
+	var completedAt sql.NullTime
+
+	if rows.Next() {
+		err := rows.Scan(&erasure.Id, &erasure.User_Id, &erasure.Post_Policy, &erasure.Status, &erasure.Posts_Affected, &erasure.Comments_Reassigned, &erasure.Follows_Removed, &erasure.Created_At, &erasure.Updated_At, &completedAt)
+		helpers.PanicError(err, "failed to scan erasure")
+
+		if completedAt.Valid {
+			erasure.Completed_At = completedAt.Time
+		} else {
+			erasure.Completed_At = time.Time{}
+		}
+	}
+
+	return erasure
+}
+
+func (repository *ErasureRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, erasureId int) Erasure {
+	erasure := repository.findOne(ctx, tx, "id = ?", erasureId)
+
+	if erasure.Id <= 0 {
+		panic(exception.NewNotFoundError("erasure not found"))
+	}
+
+	return erasure
+}
+
+func (repository *ErasureRepositoryImpl) FindLatestByUser(ctx context.Context, tx *sql.Tx, userId int) Erasure {
+	return repository.findOne(ctx, tx, "user_id = ? ORDER BY id DESC LIMIT 1", userId)
+}
+
+func (repository *ErasureRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, erasure Erasure) Erasure {
+	query := "UPDATE erasure SET status = ?, posts_affected = ?, comments_reassigned = ?, follows_removed = ?, completed_at = ? WHERE id = ?"
+
+	var completedAt sql.NullTime
+	if !erasure.Completed_At.IsZero() {
+		completedAt = sql.NullTime{Time: erasure.Completed_At, Valid: true}
+	}
+
+	_, err := tx.ExecContext(ctx, query, erasure.Status, erasure.Posts_Affected, erasure.Comments_Reassigned, erasure.Follows_Removed, completedAt, erasure.Id)
+	helpers.PanicError(err, "failed to exec query update erasure")
+
+	return repository.FindById(ctx, tx, erasure.Id)
+}
+
+func (repository *ErasureRepositoryImpl) UserExists(ctx context.Context, tx *sql.Tx, userId int) bool {
+	query := "SELECT id FROM user WHERE id = ? AND email <> ?"
+
+	rows, err := tx.QueryContext(ctx, query, userId, TombstoneEmail)
+	helpers.PanicError(err, "failed to query erasure user")
+
+	defer rows.Close()
+
+	return rows.Next()
+}
+
+func (repository *ErasureRepositoryImpl) FindOrCreateTombstone(ctx context.Context, tx *sql.Tx) int {
+	query := "SELECT id FROM user WHERE email = ?"
+
+	rows, err := tx.QueryContext(ctx, query, TombstoneEmail)
+	helpers.PanicError(err, "failed to query tombstone user")
+
+	var tombstoneId int
+
+	if rows.Next() {
+		err := rows.Scan(&tombstoneId)
+		helpers.PanicError(err, "failed to scan tombstone user")
+	}
+
+	rows.Close()
+
+	if tombstoneId > 0 {
+		return tombstoneId
+	}
+
+	_, err = tx.ExecContext(ctx, "INSERT IGNORE INTO role(name) VALUES ('user')")
+	helpers.PanicError(err, "failed to exec query insert tombstone role")
+
+	queryInsert := `INSERT INTO user(username, email, password, role_id) 
+	SELECT ?, ?, '', r.id FROM role r WHERE r.name = 'user'`
+
+	result, err := tx.ExecContext(ctx, queryInsert, TombstoneUsername, TombstoneEmail)
+	helpers.PanicError(err, "failed to exec query insert tombstone user")
+
+	id, err := result.LastInsertId()
+	helpers.PanicError(err, "failed to get last insert id tombstone user")
+
+	return int(id)
+}
+
+func (repository *ErasureRepositoryImpl) ReassignComments(ctx context.Context, tx *sql.Tx, userId, tombstoneId int) int {
+	query := "UPDATE comment SET user_id = ? WHERE user_id = ?"
+
+	result, err := tx.ExecContext(ctx, query, tombstoneId, userId)
+	helpers.PanicError(err, "failed to exec query reassign comments")
+
+	rowsAffected, err := result.RowsAffected()
+	helpers.PanicError(err, "failed to display rows affected reassign comments")
+
+	return int(rowsAffected)
+}
+
+func (repository *ErasureRepositoryImpl) DeletePosts(ctx context.Context, tx *sql.Tx, userId int) int {
+	queryComment := "DELETE FROM comment WHERE post_id IN (SELECT id FROM post WHERE user_id = ?)"
+
+	_, err := tx.ExecContext(ctx, queryComment, userId)
+	helpers.PanicError(err, "failed to exec query delete post comments")
+
+	queryPost := "DELETE FROM post WHERE user_id = ?"
+
+	result, err := tx.ExecContext(ctx, queryPost, userId)
+	helpers.PanicError(err, "failed to exec query delete posts")
+
+	rowsAffected, err := result.RowsAffected()
+	helpers.PanicError(err, "failed to display rows affected delete posts")
+
+	return int(rowsAffected)
+}
+
+// ReassignPosts moves the user's posts to the tombstone. Slugs are unique per
+// author and the tombstone collects posts from every erased user, so each post
+// is re-slugged with its id appended. The old slugs are dropped from the
+// history, links under the erased user's name cannot resolve anymore.
+func (repository *ErasureRepositoryImpl) ReassignPosts(ctx context.Context, tx *sql.Tx, userId, tombstoneId int) int {
+	queryHistory := "DELETE FROM post_slug_history WHERE user_id = ?"
+
+	_, err := tx.ExecContext(ctx, queryHistory, userId)
+	helpers.PanicError(err, "failed to exec query delete post slug history")
+
+	query := `UPDATE post
+	SET user_id = ?, slug = CONCAT(TRIM(TRAILING '-' FROM LEFT(slug, ? - CHAR_LENGTH(id) - 1)), '-', id)
+	WHERE user_id = ?`
+
+	result, err := tx.ExecContext(ctx, query, tombstoneId, helpers.SlugMaxLength, userId)
+	helpers.PanicError(err, "failed to exec query reassign posts")
+
+	rowsAffected, err := result.RowsAffected()
+	helpers.PanicError(err, "failed to display rows affected reassign posts")
+
+	queryRevision := "UPDATE post_revision SET user_id = ? WHERE user_id = ?"
+
+	_, err = tx.ExecContext(ctx, queryRevision, tombstoneId, userId)
+	helpers.PanicError(err, "failed to exec query reassign post revisions")
+
+	return int(rowsAffected)
+}
+
+func (repository *ErasureRepositoryImpl) DeleteFollows(ctx context.Context, tx *sql.Tx, userId int) int {
+	query := "DELETE FROM follow WHERE follower_id = ? OR followed_id = ?"
+
+	result, err := tx.ExecContext(ctx, query, userId, userId)
+	helpers.PanicError(err, "failed to exec query delete follows")
+
+	rowsAffected, err := result.RowsAffected()
+	helpers.PanicError(err, "failed to display rows affected delete follows")
+
+	return int(rowsAffected)
+}
+
+func (repository *ErasureRepositoryImpl) DeletePersonalRecords(ctx context.Context, tx *sql.Tx, userId int) []string {
+	query := "SELECT file_path FROM data_export WHERE user_id = ? AND file_path IS NOT NULL"
+
+	rows, err := tx.QueryContext(ctx, query, userId)
+	helpers.PanicError(err, "failed to query export files")
+
+	var filePaths []string
+
+	for rows.Next() {
+		var filePath string
+		err := rows.Scan(&filePath)
+		helpers.PanicError(err, "failed to scan export files")
+
+		filePaths = append(filePaths, filePath)
+	}
+
+	rows.Close()
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM data_export WHERE user_id = ?", userId)
+	helpers.PanicError(err, "failed to exec query delete data exports")
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM username_history WHERE user_id = ?", userId)
+	helpers.PanicError(err, "failed to exec query delete username history")
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM post_reaction WHERE user_id = ?", userId)
+	helpers.PanicError(err, "failed to exec query delete post reactions")
+
+	return filePaths
+}
+
+// RedactRecords removes the user's personal data from records that have to be
+// kept: audit log snapshots of the user and their content, the ip and user
+// agent of their own actions, report notes and suspension reasons. It runs
+// before the user's content is reassigned and the user row is scrubbed, since
+// it needs both to find the snapshots.
+func (repository *ErasureRepositoryImpl) RedactRecords(ctx context.Context, tx *sql.Tx, userId int) {
+	var username, email string
+	err := tx.QueryRowContext(ctx, "SELECT username, email FROM user WHERE id = ?", userId).Scan(&username, &email)
+	helpers.PanicError(err, "failed to query erasure user identifiers")
+
+	quotedUsername, err := json.Marshal(username)
+	helpers.PanicError(err, "failed to encode erasure username")
+	quotedEmail, err := json.Marshal(email)
+	helpers.PanicError(err, "failed to encode erasure email")
+
+	queryAudit := `UPDATE audit_log SET before_data = NULL, after_data = NULL 
+	WHERE (target_type = 'user' AND target_id = ?) 
+	OR (target_type = 'post' AND target_id IN (SELECT id FROM post WHERE user_id = ?)) 
+	OR (target_type = 'comment' AND target_id IN (SELECT id FROM comment WHERE user_id = ?)) 
+	OR LOCATE(?, CAST(before_data AS CHAR)) > 0 
+	OR LOCATE(?, CAST(after_data AS CHAR)) > 0 
+	OR LOCATE(?, CAST(before_data AS CHAR)) > 0 
+	OR LOCATE(?, CAST(after_data AS CHAR)) > 0`
+
+	_, err = tx.ExecContext(ctx, queryAudit, userId, userId, userId, string(quotedEmail), string(quotedEmail), string(quotedUsername), string(quotedUsername))
+	helpers.PanicError(err, "failed to exec query redact audit log snapshots")
+
+	_, err = tx.ExecContext(ctx, "UPDATE audit_log SET ip = '', user_agent = '' WHERE actor_id = ?", userId)
+	helpers.PanicError(err, "failed to exec query redact audit log actor")
+
+	_, err = tx.ExecContext(ctx, "UPDATE report SET note = ? WHERE note <> '' AND (reporter_id = ? OR target_user_id = ?)", Redacted, userId, userId)
+	helpers.PanicError(err, "failed to exec query redact report notes")
+
+	_, err = tx.ExecContext(ctx, "UPDATE report SET resolution_note = ? WHERE resolution_note <> '' AND target_user_id = ?", Redacted, userId)
+	helpers.PanicError(err, "failed to exec query redact report resolution notes")
+
+	_, err = tx.ExecContext(ctx, "UPDATE user_suspension SET reason = ? WHERE user_id = ?", Redacted, userId)
+	helpers.PanicError(err, "failed to exec query redact suspension reasons")
+}
+
+func (repository *ErasureRepositoryImpl) ScrubUser(ctx context.Context, tx *sql.Tx, userId int) {
+	query := `UPDATE user SET 
+	username = CONCAT('erased-', id), 
+	email = CONCAT('erased-', id, '@goblogify.invalid'), 
+	password = '', 
+	first_name = NULL, 
+	last_name = NULL, 
+	is_deleted = true, 
+	deleted_at = COALESCE(deleted_at, NOW()), 
+	is_deactivated = false, 
+	deactivated_at = NULL 
+	WHERE id = ?`
+
+	_, err := tx.ExecContext(ctx, query, userId)
+	helpers.PanicError(err, "failed to exec query scrub user")
+}
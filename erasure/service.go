@@ -0,0 +1,152 @@
+package erasure
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"time"
+
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type ErasureService interface {
+	Request(ctx context.Context, userId, callerId int, isAdmin bool) ErasureResponse
+	FindLatest(ctx context.Context, userId, callerId int, isAdmin bool) ErasureResponse
+}
+
+type ErasureServiceImpl struct {
+	repository ErasureRepository
+	db         *sql.DB
+}
+
+func NewErasureService(repository ErasureRepository, db *sql.DB) ErasureService {
+	return &ErasureServiceImpl{
+		repository: repository,
+		db:         db,
+	}
+}
+
+func (service *ErasureServiceImpl) Request(ctx context.Context, userId, callerId int, isAdmin bool) ErasureResponse {
+	if userId != callerId && !isAdmin {
+		panic(exception.NewBadRequestError("only the account owner or admin can request erasure"))
+	}
+
+	erasure, isNew := service.create(ctx, userId)
+
+	if isNew {
+		go service.run(erasure)
+	}
+
+	return ToErasureResponse(erasure)
+}
+
+func (service *ErasureServiceImpl) create(ctx context.Context, userId int) (Erasure, bool) {
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	latestErasure := service.repository.FindLatestByUser(ctx, tx, userId)
+
+	if latestErasure.Id > 0 && latestErasure.Status != StatusFailed {
+		return latestErasure, false
+	}
+
+	if !service.repository.UserExists(ctx, tx, userId) {
+		panic(exception.NewNotFoundError("user not found"))
+	}
+
+	postPolicy := helpers.NewEnv().Account.ErasurePostPolicy
+	if postPolicy != PostPolicyDelete {
+		postPolicy = PostPolicyAnonymise
+	}
+
+	newErasure := Erasure{
+		User_Id:     userId,
+		Post_Policy: postPolicy,
+		Status:      StatusPending,
+	}
+
+	return service.repository.Save(ctx, tx, newErasure), true
+}
+
+func (service *ErasureServiceImpl) FindLatest(ctx context.Context, userId, callerId int, isAdmin bool) ErasureResponse {
+	if userId != callerId && !isAdmin {
+		panic(exception.NewBadRequestError("only the account owner or admin can see erasure status"))
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	erasure := service.repository.FindLatestByUser(ctx, tx, userId)
+
+	if erasure.Id <= 0 {
+		panic(exception.NewNotFoundError("erasure not found"))
+	}
+
+	return ToErasureResponse(erasure)
+}
+
+func (service *ErasureServiceImpl) run(erasure Erasure) {
+	ctx := context.Background()
+
+	defer func() {
+		if err := recover(); err != nil {
+			helpers.LogError("failed to run erasure %d: %v", erasure.Id, err)
+
+			erasure.Status = StatusFailed
+			service.save(ctx, erasure)
+		}
+	}()
+
+	erasure.Status = StatusProcessing
+	erasure = service.save(ctx, erasure)
+
+	erasure, filePaths := service.erase(ctx, erasure)
+
+	for _, filePath := range filePaths {
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			helpers.LogError("failed to remove export file for erasure %d: %v", erasure.Id, err)
+		}
+	}
+
+	erasure.Status = StatusCompleted
+	erasure.Completed_At = time.Now()
+
+	service.save(ctx, erasure)
+}
+
+func (service *ErasureServiceImpl) erase(ctx context.Context, erasure Erasure) (Erasure, []string) {
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	service.repository.RedactRecords(ctx, tx, erasure.User_Id)
+
+	tombstoneId := service.repository.FindOrCreateTombstone(ctx, tx)
+
+	erasure.Comments_Reassigned += service.repository.ReassignComments(ctx, tx, erasure.User_Id, tombstoneId)
+
+	if erasure.Post_Policy == PostPolicyDelete {
+		erasure.Posts_Affected += service.repository.DeletePosts(ctx, tx, erasure.User_Id)
+	} else {
+		erasure.Posts_Affected += service.repository.ReassignPosts(ctx, tx, erasure.User_Id, tombstoneId)
+	}
+
+	erasure.Follows_Removed += service.repository.DeleteFollows(ctx, tx, erasure.User_Id)
+
+	filePaths := service.repository.DeletePersonalRecords(ctx, tx, erasure.User_Id)
+
+	service.repository.ScrubUser(ctx, tx, erasure.User_Id)
+
+	return erasure, filePaths
+}
+
+func (service *ErasureServiceImpl) save(ctx context.Context, erasure Erasure) Erasure {
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	return service.repository.Update(ctx, tx, erasure)
+}
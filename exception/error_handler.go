@@ -17,6 +17,9 @@ func ErrorHandler(writer http.ResponseWriter, request *http.Request, err interfa
 	if notFoundError(writer, request, err) {
 		return
 	}
+	if unauthorizedError(writer, request, err) {
+		return
+	}
 	internalServerError(writer, request, err)
 }
 
@@ -78,6 +81,25 @@ func notFoundError(writer http.ResponseWriter, _ *http.Request, err interface{})
 	return false
 }
 
+func unauthorizedError(writer http.ResponseWriter, _ *http.Request, err interface{}) bool {
+	if unauthorizedErr, ok := err.(UnauthorizedError); ok {
+		writer.Header().Add("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusUnauthorized)
+
+		ErrResponse := helpers.ErrorResponseJSON{
+			Code:    http.StatusUnauthorized,
+			Status:  "UNAUTHORIZED",
+			Error:   unauthorizedErr.Error,
+			Message: "Unauthorized",
+		}
+
+		helpers.EncodeJSONFromResponse(writer, ErrResponse)
+
+		return true
+	}
+	return false
+}
+
 func internalServerError(writer http.ResponseWriter, _ *http.Request, err interface{}) {
 	writer.Header().Add("Content-Type", "application/json")
 	writer.WriteHeader(http.StatusInternalServerError)
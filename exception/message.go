@@ -0,0 +1,20 @@
+package exception
+
+import "fmt"
+
+// Message returns the client facing message of a recovered panic, for
+// endpoints that report failures per item instead of failing the request.
+func Message(recovered interface{}) string {
+	switch err := recovered.(type) {
+	case BadRequestError:
+		return err.Error
+	case NotFoundError:
+		return err.Error
+	case UnauthorizedError:
+		return err.Error
+	case error:
+		return err.Error()
+	default:
+		return fmt.Sprint(recovered)
+	}
+}
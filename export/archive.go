@@ -0,0 +1,99 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+func writeArchive(dir string, export Export, data ExportData) string {
+	err := os.MkdirAll(dir, 0750)
+	helpers.PanicError(err, "failed to create export directory")
+
+	filePath := filepath.Join(dir, fmt.Sprintf("export-%d-%d.zip", export.User_Id, export.Id))
+
+	file, err := os.Create(filePath)
+	helpers.PanicError(err, "failed to create export file")
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+
+	writeJSONFile(archive, "profile.json", data.Profile)
+	writeJSONFile(archive, "posts.json", emptyIfNil(data.Posts))
+	writeJSONFile(archive, "comments.json", emptyIfNil(data.Comments))
+	writeJSONFile(archive, "followers.json", emptyIfNil(data.Followers))
+	writeJSONFile(archive, "following.json", emptyIfNil(data.Following))
+	writeJSONFile(archive, "username_history.json", emptyIfNil(data.Usernames))
+	writeJSONFile(archive, "audit_log.json", emptyIfNil(data.Audit))
+
+	for _, post := range data.Posts {
+		writeFile(archive, fmt.Sprintf("posts/%d.md", post.Id), postMarkdown(post))
+	}
+
+	writeFile(archive, "README.md", readmeMarkdown(data))
+
+	err = archive.Close()
+	helpers.PanicError(err, "failed to close export archive")
+
+	return filePath
+}
+
+func writeJSONFile(archive *zip.Writer, name string, value interface{}) {
+	content, err := json.MarshalIndent(value, "", "  ")
+	helpers.PanicError(err, "failed to encode export json")
+	writeFile(archive, name, string(content))
+}
+
+func writeFile(archive *zip.Writer, name string, content string) {
+	writer, err := archive.Create(name)
+	helpers.PanicError(err, "failed to create export archive entry")
+	_, err = writer.Write([]byte(content))
+	helpers.PanicError(err, "failed to write export archive entry")
+}
+
+func emptyIfNil[T any](values []T) []T {
+	if values == nil {
+		return []T{}
+	}
+	return values
+}
+
+func postMarkdown(post ExportPost) string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "# %s\n\n", post.Title)
+	fmt.Fprintf(&builder, "- Category: %s\n", post.Category)
+	fmt.Fprintf(&builder, "- Published: %t\n", post.Published)
+	fmt.Fprintf(&builder, "- Deleted: %t\n", post.Deleted)
+	fmt.Fprintf(&builder, "- Created at: %s\n", post.Created_At.Format(time.RFC3339))
+	fmt.Fprintf(&builder, "- Updated at: %s\n\n", post.Updated_At.Format(time.RFC3339))
+	builder.WriteString(post.Body)
+	builder.WriteString("\n")
+
+	return builder.String()
+}
+
+func readmeMarkdown(data ExportData) string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "# Personal data export for %s\n\n", data.Profile.Username)
+	fmt.Fprintf(&builder, "Generated at %s.\n\n", time.Now().Format(time.RFC3339))
+	builder.WriteString("| File | Contents | Records |\n")
+	builder.WriteString("| --- | --- | --- |\n")
+	builder.WriteString("| profile.json | Account profile | 1 |\n")
+	fmt.Fprintf(&builder, "| posts.json, posts/*.md | Posts, including drafts and deleted posts | %d |\n", len(data.Posts))
+	fmt.Fprintf(&builder, "| comments.json | Comments | %d |\n", len(data.Comments))
+	fmt.Fprintf(&builder, "| followers.json | Accounts following you | %d |\n", len(data.Followers))
+	fmt.Fprintf(&builder, "| following.json | Accounts you follow | %d |\n", len(data.Following))
+	fmt.Fprintf(&builder, "| username_history.json | Previous usernames | %d |\n", len(data.Usernames))
+	fmt.Fprintf(&builder, "| audit_log.json | Audit log entries you made or that concern your account | %d |\n", len(data.Audit))
+	builder.WriteString("\nSessions are not stored: sign-in uses stateless tokens, so there are no session records to export.\n")
+
+	return builder.String()
+}
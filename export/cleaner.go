@@ -0,0 +1,56 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io/fs"
+	"os"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type ExportCleaner interface {
+	Start(ctx context.Context)
+	RunDue(ctx context.Context) int
+}
+
+type ExportCleanerImpl struct {
+	repository ExportRepository
+	job        helpers.BatchJob
+}
+
+func NewExportCleaner(repository ExportRepository, db *sql.DB) ExportCleaner {
+	return &ExportCleanerImpl{
+		repository: repository,
+		job:        helpers.NewBatchJob("export cleaner", db),
+	}
+}
+
+func (cleaner *ExportCleanerImpl) Start(ctx context.Context) {
+	cleaner.job.Start(ctx, func(ctx context.Context) {
+		cleaner.RunDue(ctx)
+	})
+}
+
+// RunDue removes the archives of exports whose download link has expired,
+// marks them expired and returns how many it removed.
+func (cleaner *ExportCleanerImpl) RunDue(ctx context.Context) int {
+	return helpers.RunBatches(ctx, cleaner.job, cleaner.repository.FindExpired, cleaner.expire)
+}
+
+// expire removes the archive first. A file that is already gone was removed
+// by an earlier run whose transaction did not commit.
+func (cleaner *ExportCleanerImpl) expire(ctx context.Context, tx *sql.Tx, export Export) {
+	if export.File_Path != "" {
+		err := os.Remove(export.File_Path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			helpers.PanicError(err, "failed to remove export file")
+		}
+	}
+
+	export.Status = StatusExpired
+	export.File_Path = ""
+	export.Token = ""
+	cleaner.repository.Update(ctx, tx, export)
+}
@@ -0,0 +1,80 @@
+package export
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/julienschmidt/httprouter"
+)
+
+type ExportController interface {
+	RequestExportHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindExportByIdHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	DownloadExportHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
+
+type ExportControllerImpl struct {
+	service ExportService
+}
+
+func NewExportController(service ExportService) ExportController {
+	return &ExportControllerImpl{
+		service: service,
+	}
+}
+
+func (controller *ExportControllerImpl) RequestExportHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("userId")
+	userId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid User Id")
+
+	callerId := helpers.GetUserId(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	export := controller.service.Request(request.Context(), userId, callerId, isAdmin)
+
+	exportResponse := helpers.ResponseJSON{
+		Code:   http.StatusAccepted,
+		Status: "ACCEPTED",
+		Data:   export,
+	}
+
+	writer.WriteHeader(http.StatusAccepted)
+	helpers.EncodeJSONFromResponse(writer, exportResponse)
+}
+
+func (controller *ExportControllerImpl) FindExportByIdHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("userId")
+	userId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid User Id")
+
+	id = params.ByName("exportId")
+	exportId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid Export Id")
+
+	callerId := helpers.GetUserId(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	export := controller.service.FindById(request.Context(), userId, exportId, callerId, isAdmin)
+
+	exportResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   export,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, exportResponse)
+}
+
+func (controller *ExportControllerImpl) DownloadExportHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	token := params.ByName("token")
+
+	export := controller.service.Download(request.Context(), token)
+
+	writer.Header().Set("Content-Type", "application/zip")
+	writer.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"goblogify-export-%d.zip\"", export.Id))
+	http.ServeFile(writer, request, export.File_Path)
+}
@@ -0,0 +1,34 @@
+package export
+
+import (
+	"time"
+)
+
+type ExportResponse struct {
+	Id           int       `json:"id"`
+	User_Id      int       `json:"user_id"`
+	Status       string    `json:"status"`
+	Error        string    `json:"error,omitempty"`
+	Download_Url string    `json:"download_url,omitempty"`
+	Expires_At   time.Time `json:"expires_at"`
+	Created_At   time.Time `json:"created_at"`
+	Updated_At   time.Time `json:"updated_at"`
+}
+
+func ToExportResponse(export Export) ExportResponse {
+	var downloadUrl string
+	if export.Status == StatusCompleted && export.Token != "" && time.Now().Before(export.Expires_At) {
+		downloadUrl = "/api/v1/exports/download/" + export.Token
+	}
+
+	return ExportResponse{
+		Id:           export.Id,
+		User_Id:      export.User_Id,
+		Status:       export.Status,
+		Error:        export.Error,
+		Download_Url: downloadUrl,
+		Expires_At:   export.Expires_At,
+		Created_At:   export.Created_At,
+		Updated_At:   export.Updated_At,
+	}
+}
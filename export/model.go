@@ -0,0 +1,94 @@
+package export
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Export struct {
+	Id         int
+	User_Id    int
+	Status     string
+	File_Path  string
+	Token      string
+	Error      string
+	Expires_At time.Time
+	Created_At time.Time
+	Updated_At time.Time
+}
+
+type ExportProfile struct {
+	Id         int       `json:"id"`
+	Username   string    `json:"username"`
+	Email      string    `json:"email"`
+	First_Name string    `json:"first_name"`
+	Last_Name  string    `json:"last_name"`
+	Role       string    `json:"role"`
+	Created_At time.Time `json:"created_at"`
+	Updated_At time.Time `json:"updated_at"`
+}
+
+type ExportPost struct {
+	Id         int       `json:"id"`
+	Title      string    `json:"title"`
+	Body       string    `json:"body"`
+	Category   string    `json:"category"`
+	Published  bool      `json:"published"`
+	Deleted    bool      `json:"deleted"`
+	Created_At time.Time `json:"created_at"`
+	Updated_At time.Time `json:"updated_at"`
+}
+
+type ExportComment struct {
+	Id         int       `json:"id"`
+	Post_Id    int       `json:"post_id"`
+	Content    string    `json:"content"`
+	Deleted    bool      `json:"deleted"`
+	Created_At time.Time `json:"created_at"`
+	Updated_At time.Time `json:"updated_at"`
+}
+
+type ExportFollow struct {
+	User_Id    int       `json:"user_id"`
+	Username   string    `json:"username"`
+	Created_At time.Time `json:"created_at"`
+}
+
+type ExportUsername struct {
+	Username       string    `json:"username"`
+	Reserved_Until time.Time `json:"reserved_until"`
+	Created_At     time.Time `json:"created_at"`
+}
+
+// ExportAudit is an audit log entry the user made or that targets their
+// account. The ip and user agent are only kept for the user's own actions.
+type ExportAudit struct {
+	Id          int             `json:"id"`
+	Actor_Id    int             `json:"actor_id"`
+	Action      string          `json:"action"`
+	Target_Type string          `json:"target_type"`
+	Target_Id   int             `json:"target_id"`
+	Before      json.RawMessage `json:"before,omitempty"`
+	After       json.RawMessage `json:"after,omitempty"`
+	Ip          string          `json:"ip,omitempty"`
+	User_Agent  string          `json:"user_agent,omitempty"`
+	Created_At  time.Time       `json:"created_at"`
+}
+
+const (
+	StatusPending    = "pending"
+	StatusProcessing = "processing"
+	StatusCompleted  = "completed"
+	StatusFailed     = "failed"
+	StatusExpired    = "expired"
+)
+
+type ExportData struct {
+	Profile   ExportProfile
+	Posts     []ExportPost
+	Comments  []ExportComment
+	Followers []ExportFollow
+	Following []ExportFollow
+	Usernames []ExportUsername
+	Audit     []ExportAudit
+}
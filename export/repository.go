@@ -0,0 +1,318 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type ExportRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, export Export) Export
+	FindById(ctx context.Context, tx *sql.Tx, exportId int) Export
+	FindByToken(ctx context.Context, tx *sql.Tx, token string) Export
+	FindPending(ctx context.Context, tx *sql.Tx, userId int) Export
+	Update(ctx context.Context, tx *sql.Tx, export Export) Export
+	FindProfile(ctx context.Context, tx *sql.Tx, userId int) ExportProfile
+	FindPosts(ctx context.Context, tx *sql.Tx, userId int) []ExportPost
+	FindComments(ctx context.Context, tx *sql.Tx, userId int) []ExportComment
+	FindFollowers(ctx context.Context, tx *sql.Tx, userId int) []ExportFollow
+	FindFollowing(ctx context.Context, tx *sql.Tx, userId int) []ExportFollow
+	FindUsernames(ctx context.Context, tx *sql.Tx, userId int) []ExportUsername
+	FindAuditEntries(ctx context.Context, tx *sql.Tx, userId int) []ExportAudit
+	FindExpired(ctx context.Context, tx *sql.Tx, limit int) []Export
+}
+
+type ExportRepositoryImpl struct {
+}
+
+func NewExportRepository() ExportRepository {
+	return &ExportRepositoryImpl{}
+}
+
+func (repository *ExportRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, export Export) Export {
+	query := "INSERT INTO data_export(user_id, status) VALUES (?, ?)"
+
+	result, err := tx.ExecContext(ctx, query, export.User_Id, export.Status)
+	helpers.PanicError(err, "failed to exec query insert data export")
+
+	id, err := result.LastInsertId()
+	helpers.PanicError(err, "failed to get last insert id data export")
+
+	return repository.FindById(ctx, tx, int(id))
+}
+
+func (repository *ExportRepositoryImpl) findOne(ctx context.Context, tx *sql.Tx, where string, arg interface{}) Export {
+	query := "SELECT id, user_id, status, file_path, token, error, expires_at, created_at, updated_at FROM data_export WHERE " + where
+
+	rows, err := tx.QueryContext(ctx, query, arg)
+	helpers.PanicError(err, "failed to query data export")
+
+	defer rows.Close()
+
+	var export Export
+
+	var filePath sql.NullString
+	var token sql.NullString
+	var exportError sql.NullString
+	var expiresAt sql.NullTime
+
+	if rows.Next() {
+		err := rows.Scan(&export.Id, &export.User_Id, &export.Status, &filePath, &token, &exportError, &expiresAt, &export.Created_At, &export.Updated_At)
+		helpers.PanicError(err, "failed to scan data export")
+
+		export.File_Path = filePath.String
+		export.Token = token.String
+		export.Error = exportError.String
+
+		if expiresAt.Valid {
+			export.Expires_At = expiresAt.Time
+		} else {
+			export.Expires_At = time.Time{}
+		}
+	}
+
+	return export
+}
+
+func (repository *ExportRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, exportId int) Export {
+	export := repository.findOne(ctx, tx, "id = ?", exportId)
+
+	if export.Id <= 0 {
+		panic(exception.NewNotFoundError("export not found"))
+	}
+
+	return export
+}
+
+func (repository *ExportRepositoryImpl) FindByToken(ctx context.Context, tx *sql.Tx, token string) Export {
+	export := repository.findOne(ctx, tx, "token = ?", token)
+
+	if export.Id <= 0 {
+		panic(exception.NewNotFoundError("export not found"))
+	}
+
+	return export
+}
+
+func (repository *ExportRepositoryImpl) FindPending(ctx context.Context, tx *sql.Tx, userId int) Export {
+	return repository.findOne(ctx, tx, "user_id = ? AND status IN ('pending', 'processing') ORDER BY id DESC LIMIT 1", userId)
+}
+
+// FindExpired locks completed exports whose download link has expired, so the
+// cleaner can remove their files.
+func (repository *ExportRepositoryImpl) FindExpired(ctx context.Context, tx *sql.Tx, limit int) []Export {
+	query := "SELECT id FROM data_export WHERE status = ? AND expires_at <= NOW() ORDER BY id LIMIT ? FOR UPDATE SKIP LOCKED"
+
+	rows, err := tx.QueryContext(ctx, query, StatusCompleted, limit)
+	helpers.PanicError(err, "failed to query expired data exports")
+
+	var exportIds []int
+
+	for rows.Next() {
+		var exportId int
+		err := rows.Scan(&exportId)
+		helpers.PanicError(err, "failed to scan expired data exports")
+
+		exportIds = append(exportIds, exportId)
+	}
+
+	rows.Close()
+
+	var exports []Export
+
+	for _, exportId := range exportIds {
+		exports = append(exports, repository.FindById(ctx, tx, exportId))
+	}
+
+	return exports
+}
+
+func (repository *ExportRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, export Export) Export {
+	query := "UPDATE data_export SET status = ?, file_path = ?, token = ?, error = ?, expires_at = ? WHERE id = ?"
+
+	var token sql.NullString
+	if export.Token != "" {
+		token = sql.NullString{String: export.Token, Valid: true}
+	}
+
+	var expiresAt sql.NullTime
+	if !export.Expires_At.IsZero() {
+		expiresAt = sql.NullTime{Time: export.Expires_At, Valid: true}
+	}
+
+	_, err := tx.ExecContext(ctx, query, export.Status, export.File_Path, token, export.Error, expiresAt, export.Id)
+	helpers.PanicError(err, "failed to exec query update data export")
+
+	return repository.FindById(ctx, tx, export.Id)
+}
+
+func (repository *ExportRepositoryImpl) FindProfile(ctx context.Context, tx *sql.Tx, userId int) ExportProfile {
+	query := `SELECT u.id, u.username, u.email, u.first_name, u.last_name, r.name, u.created_at, u.updated_at 
+	FROM user u 
+	JOIN role r 
+	ON r.id = u.role_id 
+	WHERE u.id = ?`
+
+	rows, err := tx.QueryContext(ctx, query, userId)
+	helpers.PanicError(err, "failed to query export profile")
+
+	defer rows.Close()
+
+	var profile ExportProfile
+
+	var firstName sql.NullString
+	var lastName sql.NullString
+
+	if rows.Next() {
+		err := rows.Scan(&profile.Id, &profile.Username, &profile.Email, &firstName, &lastName, &profile.Role, &profile.Created_At, &profile.Updated_At)
+		helpers.PanicError(err, "failed to scan export profile")
+
+		profile.First_Name = firstName.String
+		profile.Last_Name = lastName.String
+	} else {
+		panic(exception.NewNotFoundError("user not found"))
+	}
+
+	return profile
+}
+
+func (repository *ExportRepositoryImpl) FindPosts(ctx context.Context, tx *sql.Tx, userId int) []ExportPost {
+	query := `SELECT p.id, p.title, p.body, c.name, p.is_published, p.is_deleted, p.created_at, p.updated_at 
+	FROM post p 
+	JOIN category c 
+	ON c.id = p.category_id 
+	WHERE p.user_id = ? 
+	ORDER BY p.id`
+
+	rows, err := tx.QueryContext(ctx, query, userId)
+	helpers.PanicError(err, "failed to query export posts")
+
+	defer rows.Close()
+
+	var posts []ExportPost
+
+	for rows.Next() {
+		var post ExportPost
+		err := rows.Scan(&post.Id, &post.Title, &post.Body, &post.Category, &post.Published, &post.Deleted, &post.Created_At, &post.Updated_At)
+		helpers.PanicError(err, "failed to scan export posts")
+
+		posts = append(posts, post)
+	}
+
+	return posts
+}
+
+func (repository *ExportRepositoryImpl) FindComments(ctx context.Context, tx *sql.Tx, userId int) []ExportComment {
+	query := "SELECT id, post_id, content, is_deleted, created_at, updated_at FROM comment WHERE user_id = ? ORDER BY id"
+
+	rows, err := tx.QueryContext(ctx, query, userId)
+	helpers.PanicError(err, "failed to query export comments")
+
+	defer rows.Close()
+
+	var comments []ExportComment
+
+	for rows.Next() {
+		var comment ExportComment
+		err := rows.Scan(&comment.Id, &comment.Post_Id, &comment.Content, &comment.Deleted, &comment.Created_At, &comment.Updated_At)
+		helpers.PanicError(err, "failed to scan export comments")
+
+		comments = append(comments, comment)
+	}
+
+	return comments
+}
+
+func (repository *ExportRepositoryImpl) findFollows(ctx context.Context, tx *sql.Tx, query string, userId int) []ExportFollow {
+	rows, err := tx.QueryContext(ctx, query, userId)
+	helpers.PanicError(err, "failed to query export follows")
+
+	defer rows.Close()
+
+	var follows []ExportFollow
+
+	for rows.Next() {
+		var follow ExportFollow
+		err := rows.Scan(&follow.User_Id, &follow.Username, &follow.Created_At)
+		helpers.PanicError(err, "failed to scan export follows")
+
+		follows = append(follows, follow)
+	}
+
+	return follows
+}
+
+func (repository *ExportRepositoryImpl) FindFollowers(ctx context.Context, tx *sql.Tx, userId int) []ExportFollow {
+	query := "SELECT u.id, u.username, f.created_at FROM follow f JOIN user u ON u.id = f.follower_id WHERE f.followed_id = ? ORDER BY f.id"
+
+	return repository.findFollows(ctx, tx, query, userId)
+}
+
+func (repository *ExportRepositoryImpl) FindFollowing(ctx context.Context, tx *sql.Tx, userId int) []ExportFollow {
+	query := "SELECT u.id, u.username, f.created_at FROM follow f JOIN user u ON u.id = f.followed_id WHERE f.follower_id = ? ORDER BY f.id"
+
+	return repository.findFollows(ctx, tx, query, userId)
+}
+
+func (repository *ExportRepositoryImpl) FindUsernames(ctx context.Context, tx *sql.Tx, userId int) []ExportUsername {
+	query := "SELECT username, reserved_until, created_at FROM username_history WHERE user_id = ? ORDER BY id"
+
+	rows, err := tx.QueryContext(ctx, query, userId)
+	helpers.PanicError(err, "failed to query export usernames")
+
+	defer rows.Close()
+
+	var usernames []ExportUsername
+
+	for rows.Next() {
+		var username ExportUsername
+		err := rows.Scan(&username.Username, &username.Reserved_Until, &username.Created_At)
+		helpers.PanicError(err, "failed to scan export usernames")
+
+		usernames = append(usernames, username)
+	}
+
+	return usernames
+}
+
+func (repository *ExportRepositoryImpl) FindAuditEntries(ctx context.Context, tx *sql.Tx, userId int) []ExportAudit {
+	query := `SELECT id, actor_id, action, target_type, target_id, before_data, after_data, IF(actor_id = ?, ip, ''), IF(actor_id = ?, user_agent, ''), created_at
+	FROM audit_log
+	WHERE actor_id = ? OR (target_type = 'user' AND target_id = ?)
+	ORDER BY id`
+
+	rows, err := tx.QueryContext(ctx, query, userId, userId, userId, userId)
+	helpers.PanicError(err, "failed to query export audit entries")
+
+	defer rows.Close()
+
+	var entries []ExportAudit
+
+	for rows.Next() {
+		var entry ExportAudit
+		var before sql.NullString
+		var after sql.NullString
+		var ip sql.NullString
+		var userAgent sql.NullString
+
+		err := rows.Scan(&entry.Id, &entry.Actor_Id, &entry.Action, &entry.Target_Type, &entry.Target_Id, &before, &after, &ip, &userAgent, &entry.Created_At)
+		helpers.PanicError(err, "failed to scan export audit entries")
+
+		if before.Valid {
+			entry.Before = json.RawMessage(before.String)
+		}
+		if after.Valid {
+			entry.After = json.RawMessage(after.String)
+		}
+		entry.Ip = ip.String
+		entry.User_Agent = userAgent.String
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
@@ -0,0 +1,152 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type ExportService interface {
+	Request(ctx context.Context, userId, callerId int, isAdmin bool) ExportResponse
+	FindById(ctx context.Context, userId, exportId, callerId int, isAdmin bool) ExportResponse
+	Download(ctx context.Context, token string) Export
+}
+
+type ExportServiceImpl struct {
+	repository ExportRepository
+	db         *sql.DB
+}
+
+func NewExportService(repository ExportRepository, db *sql.DB) ExportService {
+	return &ExportServiceImpl{
+		repository: repository,
+		db:         db,
+	}
+}
+
+func (service *ExportServiceImpl) Request(ctx context.Context, userId, callerId int, isAdmin bool) ExportResponse {
+	if userId != callerId && !isAdmin {
+		panic(exception.NewBadRequestError("only the account owner or admin can request a data export"))
+	}
+
+	export, isNew := service.create(ctx, userId)
+
+	if isNew {
+		go service.generate(export)
+	}
+
+	return ToExportResponse(export)
+}
+
+func (service *ExportServiceImpl) create(ctx context.Context, userId int) (Export, bool) {
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	service.repository.FindProfile(ctx, tx, userId)
+
+	pendingExport := service.repository.FindPending(ctx, tx, userId)
+
+	if pendingExport.Id > 0 {
+		return pendingExport, false
+	}
+
+	newExport := Export{
+		User_Id: userId,
+		Status:  StatusPending,
+	}
+
+	return service.repository.Save(ctx, tx, newExport), true
+}
+
+func (service *ExportServiceImpl) FindById(ctx context.Context, userId, exportId, callerId int, isAdmin bool) ExportResponse {
+	if userId != callerId && !isAdmin {
+		panic(exception.NewBadRequestError("only the account owner or admin can see a data export"))
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	export := service.repository.FindById(ctx, tx, exportId)
+
+	if export.User_Id != userId {
+		panic(exception.NewNotFoundError("export not found"))
+	}
+
+	return ToExportResponse(export)
+}
+
+func (service *ExportServiceImpl) Download(ctx context.Context, token string) Export {
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	export := service.repository.FindByToken(ctx, tx, token)
+
+	if export.Status != StatusCompleted || time.Now().After(export.Expires_At) {
+		panic(exception.NewNotFoundError("download link has expired"))
+	}
+
+	return export
+}
+
+func (service *ExportServiceImpl) generate(export Export) {
+	ctx := context.Background()
+
+	defer func() {
+		if err := recover(); err != nil {
+			helpers.LogError("failed to generate data export %d: %v", export.Id, err)
+
+			export.Status = StatusFailed
+			export.Error = "failed to generate data export"
+			service.save(ctx, export)
+		}
+	}()
+
+	env := helpers.NewEnv()
+	dir := env.Export.Dir
+	if dir == "" {
+		dir = "exports"
+	}
+	linkTTLHours := helpers.EnvInt(env.Export.LinkTTLHours, 24)
+
+	export.Status = StatusProcessing
+	export = service.save(ctx, export)
+
+	data := service.collect(ctx, export.User_Id)
+
+	export.File_Path = writeArchive(dir, export, data)
+	export.Token = helpers.RandomToken()
+	export.Expires_At = time.Now().Add(time.Duration(linkTTLHours) * time.Hour)
+	export.Status = StatusCompleted
+
+	service.save(ctx, export)
+}
+
+func (service *ExportServiceImpl) collect(ctx context.Context, userId int) ExportData {
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	return ExportData{
+		Profile:   service.repository.FindProfile(ctx, tx, userId),
+		Posts:     service.repository.FindPosts(ctx, tx, userId),
+		Comments:  service.repository.FindComments(ctx, tx, userId),
+		Followers: service.repository.FindFollowers(ctx, tx, userId),
+		Following: service.repository.FindFollowing(ctx, tx, userId),
+		Usernames: service.repository.FindUsernames(ctx, tx, userId),
+		Audit:     service.repository.FindAuditEntries(ctx, tx, userId),
+	}
+}
+
+func (service *ExportServiceImpl) save(ctx context.Context, export Export) Export {
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	return service.repository.Update(ctx, tx, export)
+}
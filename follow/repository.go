@@ -46,7 +46,9 @@ func (repository *FollowRepositoriesImpl) FindAllFollowerByUser(ctx context.Cont
 	FROM user u 
 	JOIN follow f 
 	ON u.id = f.follower_id 
-	WHERE f.followed_id = ? LIMIT ? OFFSET ?`
+	WHERE f.followed_id = ? 
+	AND u.is_deleted = false 
+	AND u.is_deactivated = false LIMIT ? OFFSET ?`
 
 	rows, err := tx.QueryContext(ctx, query, followedId, limit, offset)
 
@@ -86,7 +88,9 @@ func (repository *FollowRepositoriesImpl) FindAllFollowedByUser(ctx context.Cont
 	FROM user u 
 	JOIN follow f 
 	ON u.id = f.followed_id 
-	WHERE f.follower_id = ? LIMIT ? OFFSET ?`
+	WHERE f.follower_id = ? 
+	AND u.is_deleted = false 
+	AND u.is_deactivated = false LIMIT ? OFFSET ?`
 
 	rows, err := tx.QueryContext(ctx, query, followerId, limit, offset)
 
@@ -155,7 +159,7 @@ func (repository *FollowRepositoriesImpl) Delete(ctx context.Context, tx *sql.Tx
 }
 
 func (repository *FollowRepositoriesImpl) CountFollower(ctx context.Context, tx *sql.Tx, followedId int) int {
-	query := "SELECT COUNT(*) FROM follow WHERE followed_id = ?"
+	query := "SELECT COUNT(*) FROM follow f JOIN user u ON u.id = f.follower_id WHERE f.followed_id = ? AND u.is_deleted = false AND u.is_deactivated = false"
 
 	rows, err := tx.QueryContext(ctx, query, followedId)
 	helpers.PanicError(err, "failed to exec query count follower")
@@ -173,7 +177,7 @@ func (repository *FollowRepositoriesImpl) CountFollower(ctx context.Context, tx
 }
 
 func (repository *FollowRepositoriesImpl) CountFollowed(ctx context.Context, tx *sql.Tx, followerId int) int {
-	query := "SELECT COUNT(*) FROM follow WHERE follower_id = ?"
+	query := "SELECT COUNT(*) FROM follow f JOIN user u ON u.id = f.followed_id WHERE f.follower_id = ? AND u.is_deleted = false AND u.is_deactivated = false"
 
 	rows, err := tx.QueryContext(ctx, query, followerId)
 	helpers.PanicError(err, "failed to exec query count followed")
@@ -0,0 +1,86 @@
+package helpers
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// BatchJob is the loop the background workers share. They all run on the
+// scheduler interval and take at most the scheduler batch size of rows per
+// transaction.
+type BatchJob struct {
+	name      string
+	db        *sql.DB
+	interval  time.Duration
+	batchSize int
+}
+
+func NewBatchJob(name string, db *sql.DB) BatchJob {
+	env := NewEnv()
+
+	return BatchJob{
+		name:      name,
+		db:        db,
+		interval:  time.Duration(EnvInt(env.Scheduler.IntervalSeconds, 30)) * time.Second,
+		batchSize: EnvInt(env.Scheduler.BatchSize, 100),
+	}
+}
+
+// Start calls runDue right away and then on every tick until ctx is done. A
+// panic is logged and the next tick tries again.
+func (job BatchJob) Start(ctx context.Context, runDue func(ctx context.Context)) {
+	ticker := time.NewTicker(job.interval)
+	defer ticker.Stop()
+
+	for {
+		job.tick(ctx, runDue)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (job BatchJob) tick(ctx context.Context, runDue func(ctx context.Context)) {
+	defer func() {
+		if err := recover(); err != nil {
+			LogError("failed to run %s: %v", job.name, err)
+		}
+	}()
+
+	runDue(ctx)
+}
+
+// RunBatches applies apply to the rows findDue returns, one transaction per
+// batch, until a batch comes back short, and returns how many rows it applied.
+// findDue locks its rows FOR UPDATE SKIP LOCKED, so every instance of the
+// server may run the same job without doubling up.
+func RunBatches[T any](ctx context.Context, job BatchJob, findDue func(context.Context, *sql.Tx, int) []T, apply func(context.Context, *sql.Tx, T)) int {
+	total := 0
+
+	for {
+		count := runBatch(ctx, job, findDue, apply)
+		total += count
+
+		if count < job.batchSize {
+			return total
+		}
+	}
+}
+
+func runBatch[T any](ctx context.Context, job BatchJob, findDue func(context.Context, *sql.Tx, int) []T, apply func(context.Context, *sql.Tx, T)) int {
+	tx, err := job.db.Begin()
+	PanicError(err, "failed to begin transaction")
+	defer TxRollbackCommit(tx)
+
+	rows := findDue(ctx, tx, job.batchSize)
+
+	for _, row := range rows {
+		apply(ctx, tx, row)
+	}
+
+	return len(rows)
+}
@@ -0,0 +1,11 @@
+package helpers
+
+import "regexp"
+
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.)\S+|\b[a-z0-9-]+\.(com|net|org|io|ru|xyz|info|biz)\b`)
+
+// ContainsLink reports whether text looks like it carries a link, including
+// bare domains that are not written as full URLs.
+func ContainsLink(text string) bool {
+	return linkPattern.MatchString(text)
+}
@@ -0,0 +1,12 @@
+package helpers
+
+import (
+	"time"
+)
+
+func ParseDateQuery(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
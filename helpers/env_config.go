@@ -2,14 +2,16 @@ package helpers
 
 import (
 	"os"
+	"strconv"
 
 	"github.com/joho/godotenv"
 )
 
 type App struct {
-	AppEnv string
-	Host   string
-	Port   string
+	AppEnv         string
+	Host           string
+	Port           string
+	TrustedProxies string
 }
 
 type DB struct {
@@ -29,11 +31,57 @@ type Auth struct {
 	AdminCode string
 }
 
+type Account struct {
+	UsernameCooldownDays  string
+	DeactivationGraceDays string
+	ErasurePostPolicy     string
+}
+
+type Export struct {
+	Dir          string
+	LinkTTLHours string
+}
+
+type Stats struct {
+	CacheSeconds string
+}
+
+type Trust struct {
+	PromotionApprovedPosts string
+	PromotionAccountDays   string
+}
+
+type Spam struct {
+	Threshold    string
+	MinDocuments string
+}
+
+type Impersonation struct {
+	TTLMinutes string
+}
+
+type Scheduler struct {
+	IntervalSeconds string
+	BatchSize       string
+}
+
+type Reaction struct {
+	Set string
+}
+
 type Env struct {
-	App         *App
-	DB          *DB
-	SecretToken *SecretToken
-	Auth        *Auth
+	App           *App
+	DB            *DB
+	SecretToken   *SecretToken
+	Auth          *Auth
+	Account       *Account
+	Export        *Export
+	Stats         *Stats
+	Trust         *Trust
+	Spam          *Spam
+	Impersonation *Impersonation
+	Scheduler     *Scheduler
+	Reaction      *Reaction
 }
 
 func init() {
@@ -53,9 +101,10 @@ func init() {
 func NewEnv() *Env {
 	return &Env{
 		App: &App{
-			AppEnv: os.Getenv("APP_ENV"),
-			Host:   os.Getenv("HOST"),
-			Port:   os.Getenv("PORT"),
+			AppEnv:         os.Getenv("APP_ENV"),
+			Host:           os.Getenv("HOST"),
+			Port:           os.Getenv("PORT"),
+			TrustedProxies: os.Getenv("TRUSTED_PROXIES"),
 		},
 		DB: &DB{
 			Host:     os.Getenv("DB_HOST"),
@@ -71,5 +120,53 @@ func NewEnv() *Env {
 		Auth: &Auth{
 			AdminCode: os.Getenv("ADMIN_CODE"),
 		},
+		Account: &Account{
+			UsernameCooldownDays:  os.Getenv("USERNAME_COOLDOWN_DAYS"),
+			DeactivationGraceDays: os.Getenv("DEACTIVATION_GRACE_DAYS"),
+			ErasurePostPolicy:     os.Getenv("ERASURE_POST_POLICY"),
+		},
+		Export: &Export{
+			Dir:          os.Getenv("EXPORT_DIR"),
+			LinkTTLHours: os.Getenv("EXPORT_LINK_TTL_HOURS"),
+		},
+		Stats: &Stats{
+			CacheSeconds: os.Getenv("STATS_CACHE_SECONDS"),
+		},
+		Trust: &Trust{
+			PromotionApprovedPosts: os.Getenv("TRUST_PROMOTION_APPROVED_POSTS"),
+			PromotionAccountDays:   os.Getenv("TRUST_PROMOTION_ACCOUNT_DAYS"),
+		},
+		Spam: &Spam{
+			Threshold:    os.Getenv("SPAM_THRESHOLD"),
+			MinDocuments: os.Getenv("SPAM_MIN_DOCUMENTS"),
+		},
+		Impersonation: &Impersonation{
+			TTLMinutes: os.Getenv("IMPERSONATION_TTL_MINUTES"),
+		},
+		Scheduler: &Scheduler{
+			IntervalSeconds: os.Getenv("SCHEDULER_INTERVAL_SECONDS"),
+			BatchSize:       os.Getenv("SCHEDULER_BATCH_SIZE"),
+		},
+		Reaction: &Reaction{
+			Set: os.Getenv("REACTION_SET"),
+		},
+	}
+}
+
+func EnvInt(value string, fallback int) int {
+	if value == "" {
+		return fallback
+	}
+	result, err := strconv.Atoi(value)
+	PanicError(err, "failed to convert env value to int")
+	return result
+}
+
+func EnvFloat(value string, fallback float64) float64 {
+	if value == "" {
+		return fallback
 	}
+	result, err := strconv.ParseFloat(value, 64)
+	PanicError(err, "failed to convert env value to float")
+	return result
 }
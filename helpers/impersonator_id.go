@@ -0,0 +1,18 @@
+package helpers
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// GetImpersonatorId returns the id of the admin behind an impersonated
+// request, or 0 when the user is acting for themselves.
+func GetImpersonatorId(request *http.Request) int {
+	impersonatorIdString := request.Header.Get("impersonatorId")
+	if impersonatorIdString == "" {
+		return 0
+	}
+	impersonatorId, err := strconv.Atoi(impersonatorIdString)
+	PanicError(err, "failed to convert impersonatorId to int")
+	return impersonatorId
+}
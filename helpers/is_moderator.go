@@ -0,0 +1,13 @@
+package helpers
+
+import (
+	"net/http"
+	"strconv"
+)
+
+func IsModerator(request *http.Request) bool {
+	isModeratorString := request.Header.Get("isModerator")
+	isModerator, err := strconv.ParseBool(isModeratorString)
+	PanicError(err, "failed to convert isModerator to bool")
+	return isModerator
+}
@@ -0,0 +1,13 @@
+package helpers
+
+import "strings"
+
+var likeReplacer = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func LikePattern(keyword string) string {
+	return "%" + likeReplacer.Replace(keyword) + "%"
+}
+
+func LikePrefixPattern(keyword string) string {
+	return likeReplacer.Replace(keyword) + "%"
+}
@@ -0,0 +1,13 @@
+package helpers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+func RandomToken() string {
+	bytes := make([]byte, 32)
+	_, err := rand.Read(bytes)
+	PanicError(err, "failed to generate random token")
+	return hex.EncodeToString(bytes)
+}
@@ -0,0 +1,69 @@
+package helpers
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const SlugMaxLength = 80
+
+// transliterations covers letters that do not decompose into ASCII under
+// NFKD, such as ß or Cyrillic and Greek letters.
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ð': "d", 'þ': "th", 'ł': "l", 'ı': "i", 'ŋ': "ng",
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z", 'и': "i",
+	'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t",
+	'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "",
+	'э': "e", 'ю': "yu", 'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g",
+	'α': "a", 'β': "v", 'γ': "g", 'δ': "d", 'ε': "e", 'ζ': "z", 'η': "i", 'θ': "th", 'ι': "i", 'κ': "k",
+	'λ': "l", 'μ': "m", 'ν': "n", 'ξ': "x", 'ο': "o", 'π': "p", 'ρ': "r", 'σ': "s", 'ς': "s", 'τ': "t",
+	'υ': "y", 'φ': "f", 'χ': "ch", 'ψ': "ps", 'ω': "o",
+}
+
+// Slugify turns text into a lowercase, hyphen separated ASCII slug. Accents
+// are stripped, known scripts are transliterated and anything else is
+// dropped, so the result may be empty for text such as CJK titles.
+func Slugify(text string) string {
+	var builder strings.Builder
+	pendingHyphen := false
+
+	for _, r := range norm.NFKD.String(strings.ToLower(text)) {
+		if unicode.Is(unicode.Mn, r) || r == '\'' || r == '’' {
+			continue
+		}
+
+		var part string
+
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			part = string(r)
+		default:
+			part = transliterations[r]
+		}
+
+		if part == "" {
+			pendingHyphen = builder.Len() > 0
+			continue
+		}
+
+		if pendingHyphen {
+			builder.WriteByte('-')
+			pendingHyphen = false
+		}
+
+		builder.WriteString(part)
+	}
+
+	slug := builder.String()
+
+	if len(slug) > SlugMaxLength {
+		slug = strings.TrimRight(slug[:SlugMaxLength], "-")
+		if index := strings.LastIndexByte(slug, '-'); index > SlugMaxLength/2 {
+			slug = slug[:index]
+		}
+	}
+
+	return slug
+}
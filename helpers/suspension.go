@@ -0,0 +1,14 @@
+package helpers
+
+import (
+	"fmt"
+	"time"
+)
+
+func SuspensionMessage(reason string, expiresAt time.Time) string {
+	if expiresAt.IsZero() {
+		return fmt.Sprintf("account is banned: %s", reason)
+	}
+
+	return fmt.Sprintf("account is suspended until %s: %s", expiresAt.UTC().Format(time.RFC3339), reason)
+}
@@ -0,0 +1,13 @@
+package helpers
+
+import (
+	"net/http"
+	"strconv"
+)
+
+func GetUserId(request *http.Request) int {
+	userIdString := request.Header.Get("userId")
+	userId, err := strconv.Atoi(userIdString)
+	PanicError(err, "failed to convert userId to int")
+	return userId
+}
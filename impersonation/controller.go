@@ -0,0 +1,88 @@
+package impersonation
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/julienschmidt/httprouter"
+)
+
+type ImpersonationController interface {
+	StartImpersonationHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	EndImpersonationHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAllImpersonationHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
+
+type ImpersonationControllerImpl struct {
+	service ImpersonationService
+}
+
+func NewImpersonationController(service ImpersonationService) ImpersonationController {
+	return &ImpersonationControllerImpl{
+		service: service,
+	}
+}
+
+func (controller *ImpersonationControllerImpl) StartImpersonationHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	var impersonationRequest ImpersonationCreateRequest
+	helpers.DecodeJSONFromRequest(request, &impersonationRequest)
+
+	impersonationRequest.Admin_Id = helpers.GetUserId(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	impersonation := controller.service.Start(request.Context(), impersonationRequest, isAdmin)
+
+	impersonationResponse := helpers.ResponseJSON{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   impersonation,
+	}
+
+	writer.WriteHeader(http.StatusCreated)
+	helpers.EncodeJSONFromResponse(writer, impersonationResponse)
+}
+
+func (controller *ImpersonationControllerImpl) EndImpersonationHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("impersonationId")
+	impersonationId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid Impersonation Id")
+
+	adminId := helpers.GetImpersonatorId(request)
+	if adminId == 0 {
+		adminId = helpers.GetUserId(request)
+	}
+	isAdmin := helpers.IsAdmin(request)
+
+	controller.service.End(request.Context(), impersonationId, adminId, isAdmin)
+
+	impersonationResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "DELETED",
+		Data:   "impersonation ended",
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, impersonationResponse)
+}
+
+func (controller *ImpersonationControllerImpl) FindAllImpersonationHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	isAdmin := helpers.IsAdmin(request)
+	limit, offset := helpers.GetLimitOffset(request)
+
+	impersonations, countImpersonations := controller.service.FindAll(request.Context(), limit, offset, isAdmin)
+
+	impersonationResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"impersonations": impersonations,
+			"limit":          limit,
+			"offset":         offset,
+			"total":          countImpersonations,
+		},
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, impersonationResponse)
+}
@@ -0,0 +1,7 @@
+package impersonation
+
+type ImpersonationCreateRequest struct {
+	Admin_Id int    `json:"admin_id" validate:"required"`
+	User_Id  int    `json:"user_id" validate:"required"`
+	Reason   string `json:"reason" validate:"required,min=1,max=500"`
+}
@@ -0,0 +1,39 @@
+package impersonation
+
+import (
+	"time"
+)
+
+type ImpersonationResponse struct {
+	Id         int       `json:"id"`
+	Admin_Id   int       `json:"admin_id"`
+	User_Id    int       `json:"user_id"`
+	Reason     string    `json:"reason"`
+	Active     bool      `json:"active"`
+	Expires_At time.Time `json:"expires_at"`
+	Ended_At   time.Time `json:"ended_at"`
+	Ended_By   int       `json:"ended_by"`
+	Created_At time.Time `json:"created_at"`
+	Updated_At time.Time `json:"updated_at"`
+}
+
+type ImpersonationTokenResponse struct {
+	Impersonation ImpersonationResponse `json:"impersonation"`
+	Access_Token  string                `json:"access_token"`
+	Token_Type    string                `json:"token_type"`
+}
+
+func ToImpersonationResponse(impersonation Impersonation) ImpersonationResponse {
+	return ImpersonationResponse{
+		Id:         impersonation.Id,
+		Admin_Id:   impersonation.Admin_Id,
+		User_Id:    impersonation.User_Id,
+		Reason:     impersonation.Reason,
+		Active:     impersonation.IsActive(),
+		Expires_At: impersonation.Expires_At,
+		Ended_At:   impersonation.Ended_At,
+		Ended_By:   impersonation.Ended_By,
+		Created_At: impersonation.Created_At,
+		Updated_At: impersonation.Updated_At,
+	}
+}
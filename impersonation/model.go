@@ -0,0 +1,19 @@
+package impersonation
+
+import "time"
+
+type Impersonation struct {
+	Id         int
+	Admin_Id   int
+	User_Id    int
+	Reason     string
+	Expires_At time.Time
+	Ended_At   time.Time
+	Ended_By   int
+	Created_At time.Time
+	Updated_At time.Time
+}
+
+func (impersonation Impersonation) IsActive() bool {
+	return impersonation.Ended_At.IsZero() && time.Now().Before(impersonation.Expires_At)
+}
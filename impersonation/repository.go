@@ -0,0 +1,134 @@
+package impersonation
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type ImpersonationRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, impersonation Impersonation) Impersonation
+	FindById(ctx context.Context, tx *sql.Tx, impersonationId int) Impersonation
+	FindAll(ctx context.Context, tx *sql.Tx, limit, offset int) []Impersonation
+	CountAll(ctx context.Context, tx *sql.Tx) int
+	End(ctx context.Context, tx *sql.Tx, impersonationId, endedBy int)
+	FindUserRole(ctx context.Context, tx *sql.Tx, userId int) string
+}
+
+type ImpersonationRepositoryImpl struct {
+}
+
+func NewImpersonationRepository() ImpersonationRepository {
+	return &ImpersonationRepositoryImpl{}
+}
+
+func (repository *ImpersonationRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, impersonation Impersonation) Impersonation {
+	query := "INSERT INTO impersonation(admin_id, user_id, reason, expires_at) VALUES (?, ?, ?, ?)"
+
+	result, err := tx.ExecContext(ctx, query, impersonation.Admin_Id, impersonation.User_Id, impersonation.Reason, impersonation.Expires_At)
+	helpers.PanicError(err, "failed to exec query insert impersonation")
+
+	id, err := result.LastInsertId()
+	helpers.PanicError(err, "failed to get last insert id impersonation")
+
+	return repository.FindById(ctx, tx, int(id))
+}
+
+func (repository *ImpersonationRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, impersonationId int) Impersonation {
+	query := "SELECT id, admin_id, user_id, reason, expires_at, ended_at, ended_by, created_at, updated_at FROM impersonation WHERE id = ?"
+
+	rows, err := tx.QueryContext(ctx, query, impersonationId)
+	helpers.PanicError(err, "failed to query impersonation")
+
+	defer rows.Close()
+
+	if rows.Next() {
+		return scanImpersonation(rows)
+	}
+
+	panic(exception.NewNotFoundError("impersonation not found"))
+}
+
+func (repository *ImpersonationRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, limit, offset int) []Impersonation {
+	query := "SELECT id, admin_id, user_id, reason, expires_at, ended_at, ended_by, created_at, updated_at FROM impersonation ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?"
+
+	rows, err := tx.QueryContext(ctx, query, limit, offset)
+	helpers.PanicError(err, "failed to query impersonations")
+
+	defer rows.Close()
+
+	var impersonations []Impersonation
+
+	for rows.Next() {
+		impersonations = append(impersonations, scanImpersonation(rows))
+	}
+
+	return impersonations
+}
+
+func (repository *ImpersonationRepositoryImpl) CountAll(ctx context.Context, tx *sql.Tx) int {
+	query := "SELECT COUNT(*) FROM impersonation"
+
+	rows, err := tx.QueryContext(ctx, query)
+	helpers.PanicError(err, "failed to query count impersonations")
+
+	defer rows.Close()
+
+	var countImpersonations int
+
+	if rows.Next() {
+		err := rows.Scan(&countImpersonations)
+		helpers.PanicError(err, "failed to scan count impersonations")
+	}
+
+	return countImpersonations
+}
+
+func (repository *ImpersonationRepositoryImpl) End(ctx context.Context, tx *sql.Tx, impersonationId, endedBy int) {
+	query := "UPDATE impersonation SET ended_at = NOW(), ended_by = ? WHERE id = ? AND ended_at IS NULL"
+
+	_, err := tx.ExecContext(ctx, query, endedBy, impersonationId)
+	helpers.PanicError(err, "failed to exec query end impersonation")
+}
+
+func (repository *ImpersonationRepositoryImpl) FindUserRole(ctx context.Context, tx *sql.Tx, userId int) string {
+	query := "SELECT r.name FROM user u JOIN role r ON r.id = u.role_id WHERE u.id = ? AND u.is_deleted = false AND u.is_deactivated = false"
+
+	rows, err := tx.QueryContext(ctx, query, userId)
+	helpers.PanicError(err, "failed to query impersonation user role")
+
+	defer rows.Close()
+
+	var roleName string
+
+	if rows.Next() {
+		err := rows.Scan(&roleName)
+		helpers.PanicError(err, "failed to scan impersonation user role")
+	}
+
+	return roleName
+}
+
+func scanImpersonation(rows *sql.Rows) Impersonation {
+	var impersonation Impersonation
+	var endedAt sql.NullTime
+	var endedBy sql.NullInt64
+
+	err := rows.Scan(&impersonation.Id, &impersonation.Admin_Id, &impersonation.User_Id, &impersonation.Reason, &impersonation.Expires_At, &endedAt, &endedBy, &impersonation.Created_At, &impersonation.Updated_At)
+	helpers.PanicError(err, "failed to scan impersonation")
+
+	if endedAt.Valid {
+		impersonation.Ended_At = endedAt.Time
+	} else {
+		impersonation.Ended_At = time.Time{}
+	}
+
+	if endedBy.Valid {
+		impersonation.Ended_By = int(endedBy.Int64)
+	}
+
+	return impersonation
+}
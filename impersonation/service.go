@@ -0,0 +1,128 @@
+package impersonation
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type ImpersonationService interface {
+	Start(ctx context.Context, request ImpersonationCreateRequest, isAdmin bool) ImpersonationTokenResponse
+	End(ctx context.Context, impersonationId, adminId int, isAdmin bool)
+	FindAll(ctx context.Context, limit, offset int, isAdmin bool) ([]ImpersonationResponse, int)
+}
+
+type ImpersonationServiceImpl struct {
+	repository      ImpersonationRepository
+	auditRepository audit.AuditRepository
+	db              *sql.DB
+	validator       *validator.Validate
+}
+
+func NewImpersonationService(repository ImpersonationRepository, auditRepository audit.AuditRepository, db *sql.DB, validator *validator.Validate) ImpersonationService {
+	return &ImpersonationServiceImpl{
+		repository:      repository,
+		auditRepository: auditRepository,
+		db:              db,
+		validator:       validator,
+	}
+}
+
+func (service *ImpersonationServiceImpl) Start(ctx context.Context, request ImpersonationCreateRequest, isAdmin bool) ImpersonationTokenResponse {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can impersonate users"))
+	}
+
+	err := service.validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	if request.User_Id == request.Admin_Id {
+		panic(exception.NewBadRequestError("admin cannot impersonate their own account"))
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	roleName := service.repository.FindUserRole(ctx, tx, request.User_Id)
+
+	if roleName == "" {
+		panic(exception.NewNotFoundError("user not found"))
+	}
+
+	// Impersonating another admin would hand out their privileges without
+	// their credentials.
+	if roleName == "admin" {
+		panic(exception.NewBadRequestError("admin accounts cannot be impersonated"))
+	}
+
+	ttl := time.Duration(helpers.EnvInt(helpers.NewEnv().Impersonation.TTLMinutes, 15)) * time.Minute
+
+	impersonation := service.repository.Save(ctx, tx, Impersonation{
+		Admin_Id:   request.Admin_Id,
+		User_Id:    request.User_Id,
+		Reason:     request.Reason,
+		Expires_At: time.Now().Add(ttl),
+	})
+
+	accessToken, err := helpers.GenerateImpersonationToken(impersonation.User_Id, impersonation.Admin_Id, impersonation.Id, ttl, helpers.NewEnv().SecretToken.AccessSecret)
+	helpers.PanicError(err, "failed to generate impersonation token")
+
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionImpersonationStart, audit.TargetUser, impersonation.User_Id, nil, ToImpersonationResponse(impersonation)))
+
+	return ImpersonationTokenResponse{
+		Impersonation: ToImpersonationResponse(impersonation),
+		Access_Token:  accessToken,
+		Token_Type:    "impersonation",
+	}
+}
+
+// End can be called by any admin, or with the impersonation token itself so
+// support staff can drop the session without switching tokens.
+func (service *ImpersonationServiceImpl) End(ctx context.Context, impersonationId, adminId int, isAdmin bool) {
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	impersonation := service.repository.FindById(ctx, tx, impersonationId)
+
+	if !isAdmin && impersonation.Admin_Id != adminId {
+		panic(exception.NewBadRequestError("only admin can end impersonations"))
+	}
+
+	if !impersonation.IsActive() {
+		panic(exception.NewBadRequestError("impersonation has already ended"))
+	}
+
+	service.repository.End(ctx, tx, impersonation.Id, adminId)
+
+	endedImpersonation := service.repository.FindById(ctx, tx, impersonation.Id)
+
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionImpersonationEnd, audit.TargetImpersonation, impersonation.Id, ToImpersonationResponse(impersonation), ToImpersonationResponse(endedImpersonation)))
+}
+
+func (service *ImpersonationServiceImpl) FindAll(ctx context.Context, limit, offset int, isAdmin bool) ([]ImpersonationResponse, int) {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can get impersonations"))
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	impersonations := service.repository.FindAll(ctx, tx, limit, offset)
+	countImpersonations := service.repository.CountAll(ctx, tx)
+
+	var impersonationsData []ImpersonationResponse
+
+	for _, impersonation := range impersonations {
+		impersonationsData = append(impersonationsData, ToImpersonationResponse(impersonation))
+	}
+
+	return impersonationsData, countImpersonations
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/hutamatr/GoBlogify/database"
@@ -28,17 +29,50 @@ func main() {
 	commentController := utils.InitializedCommentController(db, helpers.Validate)
 	categoryController := utils.InitializedCategoryController(db, helpers.Validate)
 	followController := utils.InitializedFollowController(db)
+	exportController := utils.InitializedExportController(db)
+	erasureController := utils.InitializedErasureController(db)
+	suspensionController := utils.InitializedSuspensionController(db, helpers.Validate)
+	auditController := utils.InitializedAuditController(db)
+	statsController := utils.InitializedStatsController(db, helpers.Validate)
+	reportController := utils.InitializedReportController(db, helpers.Validate)
+	filterRuleController := utils.InitializedFilterRuleController(db, helpers.Validate)
+	bulkController := utils.InitializedBulkController(db, helpers.Validate)
+	impersonationController := utils.InitializedImpersonationController(db, helpers.Validate)
+	revisionPolicyController := utils.InitializedRevisionPolicyController(db, helpers.Validate)
+	tagController := utils.InitializedTagController(db, helpers.Validate)
+	searchController := utils.InitializedSearchController(db, helpers.Validate)
 
 	router := routes.Router(&routes.RouterControllers{
-		Admin:    adminController,
-		User:     userController,
-		Post:     postController,
-		Category: categoryController,
-		Role:     roleController,
-		Comment:  commentController,
-		Follow:   followController,
+		Admin:          adminController,
+		User:           userController,
+		Post:           postController,
+		Category:       categoryController,
+		Role:           roleController,
+		Comment:        commentController,
+		Follow:         followController,
+		Export:         exportController,
+		Erasure:        erasureController,
+		Suspension:     suspensionController,
+		Audit:          auditController,
+		Stats:          statsController,
+		Report:         reportController,
+		FilterRule:     filterRuleController,
+		Bulk:           bulkController,
+		Impersonation:  impersonationController,
+		RevisionPolicy: revisionPolicyController,
+		Tag:            tagController,
+		Search:         searchController,
 	})
 
+	postScheduler := utils.InitializedPostScheduler(db)
+	go postScheduler.Start(context.Background())
+
+	accountPurger := utils.InitializedAccountPurger(db)
+	go accountPurger.Start(context.Background())
+
+	exportCleaner := utils.InitializedExportCleaner(db)
+	go exportCleaner.Start(context.Background())
+
 	cors := helpers.Cors()
 	corsHandler := cors.Handler(router)
 
@@ -0,0 +1,18 @@
+package markup
+
+const (
+	FormatPlain    = "plain"
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+type Heading struct {
+	Level int    `json:"level"`
+	Id    string `json:"id"`
+	Text  string `json:"text"`
+}
+
+type Result struct {
+	Html string
+	Toc  []Heading
+}
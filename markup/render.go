@@ -0,0 +1,138 @@
+package markup
+
+import (
+	"bytes"
+	"encoding/json"
+	"html"
+	"regexp"
+	"strings"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+)
+
+// Code blocks are highlighted with CSS classes rather than inline styles so
+// the sanitiser never has to allow the style attribute.
+var markdown = goldmark.New(
+	goldmark.WithExtensions(
+		extension.GFM,
+		highlighting.NewHighlighting(
+			highlighting.WithFormatOptions(chromahtml.WithClasses(true)),
+		),
+	),
+	goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+)
+
+var policy = newPolicy()
+
+func newPolicy() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^[a-zA-Z0-9 _-]+$`)).OnElements("pre", "code", "span", "div")
+	policy.AllowAttrs("id").Matching(regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	policy.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	policy.AllowAttrs("checked", "disabled").OnElements("input")
+
+	return policy
+}
+
+// Render turns a post body into sanitised HTML. Only Markdown bodies get a
+// table of contents, built from the same heading ids used as anchors.
+func Render(format, source string) Result {
+	switch format {
+	case FormatMarkdown:
+		return renderMarkdown(source)
+	case FormatHTML:
+		return Result{Html: policy.Sanitize(source)}
+	default:
+		return Result{Html: renderPlain(source)}
+	}
+}
+
+func renderMarkdown(source string) Result {
+	content := []byte(source)
+	document := markdown.Parser().Parse(text.NewReader(content))
+
+	var toc []Heading
+
+	err := ast.Walk(document, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := node.(*ast.Heading)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+
+		id, _ := heading.AttributeString("id")
+		idBytes, _ := id.([]byte)
+
+		toc = append(toc, Heading{
+			Level: heading.Level,
+			Id:    string(idBytes),
+			Text:  string(heading.Text(content)),
+		})
+
+		return ast.WalkSkipChildren, nil
+	})
+	helpers.PanicError(err, "failed to walk markdown headings")
+
+	var buffer bytes.Buffer
+
+	err = markdown.Renderer().Render(&buffer, content, document)
+	helpers.PanicError(err, "failed to render markdown")
+
+	return Result{
+		Html: policy.Sanitize(buffer.String()),
+		Toc:  toc,
+	}
+}
+
+// renderPlain keeps the author's line breaks, blank lines start a new
+// paragraph.
+func renderPlain(source string) string {
+	var builder strings.Builder
+
+	for _, paragraph := range regexp.MustCompile(`\r?\n\s*\r?\n`).Split(strings.TrimSpace(source), -1) {
+		if paragraph == "" {
+			continue
+		}
+
+		lines := strings.Split(strings.TrimSpace(paragraph), "\n")
+		for i, line := range lines {
+			lines[i] = html.EscapeString(strings.TrimRight(line, "\r"))
+		}
+
+		builder.WriteString("<p>" + strings.Join(lines, "<br>\n") + "</p>\n")
+	}
+
+	return builder.String()
+}
+
+func TocJSON(toc []Heading) string {
+	if len(toc) == 0 {
+		return ""
+	}
+
+	data, err := json.Marshal(toc)
+	helpers.PanicError(err, "failed to marshal table of contents")
+
+	return string(data)
+}
+
+func ParseToc(data string) []Heading {
+	if data == "" {
+		return nil
+	}
+
+	var toc []Heading
+
+	err := json.Unmarshal([]byte(data), &toc)
+	helpers.PanicError(err, "failed to unmarshal table of contents")
+
+	return toc
+}
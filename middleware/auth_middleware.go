@@ -3,8 +3,10 @@ package middleware
 import (
 	"database/sql"
 	"net/http"
+	"strconv"
 	"strings"
 
+	"github.com/hutamatr/GoBlogify/audit"
 	"github.com/hutamatr/GoBlogify/database"
 	"github.com/hutamatr/GoBlogify/helpers"
 )
@@ -22,6 +24,11 @@ var publicRoutes = []string{
 	"/api/v1/refresh",
 }
 
+var publicPrefixRoutes = []string{
+	"/api/v1/@",
+	"/api/v1/exports/download/",
+}
+
 func NewAuthMiddleware(handler http.Handler) *AuthMiddleware {
 	return &AuthMiddleware{Handler: handler}
 }
@@ -31,6 +38,11 @@ func (middleware *AuthMiddleware) ServeHTTP(writer http.ResponseWriter, request
 	tokenSecret := env.SecretToken.AccessSecret
 	path := request.URL.Path
 
+	request.Header.Del("isAdmin")
+	request.Header.Del("userId")
+	request.Header.Del("isModerator")
+	request.Header.Del("impersonatorId")
+
 	for _, publicRoute := range publicRoutes {
 		if publicRoute == path {
 			middleware.Handler.ServeHTTP(writer, request)
@@ -38,6 +50,13 @@ func (middleware *AuthMiddleware) ServeHTTP(writer http.ResponseWriter, request
 		}
 	}
 
+	for _, publicPrefixRoute := range publicPrefixRoutes {
+		if strings.HasPrefix(path, publicPrefixRoute) {
+			middleware.Handler.ServeHTTP(writer, request)
+			return
+		}
+	}
+
 	authorizationHeader := request.Header.Get("Authorization")
 
 	tokenString := strings.TrimSpace(strings.Replace(authorizationHeader, "Bearer ", "", 1))
@@ -80,18 +99,140 @@ func (middleware *AuthMiddleware) ServeHTTP(writer http.ResponseWriter, request
 	db := database.ConnectDB()
 	defer db.Close()
 
-	queryUserRole := "SELECT role_id FROM user WHERE id = ?"
+	queryUserRole := "SELECT role_id, is_deleted, is_deactivated, tokens_revoked_at FROM user WHERE id = ?"
 	rows, err := db.Query(queryUserRole, id)
 	helpers.PanicError(err, "failed to query user role")
 
 	defer rows.Close()
 	var userRoleId int
+	var isDeleted bool
+	var isDeactivated bool
+	var revokedAt sql.NullTime
+
+	found := rows.Next()
 
-	if rows.Next() {
-		err = rows.Scan(&userRoleId)
+	if found {
+		err = rows.Scan(&userRoleId, &isDeleted, &isDeactivated, &revokedAt)
 		helpers.PanicError(err, "failed to scan user role")
 	}
 
+	if !found || isDeleted {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusUnauthorized)
+
+		ErrResponse := helpers.ErrorResponseJSON{
+			Code:    http.StatusUnauthorized,
+			Status:  "Unauthorized",
+			Error:   "account not found",
+			Message: "account does not exist or has been deleted",
+		}
+
+		helpers.EncodeJSONFromResponse(writer, ErrResponse)
+		return
+	}
+
+	if isDeactivated {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusUnauthorized)
+
+		ErrResponse := helpers.ErrorResponseJSON{
+			Code:    http.StatusUnauthorized,
+			Status:  "Unauthorized",
+			Error:   "account is deactivated",
+			Message: "account is deactivated, sign in again to reactivate it",
+		}
+
+		helpers.EncodeJSONFromResponse(writer, ErrResponse)
+		return
+	}
+
+	if revokedAt.Valid && helpers.IsTokenRevoked(helpers.TokenIssuedAt(claims), revokedAt.Time) {
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusUnauthorized)
+
+		ErrResponse := helpers.ErrorResponseJSON{
+			Code:    http.StatusUnauthorized,
+			Status:  "Unauthorized",
+			Error:   "token has been revoked",
+			Message: "token has been revoked, please login again",
+		}
+
+		helpers.EncodeJSONFromResponse(writer, ErrResponse)
+		return
+	}
+
+	querySuspension := "SELECT reason, expires_at FROM user_suspension WHERE user_id = ? AND lifted_at IS NULL AND (expires_at IS NULL OR expires_at > NOW()) ORDER BY expires_at IS NULL DESC, expires_at DESC LIMIT 1"
+	rowsSuspension, err := db.Query(querySuspension, id)
+	helpers.PanicError(err, "failed to query user suspension")
+
+	defer rowsSuspension.Close()
+
+	if rowsSuspension.Next() {
+		var reason string
+		var expiresAt sql.NullTime
+
+		err = rowsSuspension.Scan(&reason, &expiresAt)
+		helpers.PanicError(err, "failed to scan user suspension")
+
+		suspensionMessage := helpers.SuspensionMessage(reason, expiresAt.Time)
+
+		writer.Header().Set("Content-Type", "application/json")
+		writer.WriteHeader(http.StatusUnauthorized)
+
+		ErrResponse := helpers.ErrorResponseJSON{
+			Code:    http.StatusUnauthorized,
+			Status:  "Unauthorized",
+			Error:   suspensionMessage,
+			Message: suspensionMessage,
+		}
+
+		helpers.EncodeJSONFromResponse(writer, ErrResponse)
+		return
+	}
+
+	actorId := id
+	impersonatorId, sessionId, isImpersonation := helpers.ImpersonationClaims(claims)
+
+	if isImpersonation {
+		if !isImpersonationActive(db, sessionId, impersonatorId, id) {
+			writer.Header().Set("Content-Type", "application/json")
+			writer.WriteHeader(http.StatusUnauthorized)
+
+			ErrResponse := helpers.ErrorResponseJSON{
+				Code:    http.StatusUnauthorized,
+				Status:  "Unauthorized",
+				Error:   "impersonation has ended",
+				Message: "impersonation has ended, start a new one to continue",
+			}
+
+			helpers.EncodeJSONFromResponse(writer, ErrResponse)
+			return
+		}
+
+		actorId = impersonatorId
+		blocked := isImpersonationBlocked(request.Method, path)
+
+		auditImpersonatedRequest(audit.WithActor(request.Context(), audit.ActorFromRequest(request, actorId)), db, request, sessionId, id, blocked)
+
+		if blocked {
+			writer.Header().Set("Content-Type", "application/json")
+			writer.WriteHeader(http.StatusForbidden)
+
+			ErrResponse := helpers.ErrorResponseJSON{
+				Code:    http.StatusForbidden,
+				Status:  "Forbidden",
+				Error:   "action is not allowed while impersonating",
+				Message: "action is not allowed while impersonating a user",
+			}
+
+			helpers.EncodeJSONFromResponse(writer, ErrResponse)
+			return
+		}
+
+		request.Header.Set("impersonatorId", strconv.Itoa(impersonatorId))
+		writer.Header().Set("X-Impersonated-By", strconv.Itoa(impersonatorId))
+	}
+
 	queryRole := "SELECT id FROM role WHERE name = ?"
 	rows2, err := db.Query(queryRole, "admin")
 	helpers.PanicError(err, "failed to query role")
@@ -115,7 +256,25 @@ func (middleware *AuthMiddleware) ServeHTTP(writer http.ResponseWriter, request
 	if userRoleId == roleId {
 		isAdmin = "true"
 	}
+
+	queryModeratorRole := "SELECT id FROM role WHERE id = ? AND name = ?"
+	rows3, err := db.Query(queryModeratorRole, userRoleId, "moderator")
+	helpers.PanicError(err, "failed to query moderator role")
+
+	defer rows3.Close()
+
+	isModerator := isAdmin
+	if rows3.Next() {
+		isModerator = "true"
+	}
+
 	request.Header.Set("isAdmin", isAdmin)
+	request.Header.Set("isModerator", isModerator)
+	request.Header.Set("userId", strconv.Itoa(id))
+
+	// Under impersonation the admin is the actor, so anything the session
+	// changes is attributed to them in the audit log.
+	request = request.WithContext(audit.WithActor(request.Context(), audit.ActorFromRequest(request, actorId)))
 
 	middleware.Handler.ServeHTTP(writer, request)
 }
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"regexp"
+
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type blockedRoute struct {
+	method string
+	path   *regexp.Regexp
+}
+
+// impersonationBlockedRoutes are actions support staff must never take on a
+// user's behalf, whatever the user's own permissions allow.
+var impersonationBlockedRoutes = []blockedRoute{
+	{http.MethodDelete, regexp.MustCompile(`^/api/v1/users/[^/]+$`)},
+	{http.MethodPost, regexp.MustCompile(`^/api/v1/users/[^/]+/deactivate$`)},
+	{http.MethodPost, regexp.MustCompile(`^/api/v1/users/[^/]+/erasure$`)},
+	{http.MethodPost, regexp.MustCompile(`^/api/v1/admin/impersonations$`)},
+}
+
+func isImpersonationBlocked(method, path string) bool {
+	for _, route := range impersonationBlockedRoutes {
+		if route.method == method && route.path.MatchString(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isImpersonationActive(db *sql.DB, sessionId, adminId, userId int) bool {
+	query := "SELECT EXISTS(SELECT 1 FROM impersonation WHERE id = ? AND admin_id = ? AND user_id = ? AND ended_at IS NULL AND expires_at > NOW())"
+
+	var active bool
+	err := db.QueryRow(query, sessionId, adminId, userId).Scan(&active)
+	helpers.PanicError(err, "failed to query impersonation")
+
+	return active
+}
+
+// auditImpersonatedRequest records every request made with an impersonation
+// token, including the ones that get blocked, under the admin's id.
+func auditImpersonatedRequest(ctx context.Context, db *sql.DB, request *http.Request, sessionId, userId int, blocked bool) {
+	tx, err := db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	details := map[string]interface{}{
+		"impersonation_id": sessionId,
+		"method":           request.Method,
+		"path":             request.URL.RequestURI(),
+		"blocked":          blocked,
+	}
+
+	audit.NewAuditRepository().Save(ctx, tx, audit.NewEntry(ctx, audit.ActionImpersonationRequest, audit.TargetUser, userId, nil, details))
+}
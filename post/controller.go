@@ -2,9 +2,12 @@ package post
 
 import (
 	"net/http"
+	"net/url"
 	"strconv"
 
+	"github.com/hutamatr/GoBlogify/exception"
 	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/reaction"
 	"github.com/julienschmidt/httprouter"
 )
 
@@ -15,6 +18,22 @@ type PostController interface {
 	FindByIdPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	UpdatePostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	DeletePostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAllPendingPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ApprovePostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	RejectPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	UpdateCommentPolicyHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindBySlugPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAllScheduledPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ReschedulePostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAllRevisionPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	DiffRevisionPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	RestoreRevisionPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAllPostByTagHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAllPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAllDraftPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ReactPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	UnreactPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAllReactionPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 }
 
 type PostControllerImpl struct {
@@ -31,6 +50,8 @@ func (controller *PostControllerImpl) CreatePostHandler(writer http.ResponseWrit
 	var postRequest PostCreateRequest
 	helpers.DecodeJSONFromRequest(request, &postRequest)
 
+	postRequest.User_Id = helpers.GetUserId(request)
+
 	post := controller.service.Create(request.Context(), postRequest)
 
 	postResponse := helpers.ResponseJSON{
@@ -49,7 +70,10 @@ func (controller *PostControllerImpl) FindAllPostByUserHandler(writer http.Respo
 	helpers.PanicError(err, "Invalid User Id")
 	limit, offset := helpers.GetLimitOffset(request)
 
-	posts, countPosts := controller.service.FindAllByUser(request.Context(), userId, limit, offset)
+	callerId := helpers.GetUserId(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	posts, countPosts := controller.service.FindAllByUser(request.Context(), userId, callerId, isAdmin, limit, offset)
 
 	postResponse := helpers.ResponseJSON{
 		Code:   http.StatusOK,
@@ -72,7 +96,9 @@ func (controller *PostControllerImpl) FindAllPostByFollowedHandler(writer http.R
 	helpers.PanicError(err, "Invalid User Id")
 	limit, offset := helpers.GetLimitOffset(request)
 
-	postsByFollowed, countPosts := controller.service.FindAllByFollowed(request.Context(), userId, limit, offset)
+	callerId := helpers.GetUserId(request)
+
+	postsByFollowed, countPosts := controller.service.FindAllByFollowed(request.Context(), userId, callerId, limit, offset)
 
 	postResponse := helpers.ResponseJSON{
 		Code:   http.StatusOK,
@@ -95,7 +121,36 @@ func (controller *PostControllerImpl) FindByIdPostHandler(writer http.ResponseWr
 
 	helpers.PanicError(err, "Invalid Post Id")
 
-	post := controller.service.FindById(request.Context(), postId)
+	userId := helpers.GetUserId(request)
+	isModerator := helpers.IsModerator(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	post := controller.service.FindById(request.Context(), postId, userId, isModerator, isAdmin)
+
+	postResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   post,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, postResponse)
+}
+
+func (controller *PostControllerImpl) FindBySlugPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	username := params.ByName("username")
+	slug := params.ByName("slug")
+
+	userId := helpers.GetUserId(request)
+	isModerator := helpers.IsModerator(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	post, currentSlug := controller.service.FindBySlug(request.Context(), username, slug, userId, isModerator, isAdmin)
+
+	if currentSlug != "" {
+		http.Redirect(writer, request, "/api/v1/posts/by-slug/"+url.PathEscape(username)+"/"+currentSlug, http.StatusMovedPermanently)
+		return
+	}
 
 	postResponse := helpers.ResponseJSON{
 		Code:   http.StatusOK,
@@ -114,12 +169,14 @@ func (controller *PostControllerImpl) UpdatePostHandler(writer http.ResponseWrit
 	helpers.PanicError(err, "Invalid Post Id")
 
 	var postUpdateRequest PostUpdateRequest
+	helpers.DecodeJSONFromRequest(request, &postUpdateRequest)
 
 	postUpdateRequest.Id = postId
+	postUpdateRequest.User_Id = helpers.GetUserId(request)
+	isModerator := helpers.IsModerator(request)
+	isAdmin := helpers.IsAdmin(request)
 
-	helpers.DecodeJSONFromRequest(request, &postUpdateRequest)
-
-	updatedPost := controller.service.Update(request.Context(), postUpdateRequest)
+	updatedPost := controller.service.Update(request.Context(), postUpdateRequest, isModerator, isAdmin)
 
 	postResponse := helpers.ResponseJSON{
 		Code:   http.StatusOK,
@@ -147,3 +204,419 @@ func (controller *PostControllerImpl) DeletePostHandler(writer http.ResponseWrit
 	writer.WriteHeader(http.StatusOK)
 	helpers.EncodeJSONFromResponse(writer, postResponse)
 }
+
+func (controller *PostControllerImpl) FindAllPendingPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	isModerator := helpers.IsModerator(request)
+	limit, offset := helpers.GetLimitOffset(request)
+
+	posts, countPosts := controller.service.FindAllPending(request.Context(), limit, offset, isModerator)
+
+	postResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"posts":  posts,
+			"limit":  limit,
+			"offset": offset,
+			"total":  countPosts,
+		},
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, postResponse)
+}
+
+func (controller *PostControllerImpl) ApprovePostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	moderationRequest := controller.moderationRequest(request, params)
+	isModerator := helpers.IsModerator(request)
+
+	post := controller.service.Approve(request.Context(), moderationRequest, isModerator)
+
+	postResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "UPDATED",
+		Data:   post,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, postResponse)
+}
+
+func (controller *PostControllerImpl) RejectPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	moderationRequest := controller.moderationRequest(request, params)
+	isModerator := helpers.IsModerator(request)
+
+	post := controller.service.Reject(request.Context(), moderationRequest, isModerator)
+
+	postResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "UPDATED",
+		Data:   post,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, postResponse)
+}
+
+func (controller *PostControllerImpl) UpdateCommentPolicyHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("postId")
+	postId, err := strconv.Atoi(id)
+
+	helpers.PanicError(err, "Invalid Post Id")
+
+	var policyRequest PostCommentPolicyRequest
+	helpers.DecodeJSONFromRequest(request, &policyRequest)
+
+	policyRequest.Id = postId
+	policyRequest.User_Id = helpers.GetUserId(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	post := controller.service.UpdateCommentPolicy(request.Context(), policyRequest, isAdmin)
+
+	postResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "UPDATED",
+		Data:   post,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, postResponse)
+}
+
+func (controller *PostControllerImpl) FindAllScheduledPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("userId")
+	userId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid User Id")
+	limit, offset := helpers.GetLimitOffset(request)
+
+	callerId := helpers.GetUserId(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	posts, countPosts := controller.service.FindAllScheduled(request.Context(), userId, callerId, isAdmin, limit, offset)
+
+	postResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"posts":  posts,
+			"limit":  limit,
+			"offset": offset,
+			"total":  countPosts,
+		},
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, postResponse)
+}
+
+func (controller *PostControllerImpl) FindAllDraftPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("userId")
+	userId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid User Id")
+	limit, offset := helpers.GetLimitOffset(request)
+
+	callerId := helpers.GetUserId(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	posts, countPosts := controller.service.FindAllDrafts(request.Context(), userId, callerId, isAdmin, limit, offset)
+
+	postResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"posts":  posts,
+			"limit":  limit,
+			"offset": offset,
+			"total":  countPosts,
+		},
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, postResponse)
+}
+
+func (controller *PostControllerImpl) ReschedulePostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("postId")
+	postId, err := strconv.Atoi(id)
+
+	helpers.PanicError(err, "Invalid Post Id")
+
+	var scheduleRequest PostScheduleRequest
+	helpers.DecodeJSONFromRequest(request, &scheduleRequest)
+
+	scheduleRequest.Id = postId
+	scheduleRequest.User_Id = helpers.GetUserId(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	post := controller.service.Reschedule(request.Context(), scheduleRequest, isAdmin)
+
+	postResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "UPDATED",
+		Data:   post,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, postResponse)
+}
+
+func (controller *PostControllerImpl) FindAllRevisionPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("postId")
+	postId, err := strconv.Atoi(id)
+
+	helpers.PanicError(err, "Invalid Post Id")
+
+	userId := helpers.GetUserId(request)
+	isModerator := helpers.IsModerator(request)
+	limit, offset := helpers.GetLimitOffset(request)
+
+	revisions, countRevisions := controller.service.FindAllRevisions(request.Context(), postId, userId, isModerator, limit, offset)
+
+	postResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"revisions": revisions,
+			"limit":     limit,
+			"offset":    offset,
+			"total":     countRevisions,
+		},
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, postResponse)
+}
+
+func (controller *PostControllerImpl) DiffRevisionPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	postId, err := strconv.Atoi(params.ByName("postId"))
+	helpers.PanicError(err, "Invalid Post Id")
+
+	from, err := strconv.Atoi(params.ByName("revisionNumber"))
+	helpers.PanicError(err, "Invalid Revision Number")
+
+	to, err := strconv.Atoi(params.ByName("otherNumber"))
+	helpers.PanicError(err, "Invalid Revision Number")
+
+	userId := helpers.GetUserId(request)
+	isModerator := helpers.IsModerator(request)
+
+	diff := controller.service.DiffRevisions(request.Context(), postId, from, to, userId, isModerator)
+
+	postResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   diff,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, postResponse)
+}
+
+func (controller *PostControllerImpl) RestoreRevisionPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	postId, err := strconv.Atoi(params.ByName("postId"))
+	helpers.PanicError(err, "Invalid Post Id")
+
+	revisionNumber, err := strconv.Atoi(params.ByName("revisionNumber"))
+	helpers.PanicError(err, "Invalid Revision Number")
+
+	restoreRequest := PostRevisionRestoreRequest{
+		Id:              postId,
+		Revision_Number: revisionNumber,
+		User_Id:         helpers.GetUserId(request),
+	}
+	isAdmin := helpers.IsAdmin(request)
+
+	post := controller.service.RestoreRevision(request.Context(), restoreRequest, isAdmin)
+
+	postResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "UPDATED",
+		Data:   post,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, postResponse)
+}
+
+func (controller *PostControllerImpl) FindAllPostByTagHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	tagName := params.ByName("tagName")
+	limit, offset := helpers.GetLimitOffset(request)
+
+	callerId := helpers.GetUserId(request)
+
+	posts, countPosts := controller.service.FindAllByTag(request.Context(), tagName, callerId, limit, offset)
+
+	postResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"posts":  posts,
+			"limit":  limit,
+			"offset": offset,
+			"total":  countPosts,
+		},
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, postResponse)
+}
+
+func (controller *PostControllerImpl) FindAllPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	limit, offset := helpers.GetLimitOffset(request)
+	filter := postFilterFromRequest(request)
+
+	posts, countPosts := controller.service.FindAll(request.Context(), filter, limit, offset)
+
+	postResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"posts":  posts,
+			"limit":  limit,
+			"offset": offset,
+			"total":  countPosts,
+		},
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, postResponse)
+}
+
+func (controller *PostControllerImpl) ReactPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("postId")
+	postId, err := strconv.Atoi(id)
+
+	helpers.PanicError(err, "Invalid Post Id")
+
+	var reactionRequest reaction.ReactionRequest
+	helpers.DecodeJSONFromRequest(request, &reactionRequest)
+
+	reactionRequest.Post_Id = postId
+	reactionRequest.User_Id = helpers.GetUserId(request)
+	isModerator := helpers.IsModerator(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	post := controller.service.React(request.Context(), reactionRequest, isModerator, isAdmin)
+
+	postResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "UPDATED",
+		Data:   post,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, postResponse)
+}
+
+func (controller *PostControllerImpl) UnreactPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("postId")
+	postId, err := strconv.Atoi(id)
+
+	helpers.PanicError(err, "Invalid Post Id")
+
+	userId := helpers.GetUserId(request)
+	isModerator := helpers.IsModerator(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	post := controller.service.Unreact(request.Context(), postId, userId, isModerator, isAdmin)
+
+	postResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "DELETED",
+		Data:   post,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, postResponse)
+}
+
+func (controller *PostControllerImpl) FindAllReactionPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("postId")
+	postId, err := strconv.Atoi(id)
+
+	helpers.PanicError(err, "Invalid Post Id")
+
+	userId := helpers.GetUserId(request)
+	isModerator := helpers.IsModerator(request)
+	isAdmin := helpers.IsAdmin(request)
+	limit, offset := helpers.GetLimitOffset(request)
+	filter := request.URL.Query().Get("reaction")
+
+	reactions, countReactions := controller.service.FindAllReactions(request.Context(), postId, userId, filter, isModerator, isAdmin, limit, offset)
+
+	postResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"reactions": reactions,
+			"limit":     limit,
+			"offset":    offset,
+			"total":     countReactions,
+		},
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, postResponse)
+}
+
+func postFilterFromRequest(request *http.Request) PostFilterRequest {
+	query := request.URL.Query()
+
+	categoryId, err := parseIdQuery(query.Get("category_id"))
+	if err != nil {
+		panic(exception.NewBadRequestError("invalid category_id"))
+	}
+
+	authorId, err := parseIdQuery(query.Get("author_id"))
+	if err != nil {
+		panic(exception.NewBadRequestError("invalid author_id"))
+	}
+
+	from, err := helpers.ParseDateQuery(query.Get("from"))
+	if err != nil {
+		panic(exception.NewBadRequestError("invalid from, use YYYY-MM-DD"))
+	}
+
+	to, err := helpers.ParseDateQuery(query.Get("to"))
+	if err != nil {
+		panic(exception.NewBadRequestError("invalid to, use YYYY-MM-DD"))
+	}
+
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1)
+	}
+
+	return PostFilterRequest{
+		Caller_Id:   helpers.GetUserId(request),
+		Category_Id: categoryId,
+		Author_Id:   authorId,
+		From:        from,
+		To:          to,
+		Sort:        query.Get("sort"),
+	}
+}
+
+func parseIdQuery(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func (controller *PostControllerImpl) moderationRequest(request *http.Request, params httprouter.Params) PostModerationRequest {
+	id := params.ByName("postId")
+	postId, err := strconv.Atoi(id)
+
+	helpers.PanicError(err, "Invalid Post Id")
+
+	var moderationRequest PostModerationRequest
+
+	if request.ContentLength != 0 {
+		helpers.DecodeJSONFromRequest(request, &moderationRequest)
+	}
+
+	moderationRequest.Id = postId
+	moderationRequest.Moderator_Id = helpers.GetUserId(request)
+
+	return moderationRequest
+}
@@ -4,20 +4,55 @@ import (
 	"time"
 
 	"github.com/hutamatr/GoBlogify/category"
+	"github.com/hutamatr/GoBlogify/reaction"
 	"github.com/hutamatr/GoBlogify/user"
 )
 
+const (
+	ModerationPending  = "pending"
+	ModerationApproved = "approved"
+	ModerationRejected = "rejected"
+)
+
+const (
+	VisibilityPublic    = "public"
+	VisibilityFollowers = "followers"
+	VisibilityUnlisted  = "unlisted"
+	VisibilityPrivate   = "private"
+)
+
+const (
+	SortNewest        = "newest"
+	SortOldest        = "oldest"
+	SortMostCommented = "most_commented"
+)
+
+const (
+	CommentPolicyOpen             = "open"
+	CommentPolicyApproveFollowers = "approve_followers"
+	CommentPolicyHoldAll          = "hold_all"
+	CommentPolicyHoldLinks        = "hold_links"
+)
+
 type Post struct {
-	Id          int
-	User_Id     int
-	Category_Id int
-	Title       string
-	Body        string
-	Published   bool
-	Deleted     bool
-	Created_At  time.Time
-	Updated_At  time.Time
-	Deleted_At  time.Time
+	Id                int
+	User_Id           int
+	Category_Id       int
+	Title             string
+	Slug              string
+	Body              string
+	Body_Format       string
+	Body_Html         string
+	Body_Toc          string
+	Published         bool
+	Visibility        string
+	Deleted           bool
+	Moderation_Status string
+	Publish_At        time.Time
+	Unpublish_At      time.Time
+	Created_At        time.Time
+	Updated_At        time.Time
+	Deleted_At        time.Time
 }
 
 type PostCreateOrUpdate struct {
@@ -31,28 +66,60 @@ type PostCreateOrUpdate struct {
 }
 
 type PostJoin struct {
-	Id         int
-	Title      string
-	Body       string
-	Published  bool
-	Deleted    bool
-	Created_At time.Time
-	Updated_At time.Time
-	Deleted_At time.Time
-	User       user.UserJoin
-	Category   category.Category
+	Id                int
+	Title             string
+	Slug              string
+	Body              string
+	Body_Format       string
+	Body_Html         string
+	Body_Toc          string
+	Published         bool
+	Visibility        string
+	Deleted           bool
+	Moderation_Status string
+	Comment_Policy    string
+	Moderation_Note   string
+	Moderated_By      int
+	Moderated_At      time.Time
+	Publish_At        time.Time
+	Unpublish_At      time.Time
+	Created_At        time.Time
+	Updated_At        time.Time
+	Deleted_At        time.Time
+	Tags              []string
+	Reactions         reaction.Summary
+	User              user.UserJoin
+	Category          category.Category
 }
 
 type PostJoinFollowed struct {
-	Id          int
-	User_Id     int
-	Category_Id int
-	Title       string
-	Body        string
-	Published   bool
-	Deleted     bool
-	Created_At  time.Time
-	Updated_At  time.Time
-	Deleted_At  time.Time
-	User        user.UserJoin
+	Id                int
+	User_Id           int
+	Category_Id       int
+	Title             string
+	Slug              string
+	Body              string
+	Body_Format       string
+	Body_Html         string
+	Body_Toc          string
+	Published         bool
+	Visibility        string
+	Deleted           bool
+	Moderation_Status string
+	Comment_Policy    string
+	Publish_At        time.Time
+	Unpublish_At      time.Time
+	Created_At        time.Time
+	Updated_At        time.Time
+	Deleted_At        time.Time
+	Tags              []string
+	Reactions         reaction.Summary
+	User              user.UserJoin
+}
+
+type SlugHistory struct {
+	Post_Id    int
+	User_Id    int
+	Slug       string
+	Created_At time.Time
 }
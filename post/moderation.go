@@ -0,0 +1,78 @@
+package post
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/hutamatr/GoBlogify/contentfilter"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/user"
+)
+
+// moderationPolicy decides the moderation status of posts. The service and
+// the scheduler share it, so a post that goes live on schedule is held to
+// the same rules as one published by hand.
+type moderationPolicy struct {
+	repository     PostRepository
+	userRepository user.UserRepository
+	pipeline       contentfilter.Pipeline
+}
+
+// status decides whether a post goes out straight away once it is live or
+// waits in the pre-moderation queue. Drafts get the status they would get if
+// they were published, so publishing them later cannot skip the queue. Edits
+// by authors who are still new send the post back to the queue, and posts
+// flagged by the content filter are queued whoever wrote them.
+func (policy moderationPolicy) status(ctx context.Context, tx *sql.Tx, authorId int, flagged bool) string {
+	trust := policy.promoteIfEarned(ctx, tx, authorId)
+
+	if trust.BypassesModeration() && !flagged {
+		return ModerationApproved
+	}
+
+	return ModerationPending
+}
+
+// liveStatus checks a draft or scheduled post again as it goes live without
+// an edit, since filter rules may have been added after it was saved. A
+// rejected post stays rejected, and one a moderator approved stays approved
+// unless a rule now flags it.
+func (policy moderationPolicy) liveStatus(ctx context.Context, tx *sql.Tx, post PostJoin) string {
+	flagged := false
+
+	for _, text := range []string{post.Title, post.Body} {
+		result := policy.pipeline.Run(ctx, tx, text)
+		flagged = flagged || result.Rejected || result.Moderate
+	}
+
+	if post.Moderation_Status == ModerationRejected {
+		return ModerationRejected
+	}
+
+	if post.Moderation_Status == ModerationApproved && post.Moderated_By != 0 && !flagged {
+		return ModerationApproved
+	}
+
+	return policy.status(ctx, tx, post.User.Id, flagged)
+}
+
+func (policy moderationPolicy) promoteIfEarned(ctx context.Context, tx *sql.Tx, authorId int) user.Trust {
+	trust := policy.userRepository.FindTrust(ctx, tx, authorId)
+
+	if trust.Level != user.TrustNew {
+		return trust
+	}
+
+	env := helpers.NewEnv()
+	minApprovedPosts := helpers.EnvInt(env.Trust.PromotionApprovedPosts, 3)
+	minAccountDays := helpers.EnvInt(env.Trust.PromotionAccountDays, 30)
+	approvedPosts := policy.repository.CountApprovedByUser(ctx, tx, authorId)
+
+	if trust.EarnedPromotion(approvedPosts, minApprovedPosts, minAccountDays, time.Now()) {
+		policy.userRepository.UpdateTrustLevel(ctx, tx, authorId, user.TrustTrusted)
+		trust.Level = user.TrustTrusted
+	}
+
+	return trust
+}
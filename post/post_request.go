@@ -1,19 +1,66 @@
 package post
 
+import "time"
+
 type PostCreateRequest struct {
-	Title       string `json:"title" validate:"required,min=1,max=255"`
-	Body        string `json:"body" validate:"required,min=1,max=1000"`
-	Published   bool   `json:"published" validate:"required"`
-	User_Id     int    `json:"user_id" validate:"required"`
-	Category_Id int    `json:"category_id" validate:"required"`
+	Title        string    `json:"title" validate:"required,min=1,max=255"`
+	Slug         string    `json:"slug" validate:"omitempty,max=100"`
+	Body         string    `json:"body" validate:"required,min=1,max=1000"`
+	Body_Format  string    `json:"body_format" validate:"omitempty,oneof=plain markdown html"`
+	Published    bool      `json:"published"`
+	Visibility   string    `json:"visibility" validate:"omitempty,oneof=public followers unlisted private"`
+	Publish_At   time.Time `json:"publish_at"`
+	Unpublish_At time.Time `json:"unpublish_at"`
+	Tags         []string  `json:"tags" validate:"omitempty,max=10,dive,min=1,max=100"`
+	User_Id      int       `json:"user_id" validate:"required"`
+	Category_Id  int       `json:"category_id" validate:"required"`
 }
 
 type PostUpdateRequest struct {
-	Id          int    `json:"id" validate:"required"`
-	User_Id     int    `json:"user_id" validate:"required"`
-	Category_Id int    `json:"category_id"`
-	Title       string `json:"title" validate:"required,min=1,max=255"`
-	Body        string `json:"body" validate:"required,min=1,max=1000"`
-	Published   bool   `json:"published" validate:"required"`
-	Deleted     bool   `json:"deleted"`
+	Id           int       `json:"id" validate:"required"`
+	User_Id      int       `json:"-" validate:"required"`
+	Category_Id  int       `json:"category_id"`
+	Title        string    `json:"title" validate:"required,min=1,max=255"`
+	Slug         string    `json:"slug" validate:"omitempty,max=100"`
+	Body         string    `json:"body" validate:"required,min=1,max=1000"`
+	Body_Format  string    `json:"body_format" validate:"omitempty,oneof=plain markdown html"`
+	Published    bool      `json:"published"`
+	Visibility   string    `json:"visibility" validate:"omitempty,oneof=public followers unlisted private"`
+	Publish_At   time.Time `json:"publish_at"`
+	Unpublish_At time.Time `json:"unpublish_at"`
+	Tags         []string  `json:"tags" validate:"omitempty,max=10,dive,min=1,max=100"`
+}
+
+type PostFilterRequest struct {
+	Caller_Id   int
+	Category_Id int
+	Author_Id   int
+	From        time.Time
+	To          time.Time
+	Sort        string `validate:"omitempty,oneof=newest oldest most_commented"`
+}
+
+type PostModerationRequest struct {
+	Id           int    `json:"id" validate:"required"`
+	Moderator_Id int    `json:"moderator_id" validate:"required"`
+	Note         string `json:"note" validate:"max=1000"`
+}
+
+type PostCommentPolicyRequest struct {
+	Id             int    `json:"id" validate:"required"`
+	User_Id        int    `json:"user_id" validate:"required"`
+	Comment_Policy string `json:"comment_policy" validate:"required,oneof=open approve_followers hold_all hold_links"`
+}
+
+type PostScheduleRequest struct {
+	Id           int       `json:"id" validate:"required"`
+	User_Id      int       `json:"user_id" validate:"required"`
+	Publish_At   time.Time `json:"publish_at"`
+	Unpublish_At time.Time `json:"unpublish_at"`
+}
+
+type PostRevisionRestoreRequest struct {
+	Id              int `json:"id" validate:"required"`
+	Revision_Number int `json:"revision_number" validate:"required"`
+	User_Id         int `json:"user_id" validate:"required"`
 }
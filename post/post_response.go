@@ -4,59 +4,136 @@ import (
 	"time"
 
 	"github.com/hutamatr/GoBlogify/category"
+	"github.com/hutamatr/GoBlogify/markup"
+	"github.com/hutamatr/GoBlogify/reaction"
 	"github.com/hutamatr/GoBlogify/user"
 )
 
 type PostResponse struct {
-	Id         int                       `json:"id"`
-	Title      string                    `json:"title"`
-	Body       string                    `json:"body"`
-	Published  bool                      `json:"published"`
-	Deleted    bool                      `json:"deleted"`
-	Created_At time.Time                 `json:"created_at"`
-	Updated_At time.Time                 `json:"updated_at"`
-	Deleted_At time.Time                 `json:"deleted_at"`
-	User       user.UserResponse         `json:"user"`
-	Category   category.CategoryResponse `json:"category"`
+	Id                int                       `json:"id"`
+	Title             string                    `json:"title"`
+	Slug              string                    `json:"slug"`
+	Body              string                    `json:"body"`
+	Body_Format       string                    `json:"body_format"`
+	Body_Html         string                    `json:"body_html"`
+	Toc               []markup.Heading          `json:"toc"`
+	Published         bool                      `json:"published"`
+	Visibility        string                    `json:"visibility"`
+	Publish_At        time.Time                 `json:"publish_at"`
+	Unpublish_At      time.Time                 `json:"unpublish_at"`
+	Deleted           bool                      `json:"deleted"`
+	Moderation_Status string                    `json:"moderation_status"`
+	Moderation_Note   string                    `json:"moderation_note"`
+	Comment_Policy    string                    `json:"comment_policy"`
+	Created_At        time.Time                 `json:"created_at"`
+	Updated_At        time.Time                 `json:"updated_at"`
+	Deleted_At        time.Time                 `json:"deleted_at"`
+	Tags              []string                  `json:"tags"`
+	Reactions         map[string]int            `json:"reactions"`
+	My_Reaction       string                    `json:"my_reaction"`
+	User              user.UserResponse         `json:"user"`
+	Category          category.CategoryResponse `json:"category"`
 }
 
 func ToPostResponse(post PostJoin) PostResponse {
+	bodyHtml, toc := renderedBody(post.Body_Format, post.Body, post.Body_Html, post.Body_Toc)
+
 	return PostResponse{
-		Id:         post.Id,
-		Title:      post.Title,
-		Body:       post.Body,
-		Published:  post.Published,
-		Deleted:    post.Deleted,
-		Created_At: post.Created_At,
-		Updated_At: post.Updated_At,
-		Deleted_At: post.Deleted_At,
-		User:       user.ToUserResponse(post.User),
-		Category:   category.ToCategoryResponse(post.Category),
+		Id:                post.Id,
+		Title:             post.Title,
+		Slug:              post.Slug,
+		Body:              post.Body,
+		Body_Format:       post.Body_Format,
+		Body_Html:         bodyHtml,
+		Toc:               toc,
+		Published:         post.Published,
+		Visibility:        post.Visibility,
+		Publish_At:        post.Publish_At,
+		Unpublish_At:      post.Unpublish_At,
+		Deleted:           post.Deleted,
+		Moderation_Status: post.Moderation_Status,
+		Moderation_Note:   post.Moderation_Note,
+		Comment_Policy:    post.Comment_Policy,
+		Created_At:        post.Created_At,
+		Updated_At:        post.Updated_At,
+		Deleted_At:        post.Deleted_At,
+		Tags:              post.Tags,
+		Reactions:         reactionCounts(post.Reactions),
+		My_Reaction:       post.Reactions.Mine,
+		User:              user.ToUserResponse(post.User),
+		Category:          category.ToCategoryResponse(post.Category),
 	}
 }
 
 type PostResponseFollowed struct {
-	Id         int               `json:"id"`
-	Title      string            `json:"title"`
-	Body       string            `json:"body"`
-	Published  bool              `json:"published"`
-	Deleted    bool              `json:"deleted"`
-	Created_At time.Time         `json:"created_at"`
-	Updated_At time.Time         `json:"updated_at"`
-	Deleted_At time.Time         `json:"deleted_at"`
-	User       user.UserResponse `json:"user"`
+	Id                int               `json:"id"`
+	Title             string            `json:"title"`
+	Slug              string            `json:"slug"`
+	Body              string            `json:"body"`
+	Body_Format       string            `json:"body_format"`
+	Body_Html         string            `json:"body_html"`
+	Toc               []markup.Heading  `json:"toc"`
+	Published         bool              `json:"published"`
+	Visibility        string            `json:"visibility"`
+	Publish_At        time.Time         `json:"publish_at"`
+	Unpublish_At      time.Time         `json:"unpublish_at"`
+	Deleted           bool              `json:"deleted"`
+	Moderation_Status string            `json:"moderation_status"`
+	Comment_Policy    string            `json:"comment_policy"`
+	Created_At        time.Time         `json:"created_at"`
+	Updated_At        time.Time         `json:"updated_at"`
+	Deleted_At        time.Time         `json:"deleted_at"`
+	Tags              []string          `json:"tags"`
+	Reactions         map[string]int    `json:"reactions"`
+	My_Reaction       string            `json:"my_reaction"`
+	User              user.UserResponse `json:"user"`
 }
 
 func ToPostResponseFollowed(post PostJoinFollowed) PostResponseFollowed {
+	bodyHtml, toc := renderedBody(post.Body_Format, post.Body, post.Body_Html, post.Body_Toc)
+
 	return PostResponseFollowed{
-		Id:         post.Id,
-		Title:      post.Title,
-		Body:       post.Body,
-		Published:  post.Published,
-		Deleted:    post.Deleted,
-		Created_At: post.Created_At,
-		Updated_At: post.Updated_At,
-		Deleted_At: post.Deleted_At,
-		User:       user.ToUserResponse(post.User),
+		Id:                post.Id,
+		Title:             post.Title,
+		Slug:              post.Slug,
+		Body:              post.Body,
+		Body_Format:       post.Body_Format,
+		Body_Html:         bodyHtml,
+		Toc:               toc,
+		Published:         post.Published,
+		Visibility:        post.Visibility,
+		Publish_At:        post.Publish_At,
+		Unpublish_At:      post.Unpublish_At,
+		Deleted:           post.Deleted,
+		Moderation_Status: post.Moderation_Status,
+		Comment_Policy:    post.Comment_Policy,
+		Created_At:        post.Created_At,
+		Updated_At:        post.Updated_At,
+		Deleted_At:        post.Deleted_At,
+		Tags:              post.Tags,
+		Reactions:         reactionCounts(post.Reactions),
+		My_Reaction:       post.Reactions.Mine,
+		User:              user.ToUserResponse(post.User),
+	}
+}
+
+// reactionCounts keeps posts nobody reacted to as an empty object rather than
+// null.
+func reactionCounts(summary reaction.Summary) map[string]int {
+	if summary.Counts == nil {
+		return map[string]int{}
 	}
+
+	return summary.Counts
+}
+
+// renderedBody falls back to rendering on the fly for posts saved before
+// their HTML was cached.
+func renderedBody(format, body, bodyHtml, toc string) (string, []markup.Heading) {
+	if bodyHtml == "" && body != "" {
+		rendered := markup.Render(format, body)
+		return rendered.Html, rendered.Toc
+	}
+
+	return bodyHtml, markup.ParseToc(toc)
 }
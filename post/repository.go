@@ -3,25 +3,66 @@ package post
 import (
 	"context"
 	"database/sql"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hutamatr/GoBlogify/exception"
 	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/markup"
 )
 
 type PostRepository interface {
 	Save(ctx context.Context, tx *sql.Tx, post Post) PostJoin
-	FindAllByFollowed(ctx context.Context, tx *sql.Tx, userId, limit, offset int) []PostJoinFollowed
-	FindAllByUser(ctx context.Context, tx *sql.Tx, userId, limit, offset int) []PostJoin
+	FindAllByFollowed(ctx context.Context, tx *sql.Tx, userId, callerId, limit, offset int) []PostJoinFollowed
+	FindAllByUser(ctx context.Context, tx *sql.Tx, userId, callerId int, includeDrafts bool, limit, offset int) []PostJoin
 	FindById(ctx context.Context, tx *sql.Tx, postId int) PostJoin
 	Update(ctx context.Context, tx *sql.Tx, post Post) PostJoin
 	Delete(ctx context.Context, tx *sql.Tx, postId int)
-	CountPostsByUser(ctx context.Context, tx *sql.Tx, userId int) int
+	CountPostsByUser(ctx context.Context, tx *sql.Tx, userId, callerId int, includeDrafts bool) int
+	FindAllPending(ctx context.Context, tx *sql.Tx, limit, offset int) []PostJoin
+	CountPending(ctx context.Context, tx *sql.Tx) int
+	CountApprovedByUser(ctx context.Context, tx *sql.Tx, userId int) int
+	UpdateModeration(ctx context.Context, tx *sql.Tx, postId int, status string, moderatorId int, note string)
+	UpdateCommentPolicy(ctx context.Context, tx *sql.Tx, postId int, policy string)
+	LockAuthor(ctx context.Context, tx *sql.Tx, userId int)
+	IsSlugTaken(ctx context.Context, tx *sql.Tx, userId int, slug string, postId int) bool
+	UpdateSlug(ctx context.Context, tx *sql.Tx, postId int, slug string)
+	SaveSlugHistory(ctx context.Context, tx *sql.Tx, history SlugHistory)
+	DeleteSlugHistory(ctx context.Context, tx *sql.Tx, userId int, slug string)
+	FindIdBySlug(ctx context.Context, tx *sql.Tx, username, slug string) int
+	FindSlugRedirect(ctx context.Context, tx *sql.Tx, username, slug string) string
+	FindAllScheduledByUser(ctx context.Context, tx *sql.Tx, userId, limit, offset int) []PostJoin
+	CountScheduledByUser(ctx context.Context, tx *sql.Tx, userId int) int
+	UpdateSchedule(ctx context.Context, tx *sql.Tx, post Post)
+	FindDueToPublish(ctx context.Context, tx *sql.Tx, limit int) []int
+	FindDueToUnpublish(ctx context.Context, tx *sql.Tx, limit int) []int
+	PublishScheduled(ctx context.Context, tx *sql.Tx, postId int, moderationStatus string)
+	UnpublishScheduled(ctx context.Context, tx *sql.Tx, postId int)
+	FindAllByTag(ctx context.Context, tx *sql.Tx, tagName string, callerId, limit, offset int) []PostJoin
+	CountByTag(ctx context.Context, tx *sql.Tx, tagName string, callerId int) int
+	FindAll(ctx context.Context, tx *sql.Tx, filter PostFilterRequest, limit, offset int) []PostJoin
+	CountAll(ctx context.Context, tx *sql.Tx, filter PostFilterRequest) int
+	FindAllDraftsByUser(ctx context.Context, tx *sql.Tx, userId, limit, offset int) []PostJoin
+	CountDraftsByUser(ctx context.Context, tx *sql.Tx, userId int) int
+	IsFollower(ctx context.Context, tx *sql.Tx, userId, authorId int) bool
 }
 
 type PostRepositoryImpl struct {
 }
 
+// listedToCaller limits lists to live, approved posts of active authors that
+// the caller may discover: public posts, followers-only posts of authors they
+// follow and all of their own. Unlisted posts are only reachable by link. It
+// expects post p joined with user u and takes the caller id twice.
+const listedToCaller = `p.is_deleted = false AND p.is_hidden = false AND p.moderation_status = 'approved' 
+	AND u.is_deleted = false AND u.is_deactivated = false 
+	AND (p.visibility = 'public' OR p.user_id = ? OR (p.visibility = 'followers' AND EXISTS (SELECT 1 FROM follow vf WHERE vf.follower_id = ? AND vf.followed_id = p.user_id)))`
+
+// VisibleToCaller is listedToCaller for published posts only. Every query
+// listing posts to a caller, search included, filters with it.
+const VisibleToCaller = `p.is_published = true AND ` + listedToCaller
+
 func NewPostRepository() PostRepository {
 	return &PostRepositoryImpl{}
 }
@@ -30,9 +71,26 @@ func (repository *PostRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, post
 	ctxC, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	queryInsert := "INSERT INTO post(title, body, is_published, user_id, category_id) VALUES(?, ?, ?, ?, ?)"
+	moderationStatus := post.Moderation_Status
+	if moderationStatus == "" {
+		moderationStatus = ModerationApproved
+	}
+
+	bodyFormat := post.Body_Format
+	if bodyFormat == "" {
+		bodyFormat = markup.FormatPlain
+	}
+
+	visibility := post.Visibility
+	if visibility == "" {
+		visibility = VisibilityPublic
+	}
+
+	// Posts saved without a slug get a unique placeholder that is replaced by
+	// post-<id> once the id is known.
+	queryInsert := "INSERT INTO post(title, slug, body, body_format, body_html, body_toc, is_published, visibility, published_at, publish_at, unpublish_at, moderation_status, user_id, category_id) VALUES(?, COALESCE(NULLIF(?, ''), UUID()), ?, ?, NULLIF(?, ''), NULLIF(?, ''), ?, ?, IF(?, NOW(), NULL), ?, ?, ?, ?, ?)"
 
-	result, err := tx.ExecContext(ctxC, queryInsert, post.Title, post.Body, post.Published, post.User_Id, post.Category_Id)
+	result, err := tx.ExecContext(ctxC, queryInsert, post.Title, post.Slug, post.Body, bodyFormat, post.Body_Html, post.Body_Toc, post.Published, visibility, post.Published, nullTime(post.Publish_At), nullTime(post.Unpublish_At), moderationStatus, post.User_Id, post.Category_Id)
 
 	helpers.PanicError(err, "failed to exec query insert post")
 
@@ -40,16 +98,20 @@ func (repository *PostRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, post
 
 	helpers.PanicError(err, "failed to get last insert id post")
 
+	if post.Slug == "" {
+		repository.UpdateSlug(ctx, tx, int(id), "post-"+strconv.Itoa(int(id)))
+	}
+
 	createdPost := repository.FindById(ctx, tx, int(id))
 
 	return createdPost
 }
 
-func (repository *PostRepositoryImpl) FindAllByUser(ctx context.Context, tx *sql.Tx, userId, limit, offset int) []PostJoin {
+func (repository *PostRepositoryImpl) FindAllByUser(ctx context.Context, tx *sql.Tx, userId, callerId int, includeDrafts bool, limit, offset int) []PostJoin {
 
-	query := `SELECT p.id, p.title, p.body, p.created_at, p.updated_at, p.deleted_at, p.is_deleted, p.is_published, u.id, u.role_id, u.username, u.email, u.first_name, u.last_name, u.created_at, u.updated_at, u.deleted_at, 
-	(SELECT COUNT(*) FROM follow f WHERE f.followed_id = u.id) AS follower_count,
-	(SELECT COUNT(*) FROM follow f WHERE f.follower_id = u.id) AS following_count,
+	query := `SELECT p.id, p.title, p.slug, p.body, p.body_format, COALESCE(p.body_html, ''), COALESCE(p.body_toc, ''), p.created_at, p.updated_at, p.deleted_at, p.is_deleted, p.is_published, p.visibility, p.publish_at, p.unpublish_at, p.moderation_status, p.comment_policy, (SELECT GROUP_CONCAT(t.name ORDER BY t.name SEPARATOR ',') FROM post_tag pt JOIN tag t ON t.id = pt.tag_id WHERE pt.post_id = p.id) AS tags, u.id, u.role_id, u.username, u.email, u.first_name, u.last_name, u.created_at, u.updated_at, u.deleted_at, 
+	(SELECT COUNT(*) FROM follow f JOIN user fu ON fu.id = f.follower_id WHERE f.followed_id = u.id AND fu.is_deleted = false AND fu.is_deactivated = false) AS follower_count,
+	(SELECT COUNT(*) FROM follow f JOIN user fu ON fu.id = f.followed_id WHERE f.follower_id = u.id AND fu.is_deleted = false AND fu.is_deactivated = false) AS following_count,
 	c.id, c.name, c.created_at, c.updated_at 
 	FROM user u 
 	JOIN post p 
@@ -57,9 +119,10 @@ func (repository *PostRepositoryImpl) FindAllByUser(ctx context.Context, tx *sql
 	JOIN category c 
 	ON p.category_id = c.id 
 	WHERE p.user_id = ? 
-	AND p.is_deleted = false LIMIT ? OFFSET ?`
+	AND (p.is_published = true OR ?) 
+	AND ` + listedToCaller + ` LIMIT ? OFFSET ?`
 
-	rows, err := tx.QueryContext(ctx, query, userId, limit, offset)
+	rows, err := tx.QueryContext(ctx, query, userId, includeDrafts, callerId, callerId, limit, offset)
 
 	helpers.PanicError(err, "failed to query all posts")
 
@@ -68,17 +131,32 @@ func (repository *PostRepositoryImpl) FindAllByUser(ctx context.Context, tx *sql
 	var posts []PostJoin
 
 	var deletedAtPost sql.NullTime
+	var publishAt sql.NullTime
+	var unpublishAt sql.NullTime
 	var deletedAtUser sql.NullTime
 	var firstName sql.NullString
 	var lastName sql.NullString
+	var tags sql.NullString
 
 	for rows.Next() {
 		var post PostJoin
 
-		err := rows.Scan(&post.Id, &post.Title, &post.Body, &post.Created_At, &post.Updated_At, &deletedAtPost, &post.Deleted, &post.Published, &post.User.Id, &post.User.Role_Id, &post.User.Username, &post.User.Email, &firstName, &lastName, &post.User.Created_At, &post.User.Updated_At, &deletedAtUser, &post.User.Follower, &post.User.Following, &post.Category.Id, &post.Category.Name, &post.Category.Created_At, &post.Category.Updated_At)
+		err := rows.Scan(&post.Id, &post.Title, &post.Slug, &post.Body, &post.Body_Format, &post.Body_Html, &post.Body_Toc, &post.Created_At, &post.Updated_At, &deletedAtPost, &post.Deleted, &post.Published, &post.Visibility, &publishAt, &unpublishAt, &post.Moderation_Status, &post.Comment_Policy, &tags, &post.User.Id, &post.User.Role_Id, &post.User.Username, &post.User.Email, &firstName, &lastName, &post.User.Created_At, &post.User.Updated_At, &deletedAtUser, &post.User.Follower, &post.User.Following, &post.Category.Id, &post.Category.Name, &post.Category.Created_At, &post.Category.Updated_At)
 
 		helpers.PanicError(err, "failed to scan all posts")
 
+		post.Tags = splitTags(tags)
+
+		if publishAt.Valid {
+			post.Publish_At = publishAt.Time
+		} else {
+			post.Publish_At = time.Time{}
+		}
+		if unpublishAt.Valid {
+			post.Unpublish_At = unpublishAt.Time
+		} else {
+			post.Unpublish_At = time.Time{}
+		}
 		if deletedAtPost.Valid {
 			post.Deleted_At = deletedAtPost.Time
 		} else {
@@ -106,21 +184,21 @@ func (repository *PostRepositoryImpl) FindAllByUser(ctx context.Context, tx *sql
 	return posts
 }
 
-func (repository *PostRepositoryImpl) FindAllByFollowed(ctx context.Context, tx *sql.Tx, userId, limit, offset int) []PostJoinFollowed {
+func (repository *PostRepositoryImpl) FindAllByFollowed(ctx context.Context, tx *sql.Tx, userId, callerId, limit, offset int) []PostJoinFollowed {
 
-	query := `SELECT p.id, p.title, p.body, p.created_at, p.updated_at, p.deleted_at, p.is_deleted, p.is_published, u.id, u.role_id, u.username, u.email, u.first_name, u.last_name, u.created_at, u.updated_at, u.deleted_at, 
-	(SELECT COUNT(*) FROM follow f WHERE f.followed_id = u.id) AS follower_count,
-	(SELECT COUNT(*) FROM follow f WHERE f.follower_id = u.id) AS following_count 
+	query := `SELECT p.id, p.title, p.slug, p.body, p.body_format, COALESCE(p.body_html, ''), COALESCE(p.body_toc, ''), p.created_at, p.updated_at, p.deleted_at, p.is_deleted, p.is_published, p.visibility, p.publish_at, p.unpublish_at, p.moderation_status, p.comment_policy, (SELECT GROUP_CONCAT(t.name ORDER BY t.name SEPARATOR ',') FROM post_tag pt JOIN tag t ON t.id = pt.tag_id WHERE pt.post_id = p.id) AS tags, u.id, u.role_id, u.username, u.email, u.first_name, u.last_name, u.created_at, u.updated_at, u.deleted_at, 
+	(SELECT COUNT(*) FROM follow f JOIN user fu ON fu.id = f.follower_id WHERE f.followed_id = u.id AND fu.is_deleted = false AND fu.is_deactivated = false) AS follower_count,
+	(SELECT COUNT(*) FROM follow f JOIN user fu ON fu.id = f.followed_id WHERE f.follower_id = u.id AND fu.is_deleted = false AND fu.is_deactivated = false) AS following_count 
 	FROM user u 
 	JOIN post p 
 	ON u.id = p.user_id 
 	JOIN follow f 
 	ON u.id = f.followed_id 
 	WHERE f.follower_id = ? 
-	AND p.is_deleted = false 
+	AND ` + VisibleToCaller + ` 
 	ORDER BY p.created_at DESC LIMIT ? OFFSET ?`
 
-	rows, err := tx.QueryContext(ctx, query, userId, limit, offset)
+	rows, err := tx.QueryContext(ctx, query, userId, callerId, callerId, limit, offset)
 
 	helpers.PanicError(err, "failed to query post by user followed")
 
@@ -129,16 +207,31 @@ func (repository *PostRepositoryImpl) FindAllByFollowed(ctx context.Context, tx
 	var postsByFollowed []PostJoinFollowed
 
 	var deletedAtPost sql.NullTime
+	var publishAt sql.NullTime
+	var unpublishAt sql.NullTime
 	var deletedAtUser sql.NullTime
 	var firstName sql.NullString
 	var lastName sql.NullString
+	var tags sql.NullString
 
 	for rows.Next() {
 		var postByFollowed PostJoinFollowed
-		err := rows.Scan(&postByFollowed.Id, &postByFollowed.Title, &postByFollowed.Body, &postByFollowed.Created_At, &postByFollowed.Updated_At, &deletedAtPost, &postByFollowed.Deleted, &postByFollowed.Published, &postByFollowed.User.Id, &postByFollowed.User.Role_Id, &postByFollowed.User.Username, &postByFollowed.User.Email, &firstName, &lastName, &postByFollowed.User.Created_At, &postByFollowed.User.Updated_At, &deletedAtUser, &postByFollowed.User.Follower, &postByFollowed.User.Following)
+		err := rows.Scan(&postByFollowed.Id, &postByFollowed.Title, &postByFollowed.Slug, &postByFollowed.Body, &postByFollowed.Body_Format, &postByFollowed.Body_Html, &postByFollowed.Body_Toc, &postByFollowed.Created_At, &postByFollowed.Updated_At, &deletedAtPost, &postByFollowed.Deleted, &postByFollowed.Published, &postByFollowed.Visibility, &publishAt, &unpublishAt, &postByFollowed.Moderation_Status, &postByFollowed.Comment_Policy, &tags, &postByFollowed.User.Id, &postByFollowed.User.Role_Id, &postByFollowed.User.Username, &postByFollowed.User.Email, &firstName, &lastName, &postByFollowed.User.Created_At, &postByFollowed.User.Updated_At, &deletedAtUser, &postByFollowed.User.Follower, &postByFollowed.User.Following)
 
 		helpers.PanicError(err, "failed to scan post by user followed")
 
+		postByFollowed.Tags = splitTags(tags)
+
+		if publishAt.Valid {
+			postByFollowed.Publish_At = publishAt.Time
+		} else {
+			postByFollowed.Publish_At = time.Time{}
+		}
+		if unpublishAt.Valid {
+			postByFollowed.Unpublish_At = unpublishAt.Time
+		} else {
+			postByFollowed.Unpublish_At = time.Time{}
+		}
 		if deletedAtPost.Valid {
 			postByFollowed.Deleted_At = deletedAtPost.Time
 		} else {
@@ -169,13 +262,13 @@ func (repository *PostRepositoryImpl) FindAllByFollowed(ctx context.Context, tx
 
 func (repository *PostRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, postId int) PostJoin {
 
-	query := `SELECT p.id, p.title, p.body, p.created_at, p.updated_at, p.deleted_at, p.is_deleted, p.is_published, u.id, u.role_id, u.username, u.email, u.first_name, u.last_name, u.created_at, u.updated_at, u.deleted_at, c.id, c.name, c.created_at, c.updated_at 
+	query := `SELECT p.id, p.title, p.slug, p.body, p.body_format, COALESCE(p.body_html, ''), COALESCE(p.body_toc, ''), p.created_at, p.updated_at, p.deleted_at, p.is_deleted, p.is_published, p.visibility, p.publish_at, p.unpublish_at, p.moderation_status, p.comment_policy, (SELECT GROUP_CONCAT(t.name ORDER BY t.name SEPARATOR ',') FROM post_tag pt JOIN tag t ON t.id = pt.tag_id WHERE pt.post_id = p.id) AS tags, p.moderation_note, p.moderated_by, p.moderated_at, u.id, u.role_id, u.username, u.email, u.first_name, u.last_name, u.created_at, u.updated_at, u.deleted_at, c.id, c.name, c.created_at, c.updated_at 
 	FROM user u 
 	JOIN post p 
 	ON u.id = p.user_id 
 	JOIN category c 
 	ON p.category_id = c.id 
-	WHERE p.id = ? AND p.is_deleted = false`
+	WHERE p.id = ? AND p.is_deleted = false AND p.is_hidden = false AND u.is_deleted = false AND u.is_deactivated = false`
 
 	rows, err := tx.QueryContext(ctx, query, postId)
 
@@ -186,15 +279,49 @@ func (repository *PostRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx,
 	var post PostJoin
 
 	var deletedAtPost sql.NullTime
+	var publishAt sql.NullTime
+	var unpublishAt sql.NullTime
 	var deletedAtUser sql.NullTime
 	var firstName sql.NullString
 	var lastName sql.NullString
+	var tags sql.NullString
+	var moderationNote sql.NullString
+	var moderatedBy sql.NullInt64
+	var moderatedAt sql.NullTime
 
 	if rows.Next() {
-		err := rows.Scan(&post.Id, &post.Title, &post.Body, &post.Created_At, &post.Updated_At, &deletedAtPost, &post.Deleted, &post.Published, &post.User.Id, &post.User.Role_Id, &post.User.Username, &post.User.Email, &firstName, &lastName, &post.User.Created_At, &post.User.Updated_At, &deletedAtUser, &post.Category.Id, &post.Category.Name, &post.Category.Created_At, &post.Category.Updated_At)
+		err := rows.Scan(&post.Id, &post.Title, &post.Slug, &post.Body, &post.Body_Format, &post.Body_Html, &post.Body_Toc, &post.Created_At, &post.Updated_At, &deletedAtPost, &post.Deleted, &post.Published, &post.Visibility, &publishAt, &unpublishAt, &post.Moderation_Status, &post.Comment_Policy, &tags, &moderationNote, &moderatedBy, &moderatedAt, &post.User.Id, &post.User.Role_Id, &post.User.Username, &post.User.Email, &firstName, &lastName, &post.User.Created_At, &post.User.Updated_At, &deletedAtUser, &post.Category.Id, &post.Category.Name, &post.Category.Created_At, &post.Category.Updated_At)
 
 		helpers.PanicError(err, "failed to scan post by id")
 
+		if moderationNote.Valid {
+			post.Moderation_Note = moderationNote.String
+		} else {
+			post.Moderation_Note = ""
+		}
+		if moderatedBy.Valid {
+			post.Moderated_By = int(moderatedBy.Int64)
+		} else {
+			post.Moderated_By = 0
+		}
+		if moderatedAt.Valid {
+			post.Moderated_At = moderatedAt.Time
+		} else {
+			post.Moderated_At = time.Time{}
+		}
+
+		post.Tags = splitTags(tags)
+
+		if publishAt.Valid {
+			post.Publish_At = publishAt.Time
+		} else {
+			post.Publish_At = time.Time{}
+		}
+		if unpublishAt.Valid {
+			post.Unpublish_At = unpublishAt.Time
+		} else {
+			post.Unpublish_At = time.Time{}
+		}
 		if deletedAtPost.Valid {
 			post.Deleted_At = deletedAtPost.Time
 		} else {
@@ -224,9 +351,9 @@ func (repository *PostRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx,
 }
 
 func (repository *PostRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, post Post) PostJoin {
-	queryUpdate := "UPDATE post SET title = ?, body = ?, category_id = ?, is_published = ?, is_deleted = ? WHERE id = ? AND is_deleted = false"
+	queryUpdate := "UPDATE post SET title = ?, body = ?, body_format = ?, body_html = NULLIF(?, ''), body_toc = NULLIF(?, ''), category_id = ?, published_at = IF(?, COALESCE(published_at, NOW()), NULL), is_published = ?, visibility = COALESCE(NULLIF(?, ''), visibility), publish_at = ?, unpublish_at = ?, is_deleted = ?, moderation_status = COALESCE(NULLIF(?, ''), moderation_status) WHERE id = ? AND is_deleted = false"
 
-	_, err := tx.ExecContext(ctx, queryUpdate, post.Title, post.Body, post.Category_Id, post.Published, post.Deleted, post.Id)
+	_, err := tx.ExecContext(ctx, queryUpdate, post.Title, post.Body, post.Body_Format, post.Body_Html, post.Body_Toc, post.Category_Id, post.Published, post.Published, post.Visibility, nullTime(post.Publish_At), nullTime(post.Unpublish_At), post.Deleted, post.Moderation_Status, post.Id)
 
 	helpers.PanicError(err, "failed to exec query update post")
 
@@ -251,10 +378,10 @@ func (repository *PostRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, po
 	helpers.PanicError(err, "failed to display rows affected delete post")
 }
 
-func (repository *PostRepositoryImpl) CountPostsByUser(ctx context.Context, tx *sql.Tx, userId int) int {
-	query := "SELECT COUNT(*) FROM post WHERE is_deleted = false AND user_id = ?"
+func (repository *PostRepositoryImpl) CountPostsByUser(ctx context.Context, tx *sql.Tx, userId, callerId int, includeDrafts bool) int {
+	query := "SELECT COUNT(*) FROM post p JOIN user u ON u.id = p.user_id WHERE (p.is_published = true OR ?) AND " + listedToCaller + " AND p.user_id = ?"
 
-	rows, err := tx.QueryContext(ctx, query, userId)
+	rows, err := tx.QueryContext(ctx, query, includeDrafts, callerId, callerId, userId)
 
 	helpers.PanicError(err, "failed to query count posts")
 
@@ -269,3 +396,715 @@ func (repository *PostRepositoryImpl) CountPostsByUser(ctx context.Context, tx *
 
 	return countPosts
 }
+
+func (repository *PostRepositoryImpl) FindAllPending(ctx context.Context, tx *sql.Tx, limit, offset int) []PostJoin {
+
+	query := `SELECT p.id, p.title, p.slug, p.body, p.body_format, COALESCE(p.body_html, ''), COALESCE(p.body_toc, ''), p.created_at, p.updated_at, p.deleted_at, p.is_deleted, p.is_published, p.visibility, p.publish_at, p.unpublish_at, p.moderation_status, p.comment_policy, (SELECT GROUP_CONCAT(t.name ORDER BY t.name SEPARATOR ',') FROM post_tag pt JOIN tag t ON t.id = pt.tag_id WHERE pt.post_id = p.id) AS tags, u.id, u.role_id, u.username, u.email, u.first_name, u.last_name, u.created_at, u.updated_at, u.deleted_at, c.id, c.name, c.created_at, c.updated_at 
+	FROM user u 
+	JOIN post p 
+	ON u.id = p.user_id 
+	JOIN category c 
+	ON p.category_id = c.id 
+	WHERE p.moderation_status = 'pending' AND p.is_published = true 
+	AND p.is_deleted = false AND p.is_hidden = false 
+	AND u.is_deleted = false 
+	ORDER BY p.created_at ASC, p.id ASC LIMIT ? OFFSET ?`
+
+	rows, err := tx.QueryContext(ctx, query, limit, offset)
+
+	helpers.PanicError(err, "failed to query pending posts")
+
+	defer rows.Close()
+
+	var posts []PostJoin
+
+	var deletedAtPost sql.NullTime
+	var publishAt sql.NullTime
+	var unpublishAt sql.NullTime
+	var deletedAtUser sql.NullTime
+	var firstName sql.NullString
+	var lastName sql.NullString
+	var tags sql.NullString
+
+	for rows.Next() {
+		var post PostJoin
+
+		err := rows.Scan(&post.Id, &post.Title, &post.Slug, &post.Body, &post.Body_Format, &post.Body_Html, &post.Body_Toc, &post.Created_At, &post.Updated_At, &deletedAtPost, &post.Deleted, &post.Published, &post.Visibility, &publishAt, &unpublishAt, &post.Moderation_Status, &post.Comment_Policy, &tags, &post.User.Id, &post.User.Role_Id, &post.User.Username, &post.User.Email, &firstName, &lastName, &post.User.Created_At, &post.User.Updated_At, &deletedAtUser, &post.Category.Id, &post.Category.Name, &post.Category.Created_At, &post.Category.Updated_At)
+
+		helpers.PanicError(err, "failed to scan pending posts")
+
+		post.Tags = splitTags(tags)
+
+		if publishAt.Valid {
+			post.Publish_At = publishAt.Time
+		} else {
+			post.Publish_At = time.Time{}
+		}
+		if unpublishAt.Valid {
+			post.Unpublish_At = unpublishAt.Time
+		} else {
+			post.Unpublish_At = time.Time{}
+		}
+		if deletedAtPost.Valid {
+			post.Deleted_At = deletedAtPost.Time
+		} else {
+			post.Deleted_At = time.Time{}
+		}
+		if deletedAtUser.Valid {
+			post.User.Deleted_At = deletedAtUser.Time
+		} else {
+			post.User.Deleted_At = time.Time{}
+		}
+		if firstName.Valid {
+			post.User.First_Name = firstName.String
+		} else {
+			post.User.First_Name = ""
+		}
+		if lastName.Valid {
+			post.User.Last_Name = lastName.String
+		} else {
+			post.User.Last_Name = ""
+		}
+
+		posts = append(posts, post)
+	}
+
+	return posts
+}
+
+func (repository *PostRepositoryImpl) CountPending(ctx context.Context, tx *sql.Tx) int {
+	query := `SELECT COUNT(*) FROM post p JOIN user u ON u.id = p.user_id 
+	WHERE p.moderation_status = 'pending' AND p.is_published = true AND p.is_deleted = false AND p.is_hidden = false AND u.is_deleted = false`
+
+	rows, err := tx.QueryContext(ctx, query)
+
+	helpers.PanicError(err, "failed to query count pending posts")
+
+	defer rows.Close()
+
+	var countPosts int
+
+	if rows.Next() {
+		err := rows.Scan(&countPosts)
+		helpers.PanicError(err, "failed to scan count pending posts")
+	}
+
+	return countPosts
+}
+
+func (repository *PostRepositoryImpl) CountApprovedByUser(ctx context.Context, tx *sql.Tx, userId int) int {
+	query := "SELECT COUNT(*) FROM post WHERE user_id = ? AND moderation_status = 'approved' AND moderated_by IS NOT NULL AND is_deleted = false"
+
+	rows, err := tx.QueryContext(ctx, query, userId)
+
+	helpers.PanicError(err, "failed to query count approved posts")
+
+	defer rows.Close()
+
+	var countPosts int
+
+	if rows.Next() {
+		err := rows.Scan(&countPosts)
+		helpers.PanicError(err, "failed to scan count approved posts")
+	}
+
+	return countPosts
+}
+
+func (repository *PostRepositoryImpl) UpdateModeration(ctx context.Context, tx *sql.Tx, postId int, status string, moderatorId int, note string) {
+	query := "UPDATE post SET moderation_status = ?, moderation_note = NULLIF(?, ''), moderated_by = ?, moderated_at = NOW() WHERE id = ? AND is_deleted = false"
+
+	result, err := tx.ExecContext(ctx, query, status, note, moderatorId, postId)
+
+	helpers.PanicError(err, "failed to exec query update post moderation")
+
+	resultRows, err := result.RowsAffected()
+
+	helpers.PanicError(err, "failed to display rows affected update post moderation")
+
+	if resultRows == 0 {
+		panic(exception.NewNotFoundError("post not found"))
+	}
+}
+
+func (repository *PostRepositoryImpl) UpdateCommentPolicy(ctx context.Context, tx *sql.Tx, postId int, policy string) {
+	query := "UPDATE post SET comment_policy = ? WHERE id = ? AND is_deleted = false"
+
+	_, err := tx.ExecContext(ctx, query, policy, postId)
+
+	helpers.PanicError(err, "failed to exec query update post comment policy")
+}
+
+// LockAuthor serialises slug allocation per author, so two posts created at
+// the same time cannot both pick the same free slug.
+func (repository *PostRepositoryImpl) LockAuthor(ctx context.Context, tx *sql.Tx, userId int) {
+	query := "SELECT id FROM user WHERE id = ? FOR UPDATE"
+
+	rows, err := tx.QueryContext(ctx, query, userId)
+	helpers.PanicError(err, "failed to lock post author")
+
+	rows.Close()
+}
+
+// IsSlugTaken also counts an author's old slugs, they keep redirecting to the
+// post that used them unless that same post takes them back.
+func (repository *PostRepositoryImpl) IsSlugTaken(ctx context.Context, tx *sql.Tx, userId int, slug string, postId int) bool {
+	query := `SELECT EXISTS(SELECT 1 FROM post WHERE user_id = ? AND slug = ? AND id <> ?)
+	OR EXISTS(SELECT 1 FROM post_slug_history WHERE user_id = ? AND slug = ? AND post_id <> ?)`
+
+	var taken bool
+	err := tx.QueryRowContext(ctx, query, userId, slug, postId, userId, slug, postId).Scan(&taken)
+	helpers.PanicError(err, "failed to query post slug")
+
+	return taken
+}
+
+func (repository *PostRepositoryImpl) UpdateSlug(ctx context.Context, tx *sql.Tx, postId int, slug string) {
+	query := "UPDATE post SET slug = ? WHERE id = ?"
+
+	_, err := tx.ExecContext(ctx, query, slug, postId)
+	helpers.PanicError(err, "failed to exec query update post slug")
+}
+
+func (repository *PostRepositoryImpl) SaveSlugHistory(ctx context.Context, tx *sql.Tx, history SlugHistory) {
+	query := "INSERT INTO post_slug_history(post_id, user_id, slug) VALUES (?, ?, ?)"
+
+	_, err := tx.ExecContext(ctx, query, history.Post_Id, history.User_Id, history.Slug)
+	helpers.PanicError(err, "failed to exec query insert post slug history")
+}
+
+func (repository *PostRepositoryImpl) DeleteSlugHistory(ctx context.Context, tx *sql.Tx, userId int, slug string) {
+	query := "DELETE FROM post_slug_history WHERE user_id = ? AND slug = ?"
+
+	_, err := tx.ExecContext(ctx, query, userId, slug)
+	helpers.PanicError(err, "failed to exec query delete post slug history")
+}
+
+func (repository *PostRepositoryImpl) FindIdBySlug(ctx context.Context, tx *sql.Tx, username, slug string) int {
+	query := "SELECT p.id FROM post p JOIN user u ON u.id = p.user_id WHERE u.username = ? AND p.slug = ? AND u.is_deleted = false"
+
+	rows, err := tx.QueryContext(ctx, query, username, slug)
+	helpers.PanicError(err, "failed to query post by slug")
+
+	defer rows.Close()
+
+	var postId int
+
+	if rows.Next() {
+		err := rows.Scan(&postId)
+		helpers.PanicError(err, "failed to scan post by slug")
+	}
+
+	return postId
+}
+
+// FindSlugRedirect returns the current slug of the post that used slug
+// before, or an empty string when it never existed.
+func (repository *PostRepositoryImpl) FindSlugRedirect(ctx context.Context, tx *sql.Tx, username, slug string) string {
+	query := `SELECT p.slug FROM post_slug_history h
+	JOIN post p ON p.id = h.post_id
+	JOIN user u ON u.id = h.user_id
+	WHERE u.username = ? AND h.slug = ? AND u.is_deleted = false`
+
+	rows, err := tx.QueryContext(ctx, query, username, slug)
+	helpers.PanicError(err, "failed to query post slug redirect")
+
+	defer rows.Close()
+
+	var currentSlug string
+
+	if rows.Next() {
+		err := rows.Scan(&currentSlug)
+		helpers.PanicError(err, "failed to scan post slug redirect")
+	}
+
+	return currentSlug
+}
+
+func (repository *PostRepositoryImpl) FindAllScheduledByUser(ctx context.Context, tx *sql.Tx, userId, limit, offset int) []PostJoin {
+
+	query := `SELECT p.id, p.title, p.slug, p.body, p.body_format, COALESCE(p.body_html, ''), COALESCE(p.body_toc, ''), p.created_at, p.updated_at, p.deleted_at, p.is_deleted, p.is_published, p.visibility, p.publish_at, p.unpublish_at, p.moderation_status, p.comment_policy, (SELECT GROUP_CONCAT(t.name ORDER BY t.name SEPARATOR ',') FROM post_tag pt JOIN tag t ON t.id = pt.tag_id WHERE pt.post_id = p.id) AS tags, u.id, u.role_id, u.username, u.email, u.first_name, u.last_name, u.created_at, u.updated_at, u.deleted_at, c.id, c.name, c.created_at, c.updated_at 
+	FROM user u 
+	JOIN post p 
+	ON u.id = p.user_id 
+	JOIN category c 
+	ON p.category_id = c.id 
+	WHERE p.user_id = ? 
+	AND (p.publish_at IS NOT NULL OR p.unpublish_at IS NOT NULL) 
+	AND p.is_deleted = false AND p.is_hidden = false 
+	ORDER BY COALESCE(p.publish_at, p.unpublish_at) ASC, p.id ASC LIMIT ? OFFSET ?`
+
+	rows, err := tx.QueryContext(ctx, query, userId, limit, offset)
+
+	helpers.PanicError(err, "failed to query scheduled posts")
+
+	defer rows.Close()
+
+	var posts []PostJoin
+
+	var deletedAtPost sql.NullTime
+	var publishAt sql.NullTime
+	var unpublishAt sql.NullTime
+	var deletedAtUser sql.NullTime
+	var firstName sql.NullString
+	var lastName sql.NullString
+	var tags sql.NullString
+
+	for rows.Next() {
+		var post PostJoin
+
+		err := rows.Scan(&post.Id, &post.Title, &post.Slug, &post.Body, &post.Body_Format, &post.Body_Html, &post.Body_Toc, &post.Created_At, &post.Updated_At, &deletedAtPost, &post.Deleted, &post.Published, &post.Visibility, &publishAt, &unpublishAt, &post.Moderation_Status, &post.Comment_Policy, &tags, &post.User.Id, &post.User.Role_Id, &post.User.Username, &post.User.Email, &firstName, &lastName, &post.User.Created_At, &post.User.Updated_At, &deletedAtUser, &post.Category.Id, &post.Category.Name, &post.Category.Created_At, &post.Category.Updated_At)
+
+		helpers.PanicError(err, "failed to scan scheduled posts")
+
+		post.Tags = splitTags(tags)
+
+		if publishAt.Valid {
+			post.Publish_At = publishAt.Time
+		} else {
+			post.Publish_At = time.Time{}
+		}
+		if unpublishAt.Valid {
+			post.Unpublish_At = unpublishAt.Time
+		} else {
+			post.Unpublish_At = time.Time{}
+		}
+		if deletedAtPost.Valid {
+			post.Deleted_At = deletedAtPost.Time
+		} else {
+			post.Deleted_At = time.Time{}
+		}
+		if deletedAtUser.Valid {
+			post.User.Deleted_At = deletedAtUser.Time
+		} else {
+			post.User.Deleted_At = time.Time{}
+		}
+		if firstName.Valid {
+			post.User.First_Name = firstName.String
+		} else {
+			post.User.First_Name = ""
+		}
+		if lastName.Valid {
+			post.User.Last_Name = lastName.String
+		} else {
+			post.User.Last_Name = ""
+		}
+
+		posts = append(posts, post)
+	}
+
+	return posts
+}
+
+func (repository *PostRepositoryImpl) CountScheduledByUser(ctx context.Context, tx *sql.Tx, userId int) int {
+	query := `SELECT COUNT(*) FROM post 
+	WHERE user_id = ? AND (publish_at IS NOT NULL OR unpublish_at IS NOT NULL) AND is_deleted = false AND is_hidden = false`
+
+	var countPosts int
+	err := tx.QueryRowContext(ctx, query, userId).Scan(&countPosts)
+	helpers.PanicError(err, "failed to query count scheduled posts")
+
+	return countPosts
+}
+
+// UpdateSchedule keeps the moderation status unless the post sets a new one.
+func (repository *PostRepositoryImpl) UpdateSchedule(ctx context.Context, tx *sql.Tx, post Post) {
+	query := "UPDATE post SET publish_at = ?, unpublish_at = ?, is_published = ?, published_at = IF(?, COALESCE(published_at, NOW()), NULL), moderation_status = COALESCE(NULLIF(?, ''), moderation_status) WHERE id = ? AND is_deleted = false"
+
+	_, err := tx.ExecContext(ctx, query, nullTime(post.Publish_At), nullTime(post.Unpublish_At), post.Published, post.Published, post.Moderation_Status, post.Id)
+	helpers.PanicError(err, "failed to exec query update post schedule")
+}
+
+// FindDueToPublish locks the due posts it returns. Rows already locked by
+// another instance are skipped rather than waited on, and a published post
+// no longer has a publish_at, so every post is published exactly once.
+// FindDueToPublish leaves out the posts FindById cannot load, hidden ones and
+// those of deleted or deactivated authors. They stay scheduled and go live
+// once they are loadable again instead of failing every batch.
+func (repository *PostRepositoryImpl) FindDueToPublish(ctx context.Context, tx *sql.Tx, limit int) []int {
+	query := `SELECT p.id FROM post p 
+	JOIN user u ON u.id = p.user_id 
+	WHERE p.publish_at <= NOW() AND p.is_deleted = false AND p.is_hidden = false 
+	AND u.is_deleted = false AND u.is_deactivated = false 
+	ORDER BY p.publish_at ASC, p.id ASC LIMIT ? FOR UPDATE OF p SKIP LOCKED`
+
+	return repository.findDueIds(ctx, tx, query, limit)
+}
+
+func (repository *PostRepositoryImpl) FindDueToUnpublish(ctx context.Context, tx *sql.Tx, limit int) []int {
+	query := `SELECT id FROM post 
+	WHERE unpublish_at <= NOW() AND publish_at IS NULL AND is_deleted = false 
+	ORDER BY unpublish_at ASC, id ASC LIMIT ? FOR UPDATE SKIP LOCKED`
+
+	return repository.findDueIds(ctx, tx, query, limit)
+}
+
+func (repository *PostRepositoryImpl) findDueIds(ctx context.Context, tx *sql.Tx, query string, limit int) []int {
+	rows, err := tx.QueryContext(ctx, query, limit)
+	helpers.PanicError(err, "failed to query due scheduled posts")
+
+	defer rows.Close()
+
+	var postIds []int
+
+	for rows.Next() {
+		var postId int
+		err := rows.Scan(&postId)
+		helpers.PanicError(err, "failed to scan due scheduled posts")
+
+		postIds = append(postIds, postId)
+	}
+
+	return postIds
+}
+
+func (repository *PostRepositoryImpl) PublishScheduled(ctx context.Context, tx *sql.Tx, postId int, moderationStatus string) {
+	query := "UPDATE post SET is_published = true, published_at = NOW(), publish_at = NULL, moderation_status = ? WHERE id = ?"
+
+	_, err := tx.ExecContext(ctx, query, moderationStatus, postId)
+	helpers.PanicError(err, "failed to exec query publish scheduled post")
+}
+
+func (repository *PostRepositoryImpl) UnpublishScheduled(ctx context.Context, tx *sql.Tx, postId int) {
+	query := "UPDATE post SET is_published = false, published_at = NULL, unpublish_at = NULL WHERE id = ?"
+
+	_, err := tx.ExecContext(ctx, query, postId)
+	helpers.PanicError(err, "failed to exec query unpublish scheduled post")
+}
+
+func (repository *PostRepositoryImpl) FindAllByTag(ctx context.Context, tx *sql.Tx, tagName string, callerId, limit, offset int) []PostJoin {
+
+	query := `SELECT p.id, p.title, p.slug, p.body, p.body_format, COALESCE(p.body_html, ''), COALESCE(p.body_toc, ''), p.created_at, p.updated_at, p.deleted_at, p.is_deleted, p.is_published, p.visibility, p.publish_at, p.unpublish_at, p.moderation_status, p.comment_policy, (SELECT GROUP_CONCAT(t.name ORDER BY t.name SEPARATOR ',') FROM post_tag pt JOIN tag t ON t.id = pt.tag_id WHERE pt.post_id = p.id) AS tags, u.id, u.role_id, u.username, u.email, u.first_name, u.last_name, u.created_at, u.updated_at, u.deleted_at, c.id, c.name, c.created_at, c.updated_at 
+	FROM tag t 
+	JOIN post_tag pt 
+	ON pt.tag_id = t.id 
+	JOIN post p 
+	ON p.id = pt.post_id 
+	JOIN user u 
+	ON u.id = p.user_id 
+	JOIN category c 
+	ON p.category_id = c.id 
+	WHERE t.name = ? 
+	AND ` + VisibleToCaller + ` 
+	ORDER BY p.created_at DESC, p.id DESC LIMIT ? OFFSET ?`
+
+	rows, err := tx.QueryContext(ctx, query, tagName, callerId, callerId, limit, offset)
+
+	helpers.PanicError(err, "failed to query posts by tag")
+
+	defer rows.Close()
+
+	var posts []PostJoin
+
+	var deletedAtPost sql.NullTime
+	var publishAt sql.NullTime
+	var unpublishAt sql.NullTime
+	var deletedAtUser sql.NullTime
+	var firstName sql.NullString
+	var lastName sql.NullString
+	var tags sql.NullString
+
+	for rows.Next() {
+		var post PostJoin
+
+		err := rows.Scan(&post.Id, &post.Title, &post.Slug, &post.Body, &post.Body_Format, &post.Body_Html, &post.Body_Toc, &post.Created_At, &post.Updated_At, &deletedAtPost, &post.Deleted, &post.Published, &post.Visibility, &publishAt, &unpublishAt, &post.Moderation_Status, &post.Comment_Policy, &tags, &post.User.Id, &post.User.Role_Id, &post.User.Username, &post.User.Email, &firstName, &lastName, &post.User.Created_At, &post.User.Updated_At, &deletedAtUser, &post.Category.Id, &post.Category.Name, &post.Category.Created_At, &post.Category.Updated_At)
+
+		helpers.PanicError(err, "failed to scan posts by tag")
+
+		post.Tags = splitTags(tags)
+
+		if publishAt.Valid {
+			post.Publish_At = publishAt.Time
+		} else {
+			post.Publish_At = time.Time{}
+		}
+		if unpublishAt.Valid {
+			post.Unpublish_At = unpublishAt.Time
+		} else {
+			post.Unpublish_At = time.Time{}
+		}
+		if deletedAtPost.Valid {
+			post.Deleted_At = deletedAtPost.Time
+		} else {
+			post.Deleted_At = time.Time{}
+		}
+		if deletedAtUser.Valid {
+			post.User.Deleted_At = deletedAtUser.Time
+		} else {
+			post.User.Deleted_At = time.Time{}
+		}
+		if firstName.Valid {
+			post.User.First_Name = firstName.String
+		} else {
+			post.User.First_Name = ""
+		}
+		if lastName.Valid {
+			post.User.Last_Name = lastName.String
+		} else {
+			post.User.Last_Name = ""
+		}
+
+		posts = append(posts, post)
+	}
+
+	return posts
+}
+
+func (repository *PostRepositoryImpl) CountByTag(ctx context.Context, tx *sql.Tx, tagName string, callerId int) int {
+	query := `SELECT COUNT(*) FROM tag t 
+	JOIN post_tag pt ON pt.tag_id = t.id 
+	JOIN post p ON p.id = pt.post_id 
+	JOIN user u ON u.id = p.user_id 
+	WHERE t.name = ? 
+	AND ` + VisibleToCaller
+
+	var countPosts int
+	err := tx.QueryRowContext(ctx, query, tagName, callerId, callerId).Scan(&countPosts)
+	helpers.PanicError(err, "failed to query count posts by tag")
+
+	return countPosts
+}
+
+func postFilterQuery(filter PostFilterRequest) (string, []interface{}) {
+	conditions := []string{VisibleToCaller}
+	args := []interface{}{filter.Caller_Id, filter.Caller_Id}
+
+	if filter.Category_Id > 0 {
+		conditions = append(conditions, "p.category_id = ?")
+		args = append(args, filter.Category_Id)
+	}
+
+	if filter.Author_Id > 0 {
+		conditions = append(conditions, "p.user_id = ?")
+		args = append(args, filter.Author_Id)
+	}
+
+	if !filter.From.IsZero() {
+		conditions = append(conditions, "COALESCE(p.published_at, p.created_at) >= ?")
+		args = append(args, filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		conditions = append(conditions, "COALESCE(p.published_at, p.created_at) < ?")
+		args = append(args, filter.To)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func postFilterOrder(sort string) string {
+	switch sort {
+	case SortOldest:
+		return "COALESCE(p.published_at, p.created_at) ASC, p.id ASC"
+	case SortMostCommented:
+		return "comment_count DESC, COALESCE(p.published_at, p.created_at) DESC, p.id DESC"
+	default:
+		return "COALESCE(p.published_at, p.created_at) DESC, p.id DESC"
+	}
+}
+
+func (repository *PostRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, filter PostFilterRequest, limit, offset int) []PostJoin {
+	where, args := postFilterQuery(filter)
+
+	query := `SELECT p.id, p.title, p.slug, p.body, p.body_format, COALESCE(p.body_html, ''), COALESCE(p.body_toc, ''), p.created_at, p.updated_at, p.deleted_at, p.is_deleted, p.is_published, p.visibility, p.publish_at, p.unpublish_at, p.moderation_status, p.comment_policy, (SELECT GROUP_CONCAT(t.name ORDER BY t.name SEPARATOR ',') FROM post_tag pt JOIN tag t ON t.id = pt.tag_id WHERE pt.post_id = p.id) AS tags, u.id, u.role_id, u.username, u.email, u.first_name, u.last_name, u.created_at, u.updated_at, u.deleted_at, c.id, c.name, c.created_at, c.updated_at, 
+	(SELECT COUNT(*) FROM comment cm WHERE cm.post_id = p.id AND cm.is_deleted = false AND cm.is_hidden = false AND cm.moderation_status = 'approved') AS comment_count 
+	FROM post p 
+	JOIN user u 
+	ON u.id = p.user_id 
+	JOIN category c 
+	ON p.category_id = c.id 
+	WHERE ` + where + ` 
+	ORDER BY ` + postFilterOrder(filter.Sort) + ` LIMIT ? OFFSET ?`
+
+	args = append(args, limit, offset)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+
+	helpers.PanicError(err, "failed to query all posts")
+
+	defer rows.Close()
+
+	var posts []PostJoin
+
+	var deletedAtPost sql.NullTime
+	var publishAt sql.NullTime
+	var unpublishAt sql.NullTime
+	var deletedAtUser sql.NullTime
+	var firstName sql.NullString
+	var lastName sql.NullString
+	var tags sql.NullString
+	var commentCount int
+
+	for rows.Next() {
+		var post PostJoin
+
+		err := rows.Scan(&post.Id, &post.Title, &post.Slug, &post.Body, &post.Body_Format, &post.Body_Html, &post.Body_Toc, &post.Created_At, &post.Updated_At, &deletedAtPost, &post.Deleted, &post.Published, &post.Visibility, &publishAt, &unpublishAt, &post.Moderation_Status, &post.Comment_Policy, &tags, &post.User.Id, &post.User.Role_Id, &post.User.Username, &post.User.Email, &firstName, &lastName, &post.User.Created_At, &post.User.Updated_At, &deletedAtUser, &post.Category.Id, &post.Category.Name, &post.Category.Created_At, &post.Category.Updated_At, &commentCount)
+
+		helpers.PanicError(err, "failed to scan all posts")
+
+		post.Tags = splitTags(tags)
+
+		if publishAt.Valid {
+			post.Publish_At = publishAt.Time
+		} else {
+			post.Publish_At = time.Time{}
+		}
+		if unpublishAt.Valid {
+			post.Unpublish_At = unpublishAt.Time
+		} else {
+			post.Unpublish_At = time.Time{}
+		}
+		if deletedAtPost.Valid {
+			post.Deleted_At = deletedAtPost.Time
+		} else {
+			post.Deleted_At = time.Time{}
+		}
+		if deletedAtUser.Valid {
+			post.User.Deleted_At = deletedAtUser.Time
+		} else {
+			post.User.Deleted_At = time.Time{}
+		}
+		if firstName.Valid {
+			post.User.First_Name = firstName.String
+		} else {
+			post.User.First_Name = ""
+		}
+		if lastName.Valid {
+			post.User.Last_Name = lastName.String
+		} else {
+			post.User.Last_Name = ""
+		}
+
+		posts = append(posts, post)
+	}
+
+	return posts
+}
+
+func (repository *PostRepositoryImpl) CountAll(ctx context.Context, tx *sql.Tx, filter PostFilterRequest) int {
+	where, args := postFilterQuery(filter)
+
+	query := "SELECT COUNT(*) FROM post p JOIN user u ON u.id = p.user_id WHERE " + where
+
+	var countPosts int
+	err := tx.QueryRowContext(ctx, query, args...).Scan(&countPosts)
+	helpers.PanicError(err, "failed to query count all posts")
+
+	return countPosts
+}
+
+// FindAllDraftsByUser returns the author's unpublished posts, most recently
+// edited first. Scheduled posts are listed by FindAllScheduledByUser instead.
+func (repository *PostRepositoryImpl) FindAllDraftsByUser(ctx context.Context, tx *sql.Tx, userId, limit, offset int) []PostJoin {
+
+	query := `SELECT p.id, p.title, p.slug, p.body, p.body_format, COALESCE(p.body_html, ''), COALESCE(p.body_toc, ''), p.created_at, p.updated_at, p.deleted_at, p.is_deleted, p.is_published, p.visibility, p.publish_at, p.unpublish_at, p.moderation_status, p.comment_policy, (SELECT GROUP_CONCAT(t.name ORDER BY t.name SEPARATOR ',') FROM post_tag pt JOIN tag t ON t.id = pt.tag_id WHERE pt.post_id = p.id) AS tags, u.id, u.role_id, u.username, u.email, u.first_name, u.last_name, u.created_at, u.updated_at, u.deleted_at, c.id, c.name, c.created_at, c.updated_at 
+	FROM user u 
+	JOIN post p 
+	ON u.id = p.user_id 
+	JOIN category c 
+	ON p.category_id = c.id 
+	WHERE p.user_id = ? 
+	AND p.is_published = false AND p.publish_at IS NULL 
+	AND p.is_deleted = false AND p.is_hidden = false 
+	ORDER BY p.updated_at DESC, p.id DESC LIMIT ? OFFSET ?`
+
+	rows, err := tx.QueryContext(ctx, query, userId, limit, offset)
+
+	helpers.PanicError(err, "failed to query draft posts")
+
+	defer rows.Close()
+
+	var posts []PostJoin
+
+	var deletedAtPost sql.NullTime
+	var publishAt sql.NullTime
+	var unpublishAt sql.NullTime
+	var deletedAtUser sql.NullTime
+	var firstName sql.NullString
+	var lastName sql.NullString
+	var tags sql.NullString
+
+	for rows.Next() {
+		var post PostJoin
+
+		err := rows.Scan(&post.Id, &post.Title, &post.Slug, &post.Body, &post.Body_Format, &post.Body_Html, &post.Body_Toc, &post.Created_At, &post.Updated_At, &deletedAtPost, &post.Deleted, &post.Published, &post.Visibility, &publishAt, &unpublishAt, &post.Moderation_Status, &post.Comment_Policy, &tags, &post.User.Id, &post.User.Role_Id, &post.User.Username, &post.User.Email, &firstName, &lastName, &post.User.Created_At, &post.User.Updated_At, &deletedAtUser, &post.Category.Id, &post.Category.Name, &post.Category.Created_At, &post.Category.Updated_At)
+
+		helpers.PanicError(err, "failed to scan draft posts")
+
+		post.Tags = splitTags(tags)
+
+		if publishAt.Valid {
+			post.Publish_At = publishAt.Time
+		} else {
+			post.Publish_At = time.Time{}
+		}
+		if unpublishAt.Valid {
+			post.Unpublish_At = unpublishAt.Time
+		} else {
+			post.Unpublish_At = time.Time{}
+		}
+		if deletedAtPost.Valid {
+			post.Deleted_At = deletedAtPost.Time
+		} else {
+			post.Deleted_At = time.Time{}
+		}
+		if deletedAtUser.Valid {
+			post.User.Deleted_At = deletedAtUser.Time
+		} else {
+			post.User.Deleted_At = time.Time{}
+		}
+		if firstName.Valid {
+			post.User.First_Name = firstName.String
+		} else {
+			post.User.First_Name = ""
+		}
+		if lastName.Valid {
+			post.User.Last_Name = lastName.String
+		} else {
+			post.User.Last_Name = ""
+		}
+
+		posts = append(posts, post)
+	}
+
+	return posts
+}
+
+func (repository *PostRepositoryImpl) CountDraftsByUser(ctx context.Context, tx *sql.Tx, userId int) int {
+	query := `SELECT COUNT(*) FROM post 
+	WHERE user_id = ? AND is_published = false AND publish_at IS NULL AND is_deleted = false AND is_hidden = false`
+
+	var countPosts int
+	err := tx.QueryRowContext(ctx, query, userId).Scan(&countPosts)
+	helpers.PanicError(err, "failed to query count draft posts")
+
+	return countPosts
+}
+
+func (repository *PostRepositoryImpl) IsFollower(ctx context.Context, tx *sql.Tx, userId, authorId int) bool {
+	query := "SELECT EXISTS(SELECT 1 FROM follow WHERE follower_id = ? AND followed_id = ?)"
+
+	var isFollower bool
+	err := tx.QueryRowContext(ctx, query, userId, authorId).Scan(&isFollower)
+	helpers.PanicError(err, "failed to query follower")
+
+	return isFollower
+}
+
+func nullTime(value time.Time) sql.NullTime {
+	if value.IsZero() {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: value, Valid: true}
+}
+
+// splitTags turns the GROUP_CONCAT of a post's tag names back into a slice.
+// Tag names never contain commas, normalisation strips them.
+func splitTags(tags sql.NullString) []string {
+	if !tags.Valid || tags.String == "" {
+		return []string{}
+	}
+
+	return strings.Split(tags.String, ",")
+}
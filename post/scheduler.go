@@ -0,0 +1,55 @@
+package post
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hutamatr/GoBlogify/contentfilter"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/user"
+)
+
+type PostScheduler interface {
+	Start(ctx context.Context)
+	RunDue(ctx context.Context) (int, int)
+}
+
+type PostSchedulerImpl struct {
+	repository PostRepository
+	moderation moderationPolicy
+	job        helpers.BatchJob
+}
+
+func NewPostScheduler(postRepository PostRepository, userRepository user.UserRepository, pipeline contentfilter.Pipeline, db *sql.DB) PostScheduler {
+	return &PostSchedulerImpl{
+		repository: postRepository,
+		moderation: moderationPolicy{repository: postRepository, userRepository: userRepository, pipeline: pipeline},
+		job:        helpers.NewBatchJob("post scheduler", db),
+	}
+}
+
+// Start runs the scheduler until ctx is done. Every instance of the server
+// may run one, the row locks taken in RunDue keep them from doubling up.
+func (scheduler *PostSchedulerImpl) Start(ctx context.Context) {
+	scheduler.job.Start(ctx, func(ctx context.Context) {
+		scheduler.RunDue(ctx)
+	})
+}
+
+// RunDue publishes the posts whose publish_at has passed, then unpublishes
+// the expired ones, and returns how many of each it changed.
+func (scheduler *PostSchedulerImpl) RunDue(ctx context.Context) (int, int) {
+	published := helpers.RunBatches(ctx, scheduler.job, scheduler.repository.FindDueToPublish, scheduler.publish)
+	unpublished := helpers.RunBatches(ctx, scheduler.job, scheduler.repository.FindDueToUnpublish, scheduler.repository.UnpublishScheduled)
+
+	return published, unpublished
+}
+
+// publish runs the post through moderation again as it goes live, so a draft
+// scheduled by a new author or caught by a newer filter rule still waits for
+// a moderator.
+func (scheduler *PostSchedulerImpl) publish(ctx context.Context, tx *sql.Tx, postId int) {
+	post := scheduler.repository.FindById(ctx, tx, postId)
+
+	scheduler.repository.PublishScheduled(ctx, tx, postId, scheduler.moderation.liveStatus(ctx, tx, post))
+}
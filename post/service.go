@@ -3,32 +3,72 @@ package post
 import (
 	"context"
 	"database/sql"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/contentfilter"
 	"github.com/hutamatr/GoBlogify/exception"
 	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/markup"
+	"github.com/hutamatr/GoBlogify/reaction"
+	"github.com/hutamatr/GoBlogify/revision"
+	"github.com/hutamatr/GoBlogify/tag"
+	"github.com/hutamatr/GoBlogify/user"
 )
 
 type PostService interface {
 	Create(ctx context.Context, request PostCreateRequest) PostResponse
-	FindAllByUser(ctx context.Context, userId, limit, offset int) ([]PostResponse, int)
-	FindAllByFollowed(ctx context.Context, userId, limit, offset int) ([]PostResponseFollowed, int)
-	FindById(ctx context.Context, postId int) PostResponse
-	Update(ctx context.Context, request PostUpdateRequest) PostResponse
+	FindAllByUser(ctx context.Context, userId, callerId int, isAdmin bool, limit, offset int) ([]PostResponse, int)
+	FindAllByFollowed(ctx context.Context, userId, callerId, limit, offset int) ([]PostResponseFollowed, int)
+	FindById(ctx context.Context, postId, userId int, isModerator, isAdmin bool) PostResponse
+	Update(ctx context.Context, request PostUpdateRequest, isModerator, isAdmin bool) PostResponse
 	Delete(ctx context.Context, postId int)
+	FindAllPending(ctx context.Context, limit, offset int, isModerator bool) ([]PostResponse, int)
+	Approve(ctx context.Context, request PostModerationRequest, isModerator bool) PostResponse
+	Reject(ctx context.Context, request PostModerationRequest, isModerator bool) PostResponse
+	UpdateCommentPolicy(ctx context.Context, request PostCommentPolicyRequest, isAdmin bool) PostResponse
+	FindBySlug(ctx context.Context, username, slug string, userId int, isModerator, isAdmin bool) (PostResponse, string)
+	FindAllScheduled(ctx context.Context, userId, callerId int, isAdmin bool, limit, offset int) ([]PostResponse, int)
+	Reschedule(ctx context.Context, request PostScheduleRequest, isAdmin bool) PostResponse
+	FindAllRevisions(ctx context.Context, postId, userId int, isModerator bool, limit, offset int) ([]revision.RevisionResponse, int)
+	DiffRevisions(ctx context.Context, postId, from, to, userId int, isModerator bool) revision.DiffResponse
+	RestoreRevision(ctx context.Context, request PostRevisionRestoreRequest, isAdmin bool) PostResponse
+	FindAllByTag(ctx context.Context, tagName string, callerId, limit, offset int) ([]PostResponse, int)
+	FindAll(ctx context.Context, filter PostFilterRequest, limit, offset int) ([]PostResponse, int)
+	FindAllDrafts(ctx context.Context, userId, callerId int, isAdmin bool, limit, offset int) ([]PostResponse, int)
+	React(ctx context.Context, request reaction.ReactionRequest, isModerator, isAdmin bool) PostResponse
+	Unreact(ctx context.Context, postId, userId int, isModerator, isAdmin bool) PostResponse
+	FindAllReactions(ctx context.Context, postId, userId int, filter string, isModerator, isAdmin bool, limit, offset int) ([]reaction.ReactionResponse, int)
 }
 
 type PostServiceImpl struct {
-	repository PostRepository
-	db         *sql.DB
-	validator  *validator.Validate
+	repository         PostRepository
+	userRepository     user.UserRepository
+	auditRepository    audit.AuditRepository
+	revisionRepository revision.RevisionRepository
+	tagRepository      tag.TagRepository
+	reactionRepository reaction.ReactionRepository
+	pipeline           contentfilter.Pipeline
+	moderation         moderationPolicy
+	db                 *sql.DB
+	validator          *validator.Validate
 }
 
-func NewPostService(postRepository PostRepository, db *sql.DB, validator *validator.Validate) PostService {
+func NewPostService(postRepository PostRepository, userRepository user.UserRepository, auditRepository audit.AuditRepository, revisionRepository revision.RevisionRepository, tagRepository tag.TagRepository, reactionRepository reaction.ReactionRepository, pipeline contentfilter.Pipeline, db *sql.DB, validator *validator.Validate) PostService {
 	return &PostServiceImpl{
-		repository: postRepository,
-		db:         db,
-		validator:  validator,
+		repository:         postRepository,
+		userRepository:     userRepository,
+		auditRepository:    auditRepository,
+		revisionRepository: revisionRepository,
+		tagRepository:      tagRepository,
+		reactionRepository: reactionRepository,
+		pipeline:           pipeline,
+		moderation:         moderationPolicy{repository: postRepository, userRepository: userRepository, pipeline: pipeline},
+		db:                 db,
+		validator:          validator,
 	}
 }
 
@@ -40,26 +80,65 @@ func (service *PostServiceImpl) Create(ctx context.Context, request PostCreateRe
 	helpers.PanicError(err, "failed to begin transaction")
 	defer helpers.TxRollbackCommit(tx)
 
+	filtered, flagged := service.pipeline.Check(ctx, tx, request.Title, request.Body)
+
+	slugSource := request.Slug
+	if slugSource == "" {
+		slugSource = filtered[0]
+	}
+
+	bodyFormat := request.Body_Format
+	if bodyFormat == "" {
+		bodyFormat = markup.FormatPlain
+	}
+
+	rendered := markup.Render(bodyFormat, filtered[1])
+
+	tags := normalizeTags(request.Tags)
+
+	publishAt, published := resolveSchedule(request.Publish_At, request.Unpublish_At, request.Published)
+
+	service.repository.LockAuthor(ctx, tx, request.User_Id)
+
 	postRequest := Post{
-		Title:       request.Title,
-		Body:        request.Body,
-		User_Id:     request.User_Id,
-		Published:   request.Published,
-		Category_Id: request.Category_Id,
+		Title:             filtered[0],
+		Slug:              service.uniqueSlug(ctx, tx, request.User_Id, 0, slugSource),
+		Body:              filtered[1],
+		Body_Format:       bodyFormat,
+		Body_Html:         rendered.Html,
+		Body_Toc:          markup.TocJSON(rendered.Toc),
+		User_Id:           request.User_Id,
+		Published:         published,
+		Visibility:        request.Visibility,
+		Publish_At:        publishAt,
+		Unpublish_At:      request.Unpublish_At,
+		Category_Id:       request.Category_Id,
+		Moderation_Status: service.moderation.status(ctx, tx, request.User_Id, flagged),
 	}
 
 	createdPost := service.repository.Save(ctx, tx, postRequest)
 
+	if len(tags) > 0 {
+		service.setTags(ctx, tx, createdPost.Id, tags)
+		createdPost.Tags = tags
+	}
+
+	service.saveRevision(ctx, tx, PostJoin{}, createdPost, request.User_Id, 0)
+
 	return ToPostResponse(createdPost)
 }
 
-func (service *PostServiceImpl) FindAllByUser(ctx context.Context, userId, limit, offset int) ([]PostResponse, int) {
+// FindAllByUser lists the user's drafts alongside their published posts only
+// for the user themselves and admins.
+func (service *PostServiceImpl) FindAllByUser(ctx context.Context, userId, callerId int, isAdmin bool, limit, offset int) ([]PostResponse, int) {
 	tx, err := service.db.Begin()
 	helpers.PanicError(err, "failed to begin transaction")
 	defer helpers.TxRollbackCommit(tx)
 
-	posts := service.repository.FindAllByUser(ctx, tx, userId, limit, offset)
-	countPosts := service.repository.CountPostsByUser(ctx, tx, userId)
+	includeDrafts := userId == callerId || isAdmin
+
+	posts := service.repository.FindAllByUser(ctx, tx, userId, callerId, includeDrafts, limit, offset)
+	countPosts := service.repository.CountPostsByUser(ctx, tx, userId, callerId, includeDrafts)
 
 	var postsData []PostResponse
 
@@ -67,6 +146,8 @@ func (service *PostServiceImpl) FindAllByUser(ctx context.Context, userId, limit
 		panic(exception.NewNotFoundError("posts not found"))
 	}
 
+	posts = service.attachReactions(ctx, tx, posts, callerId)
+
 	for _, post := range posts {
 		postsData = append(postsData, ToPostResponse(post))
 	}
@@ -74,12 +155,12 @@ func (service *PostServiceImpl) FindAllByUser(ctx context.Context, userId, limit
 	return postsData, countPosts
 }
 
-func (service *PostServiceImpl) FindAllByFollowed(ctx context.Context, userId, limit, offset int) ([]PostResponseFollowed, int) {
+func (service *PostServiceImpl) FindAllByFollowed(ctx context.Context, userId, callerId, limit, offset int) ([]PostResponseFollowed, int) {
 	tx, err := service.db.Begin()
 	helpers.PanicError(err, "failed to begin transaction")
 	defer helpers.TxRollbackCommit(tx)
 
-	postsByFollowed := service.repository.FindAllByFollowed(ctx, tx, userId, limit, offset)
+	postsByFollowed := service.repository.FindAllByFollowed(ctx, tx, userId, callerId, limit, offset)
 
 	var postByFollowedData []PostResponseFollowed
 
@@ -87,6 +168,8 @@ func (service *PostServiceImpl) FindAllByFollowed(ctx context.Context, userId, l
 		panic(exception.NewNotFoundError("posts not found"))
 	}
 
+	postsByFollowed = service.attachFollowedReactions(ctx, tx, postsByFollowed, callerId)
+
 	for _, post := range postsByFollowed {
 		postByFollowedData = append(postByFollowedData, ToPostResponseFollowed(post))
 	}
@@ -94,17 +177,47 @@ func (service *PostServiceImpl) FindAllByFollowed(ctx context.Context, userId, l
 	return postByFollowedData, len(postsByFollowed)
 }
 
-func (service *PostServiceImpl) FindById(ctx context.Context, postId int) PostResponse {
+func (service *PostServiceImpl) FindById(ctx context.Context, postId, userId int, isModerator, isAdmin bool) PostResponse {
 	tx, err := service.db.Begin()
 	helpers.PanicError(err, "failed to begin transaction")
 	defer helpers.TxRollbackCommit(tx)
 
 	post := service.repository.FindById(ctx, tx, postId)
 
-	return ToPostResponse(post)
+	checkVisible(post, userId, isModerator, isAdmin)
+	checkAudience(ctx, tx, service.repository, post, userId)
+
+	return service.withReactions(ctx, tx, post, userId)
 }
 
-func (service *PostServiceImpl) Update(ctx context.Context, request PostUpdateRequest) PostResponse {
+// FindBySlug returns the post, or the slug it now lives at when an old slug
+// was requested so the caller can redirect.
+func (service *PostServiceImpl) FindBySlug(ctx context.Context, username, slug string, userId int, isModerator, isAdmin bool) (PostResponse, string) {
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	postId := service.repository.FindIdBySlug(ctx, tx, username, slug)
+
+	if postId == 0 {
+		if currentSlug := service.repository.FindSlugRedirect(ctx, tx, username, slug); currentSlug != "" {
+			return PostResponse{}, currentSlug
+		}
+
+		panic(exception.NewNotFoundError("post not found"))
+	}
+
+	post := service.repository.FindById(ctx, tx, postId)
+
+	checkVisible(post, userId, isModerator, isAdmin)
+	checkAudience(ctx, tx, service.repository, post, userId)
+
+	return service.withReactions(ctx, tx, post, userId), ""
+}
+
+// Update edits a post for its author or an admin. The author and the
+// deleted flag always come from the stored post, never from the request.
+func (service *PostServiceImpl) Update(ctx context.Context, request PostUpdateRequest, isModerator, isAdmin bool) PostResponse {
 	err := service.validator.Struct(request)
 	helpers.PanicError(err, "invalid request")
 
@@ -112,23 +225,69 @@ func (service *PostServiceImpl) Update(ctx context.Context, request PostUpdateRe
 	helpers.PanicError(err, "failed to begin transaction")
 	defer helpers.TxRollbackCommit(tx)
 
-	service.repository.FindById(ctx, tx, request.Id)
+	post := service.repository.FindById(ctx, tx, request.Id)
+
+	checkVisible(post, request.User_Id, isModerator, isAdmin)
+	checkAudience(ctx, tx, service.repository, post, request.User_Id)
+
+	if post.User.Id != request.User_Id && !isAdmin {
+		panic(exception.NewBadRequestError("only the post author or admin can update a post"))
+	}
+
+	filtered, flagged := service.pipeline.Check(ctx, tx, request.Title, request.Body)
+
+	bodyFormat := request.Body_Format
+	if bodyFormat == "" {
+		bodyFormat = post.Body_Format
+	}
+
+	rendered := markup.Render(bodyFormat, filtered[1])
+
+	// A post keeps its schedule unless the update brings a new one.
+	publishAt := request.Publish_At
+	if publishAt.IsZero() {
+		publishAt = post.Publish_At
+	}
+	unpublishAt := request.Unpublish_At
+	if unpublishAt.IsZero() {
+		unpublishAt = post.Unpublish_At
+	}
+
+	publishAt, published := resolveSchedule(publishAt, unpublishAt, request.Published)
+
+	// Tags are only replaced when the update sends them, an empty list clears
+	// them.
+	if request.Tags != nil {
+		service.setTags(ctx, tx, post.Id, normalizeTags(request.Tags))
+	}
 
 	updatePostData := Post{
-		Id:          request.Id,
-		Title:       request.Title,
-		Body:        request.Body,
-		User_Id:     request.User_Id,
-		Category_Id: request.Category_Id,
-		Published:   request.Published,
-		Deleted:     request.Deleted,
+		Id:                request.Id,
+		Title:             filtered[0],
+		Body:              filtered[1],
+		Body_Format:       bodyFormat,
+		Body_Html:         rendered.Html,
+		Body_Toc:          markup.TocJSON(rendered.Toc),
+		User_Id:           post.User.Id,
+		Category_Id:       request.Category_Id,
+		Published:         published,
+		Visibility:        request.Visibility,
+		Publish_At:        publishAt,
+		Unpublish_At:      unpublishAt,
+		Moderation_Status: service.moderation.status(ctx, tx, post.User.Id, flagged),
+	}
+
+	if request.Slug != "" && helpers.Slugify(request.Slug) != post.Slug {
+		service.changeSlug(ctx, tx, post, request.Slug)
 	}
 
 	updatedPost := service.repository.Update(ctx, tx, updatePostData)
 
 	helpers.PanicError(err, "failed to exec query update post")
 
-	return ToPostResponse(updatedPost)
+	service.saveRevision(ctx, tx, post, updatedPost, request.User_Id, 0)
+
+	return service.withReactions(ctx, tx, updatedPost, request.User_Id)
 }
 
 func (service *PostServiceImpl) Delete(ctx context.Context, postId int) {
@@ -138,3 +297,540 @@ func (service *PostServiceImpl) Delete(ctx context.Context, postId int) {
 
 	service.repository.Delete(ctx, tx, postId)
 }
+
+func (service *PostServiceImpl) FindAllDrafts(ctx context.Context, userId, callerId int, isAdmin bool, limit, offset int) ([]PostResponse, int) {
+	if userId != callerId && !isAdmin {
+		panic(exception.NewBadRequestError("only the post author or admin can see drafts"))
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	posts := service.repository.FindAllDraftsByUser(ctx, tx, userId, limit, offset)
+	countPosts := service.repository.CountDraftsByUser(ctx, tx, userId)
+	posts = service.attachReactions(ctx, tx, posts, callerId)
+
+	postsData := []PostResponse{}
+
+	for _, post := range posts {
+		postsData = append(postsData, ToPostResponse(post))
+	}
+
+	return postsData, countPosts
+}
+
+func (service *PostServiceImpl) FindAll(ctx context.Context, filter PostFilterRequest, limit, offset int) ([]PostResponse, int) {
+	err := service.validator.Struct(filter)
+	helpers.PanicError(err, "invalid request")
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	posts := service.repository.FindAll(ctx, tx, filter, limit, offset)
+	countPosts := service.repository.CountAll(ctx, tx, filter)
+	posts = service.attachReactions(ctx, tx, posts, filter.Caller_Id)
+
+	postsData := []PostResponse{}
+
+	for _, post := range posts {
+		postsData = append(postsData, ToPostResponse(post))
+	}
+
+	return postsData, countPosts
+}
+
+func (service *PostServiceImpl) FindAllByTag(ctx context.Context, tagName string, callerId, limit, offset int) ([]PostResponse, int) {
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	name := tag.Normalize(tagName)
+
+	posts := service.repository.FindAllByTag(ctx, tx, name, callerId, limit, offset)
+	countPosts := service.repository.CountByTag(ctx, tx, name, callerId)
+	posts = service.attachReactions(ctx, tx, posts, callerId)
+
+	postsData := []PostResponse{}
+
+	for _, post := range posts {
+		postsData = append(postsData, ToPostResponse(post))
+	}
+
+	return postsData, countPosts
+}
+
+func (service *PostServiceImpl) FindAllPending(ctx context.Context, limit, offset int, isModerator bool) ([]PostResponse, int) {
+	if !isModerator {
+		panic(exception.NewBadRequestError("only moderators can get the pending posts queue"))
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	posts := service.repository.FindAllPending(ctx, tx, limit, offset)
+	countPosts := service.repository.CountPending(ctx, tx)
+
+	var postsData []PostResponse
+
+	for _, post := range posts {
+		postsData = append(postsData, ToPostResponse(post))
+	}
+
+	return postsData, countPosts
+}
+
+func (service *PostServiceImpl) Approve(ctx context.Context, request PostModerationRequest, isModerator bool) PostResponse {
+	if !isModerator {
+		panic(exception.NewBadRequestError("only moderators can approve posts"))
+	}
+
+	return service.moderate(ctx, request, ModerationApproved, audit.ActionPostApprove)
+}
+
+func (service *PostServiceImpl) Reject(ctx context.Context, request PostModerationRequest, isModerator bool) PostResponse {
+	if !isModerator {
+		panic(exception.NewBadRequestError("only moderators can reject posts"))
+	}
+
+	return service.moderate(ctx, request, ModerationRejected, audit.ActionPostReject)
+}
+
+func (service *PostServiceImpl) UpdateCommentPolicy(ctx context.Context, request PostCommentPolicyRequest, isAdmin bool) PostResponse {
+	err := service.validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	post := service.repository.FindById(ctx, tx, request.Id)
+
+	if post.User.Id != request.User_Id && !isAdmin {
+		panic(exception.NewBadRequestError("only the post author can change the comment policy"))
+	}
+
+	service.repository.UpdateCommentPolicy(ctx, tx, post.Id, request.Comment_Policy)
+
+	return service.withReactions(ctx, tx, service.repository.FindById(ctx, tx, post.Id), request.User_Id)
+}
+
+func (service *PostServiceImpl) FindAllScheduled(ctx context.Context, userId, callerId int, isAdmin bool, limit, offset int) ([]PostResponse, int) {
+	if userId != callerId && !isAdmin {
+		panic(exception.NewBadRequestError("only the post author or admin can see scheduled posts"))
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	posts := service.repository.FindAllScheduledByUser(ctx, tx, userId, limit, offset)
+	countPosts := service.repository.CountScheduledByUser(ctx, tx, userId)
+	posts = service.attachReactions(ctx, tx, posts, callerId)
+
+	var postsData []PostResponse
+
+	for _, post := range posts {
+		postsData = append(postsData, ToPostResponse(post))
+	}
+
+	return postsData, countPosts
+}
+
+// Reschedule replaces both schedule times of a post. A zero publish_at
+// cancels a pending publish and leaves the post as it is.
+func (service *PostServiceImpl) Reschedule(ctx context.Context, request PostScheduleRequest, isAdmin bool) PostResponse {
+	err := service.validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	post := service.repository.FindById(ctx, tx, request.Id)
+
+	if post.User.Id != request.User_Id && !isAdmin {
+		panic(exception.NewBadRequestError("only the post author or admin can reschedule a post"))
+	}
+
+	publishAt, published := resolveSchedule(request.Publish_At, request.Unpublish_At, post.Published || !request.Publish_At.IsZero())
+
+	// A draft or scheduled post that goes live now is checked the same way
+	// the scheduler would check it.
+	moderationStatus := ""
+	if published && !post.Published {
+		moderationStatus = service.moderation.liveStatus(ctx, tx, post)
+	}
+
+	service.repository.UpdateSchedule(ctx, tx, Post{
+		Id:                post.Id,
+		Published:         published,
+		Publish_At:        publishAt,
+		Unpublish_At:      request.Unpublish_At,
+		Moderation_Status: moderationStatus,
+	})
+
+	return service.withReactions(ctx, tx, service.repository.FindById(ctx, tx, post.Id), request.User_Id)
+}
+
+func (service *PostServiceImpl) FindAllRevisions(ctx context.Context, postId, userId int, isModerator bool, limit, offset int) ([]revision.RevisionResponse, int) {
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	post := service.repository.FindById(ctx, tx, postId)
+
+	if post.User.Id != userId && !isModerator {
+		panic(exception.NewBadRequestError("only the post author or moderators can see revisions"))
+	}
+
+	revisions := service.revisionRepository.FindAllByPost(ctx, tx, post.Id, limit, offset)
+	countRevisions := service.revisionRepository.CountByPost(ctx, tx, post.Id)
+
+	var revisionsData []revision.RevisionResponse
+
+	for _, postRevision := range revisions {
+		revisionsData = append(revisionsData, revision.ToRevisionResponse(postRevision))
+	}
+
+	return revisionsData, countRevisions
+}
+
+func (service *PostServiceImpl) DiffRevisions(ctx context.Context, postId, from, to, userId int, isModerator bool) revision.DiffResponse {
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	post := service.repository.FindById(ctx, tx, postId)
+
+	if post.User.Id != userId && !isModerator {
+		panic(exception.NewBadRequestError("only the post author or moderators can see revisions"))
+	}
+
+	fromRevision := service.revisionRepository.FindByNumber(ctx, tx, post.Id, from)
+	toRevision := service.revisionRepository.FindByNumber(ctx, tx, post.Id, to)
+
+	return revision.ToDiffResponse(fromRevision, toRevision)
+}
+
+// RestoreRevision copies an old revision back onto the post. The restore is
+// itself recorded as a new revision, so history is never rewritten.
+func (service *PostServiceImpl) RestoreRevision(ctx context.Context, request PostRevisionRestoreRequest, isAdmin bool) PostResponse {
+	err := service.validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	post := service.repository.FindById(ctx, tx, request.Id)
+
+	if post.User.Id != request.User_Id && !isAdmin {
+		panic(exception.NewBadRequestError("only the post author or admin can restore a revision"))
+	}
+
+	oldRevision := service.revisionRepository.FindByNumber(ctx, tx, post.Id, request.Revision_Number)
+
+	// The restored text goes through the filter and moderation like any
+	// other edit, rules may have changed since the revision was saved.
+	filtered, flagged := service.pipeline.Check(ctx, tx, oldRevision.Title, oldRevision.Body)
+	rendered := markup.Render(oldRevision.Body_Format, filtered[1])
+
+	restoredPost := service.repository.Update(ctx, tx, Post{
+		Id:                post.Id,
+		Title:             filtered[0],
+		Body:              filtered[1],
+		Body_Format:       oldRevision.Body_Format,
+		Body_Html:         rendered.Html,
+		Body_Toc:          markup.TocJSON(rendered.Toc),
+		Category_Id:       oldRevision.Category_Id,
+		Published:         post.Published,
+		Publish_At:        post.Publish_At,
+		Unpublish_At:      post.Unpublish_At,
+		Moderation_Status: service.moderation.status(ctx, tx, post.User.Id, flagged),
+	})
+
+	service.saveRevision(ctx, tx, post, restoredPost, request.User_Id, oldRevision.Revision_Number)
+
+	return service.withReactions(ctx, tx, restoredPost, request.User_Id)
+}
+
+// React adds the user's reaction to a post they can read, or changes the one
+// they left before.
+func (service *PostServiceImpl) React(ctx context.Context, request reaction.ReactionRequest, isModerator, isAdmin bool) PostResponse {
+	err := service.validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	if !reaction.IsAllowed(request.Reaction) {
+		panic(exception.NewBadRequestError("reaction must be one of " + strings.Join(reaction.AllowedSet(), " ")))
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	post := service.repository.FindById(ctx, tx, request.Post_Id)
+
+	checkVisible(post, request.User_Id, isModerator, isAdmin)
+	checkAudience(ctx, tx, service.repository, post, request.User_Id)
+
+	service.reactionRepository.Save(ctx, tx, reaction.Reaction{
+		Post_Id:  post.Id,
+		User_Id:  request.User_Id,
+		Reaction: request.Reaction,
+	})
+
+	return service.withReactions(ctx, tx, post, request.User_Id)
+}
+
+func (service *PostServiceImpl) Unreact(ctx context.Context, postId, userId int, isModerator, isAdmin bool) PostResponse {
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	post := service.repository.FindById(ctx, tx, postId)
+
+	checkVisible(post, userId, isModerator, isAdmin)
+	checkAudience(ctx, tx, service.repository, post, userId)
+
+	service.reactionRepository.Delete(ctx, tx, post.Id, userId)
+
+	return service.withReactions(ctx, tx, post, userId)
+}
+
+// FindAllReactions lists who reacted to a post, optionally only those who
+// left the given reaction.
+func (service *PostServiceImpl) FindAllReactions(ctx context.Context, postId, userId int, filter string, isModerator, isAdmin bool, limit, offset int) ([]reaction.ReactionResponse, int) {
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	post := service.repository.FindById(ctx, tx, postId)
+
+	checkVisible(post, userId, isModerator, isAdmin)
+	checkAudience(ctx, tx, service.repository, post, userId)
+
+	reactions := service.reactionRepository.FindAllByPost(ctx, tx, post.Id, filter, limit, offset)
+	countReactions := service.reactionRepository.CountByPost(ctx, tx, post.Id, filter)
+
+	reactionsData := []reaction.ReactionResponse{}
+
+	for _, postReaction := range reactions {
+		reactionsData = append(reactionsData, reaction.ToReactionResponse(postReaction))
+	}
+
+	return reactionsData, countReactions
+}
+
+func (service *PostServiceImpl) moderate(ctx context.Context, request PostModerationRequest, status, action string) PostResponse {
+	err := service.validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	post := service.repository.FindById(ctx, tx, request.Id)
+
+	if post.Moderation_Status != ModerationPending || !post.Published {
+		panic(exception.NewBadRequestError("post is not pending review"))
+	}
+
+	service.repository.UpdateModeration(ctx, tx, post.Id, status, request.Moderator_Id, request.Note)
+
+	if status == ModerationApproved {
+		service.moderation.promoteIfEarned(ctx, tx, post.User.Id)
+	}
+
+	moderatedPost := ToPostResponse(service.repository.FindById(ctx, tx, post.Id))
+
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, action, audit.TargetPost, post.Id, ToPostResponse(post), moderatedPost))
+
+	return moderatedPost
+}
+
+// saveRevision records after as the post's next revision and prunes the
+// history to the retention policy. A post edited for the first time since
+// revisions were introduced gets its previous state saved as revision 1.
+func (service *PostServiceImpl) saveRevision(ctx context.Context, tx *sql.Tx, before, after PostJoin, editorId, restoredFrom int) {
+	latest := service.revisionRepository.LatestNumber(ctx, tx, after.Id)
+
+	if latest == 0 && before.Id != 0 {
+		latest++
+		service.revisionRepository.Save(ctx, tx, toRevision(before, before.User.Id, latest, 0))
+	}
+
+	service.revisionRepository.Save(ctx, tx, toRevision(after, editorId, latest+1, restoredFrom))
+	service.revisionRepository.Prune(ctx, tx, after.Id, service.revisionRepository.FindPolicy(ctx, tx))
+}
+
+func toRevision(post PostJoin, editorId, revisionNumber, restoredFrom int) revision.Revision {
+	return revision.Revision{
+		Post_Id:         post.Id,
+		Revision_Number: revisionNumber,
+		User_Id:         editorId,
+		Title:           post.Title,
+		Body:            post.Body,
+		Body_Format:     post.Body_Format,
+		Category_Id:     post.Category.Id,
+		Restored_From:   restoredFrom,
+	}
+}
+
+// attachReactions loads the reaction counts and the caller's own reaction for
+// a whole page of posts with a single query.
+func (service *PostServiceImpl) attachReactions(ctx context.Context, tx *sql.Tx, posts []PostJoin, callerId int) []PostJoin {
+	postIds := make([]int, 0, len(posts))
+
+	for _, post := range posts {
+		postIds = append(postIds, post.Id)
+	}
+
+	summaries := service.reactionRepository.FindSummaries(ctx, tx, postIds, callerId)
+
+	for i := range posts {
+		posts[i].Reactions = summaries[posts[i].Id]
+	}
+
+	return posts
+}
+
+func (service *PostServiceImpl) attachFollowedReactions(ctx context.Context, tx *sql.Tx, posts []PostJoinFollowed, callerId int) []PostJoinFollowed {
+	postIds := make([]int, 0, len(posts))
+
+	for _, post := range posts {
+		postIds = append(postIds, post.Id)
+	}
+
+	summaries := service.reactionRepository.FindSummaries(ctx, tx, postIds, callerId)
+
+	for i := range posts {
+		posts[i].Reactions = summaries[posts[i].Id]
+	}
+
+	return posts
+}
+
+func (service *PostServiceImpl) withReactions(ctx context.Context, tx *sql.Tx, post PostJoin, callerId int) PostResponse {
+	return ToPostResponse(service.attachReactions(ctx, tx, []PostJoin{post}, callerId)[0])
+}
+
+// setTags replaces the post's tags, creating the ones that do not exist yet.
+func (service *PostServiceImpl) setTags(ctx context.Context, tx *sql.Tx, postId int, names []string) {
+	tagIds := make([]int, 0, len(names))
+
+	for _, name := range names {
+		tagIds = append(tagIds, service.tagRepository.FindOrCreate(ctx, tx, name))
+	}
+
+	service.tagRepository.SetPostTags(ctx, tx, postId, tagIds)
+}
+
+// normalizeTags normalises and dedupes the requested tags. The limit is
+// checked after deduping so "Go" and "#go" only count once.
+func normalizeTags(names []string) []string {
+	tags := tag.NormalizeAll(names)
+
+	if len(tags) > tag.MaxTagsPerPost {
+		panic(exception.NewBadRequestError("a post can have at most " + strconv.Itoa(tag.MaxTagsPerPost) + " tags"))
+	}
+
+	return tags
+}
+
+// resolveSchedule returns the publish_at to store and whether the post is
+// live now. A publish time in the future holds the post back for the
+// scheduler, one that has already passed publishes it straight away.
+func resolveSchedule(publishAt, unpublishAt time.Time, published bool) (time.Time, bool) {
+	if !publishAt.IsZero() && !unpublishAt.IsZero() && !unpublishAt.After(publishAt) {
+		panic(exception.NewBadRequestError("unpublish_at must be after publish_at"))
+	}
+
+	if publishAt.IsZero() || !publishAt.After(time.Now()) {
+		return time.Time{}, published
+	}
+
+	return publishAt, false
+}
+
+// checkVisible hides posts awaiting moderation from everyone but their author
+// and moderators, and drafts from everyone but their author and admins.
+func checkVisible(post PostJoin, userId int, isModerator, isAdmin bool) {
+	if post.User.Id == userId {
+		return
+	}
+
+	if post.Moderation_Status != ModerationApproved && !isModerator {
+		panic(exception.NewNotFoundError("post not found"))
+	}
+
+	if !post.Published && !isAdmin {
+		panic(exception.NewNotFoundError("post not found"))
+	}
+}
+
+// checkAudience applies the post's visibility level. Unlisted posts are
+// served to anyone with the link, followers-only posts to the author's
+// followers and private posts to nobody but the author.
+func checkAudience(ctx context.Context, tx *sql.Tx, repository PostRepository, post PostJoin, userId int) {
+	if post.User.Id == userId {
+		return
+	}
+
+	switch post.Visibility {
+	case VisibilityPrivate:
+		panic(exception.NewNotFoundError("post not found"))
+	case VisibilityFollowers:
+		if !repository.IsFollower(ctx, tx, userId, post.User.Id) {
+			panic(exception.NewNotFoundError("post not found"))
+		}
+	}
+}
+
+// FindReadable loads a post and panics with not found unless the caller may
+// read it, the same checks the post endpoints make. Packages serving content
+// attached to a post, such as comments, use it before touching that content.
+func FindReadable(ctx context.Context, tx *sql.Tx, repository PostRepository, postId, userId int, isModerator, isAdmin bool) PostJoin {
+	post := repository.FindById(ctx, tx, postId)
+
+	checkVisible(post, userId, isModerator, isAdmin)
+	checkAudience(ctx, tx, repository, post, userId)
+
+	return post
+}
+
+// uniqueSlug slugifies source and appends -2, -3, ... until it is free for
+// the author. Titles without any transliterable letters fall back to "post".
+func (service *PostServiceImpl) uniqueSlug(ctx context.Context, tx *sql.Tx, authorId, postId int, source string) string {
+	base := helpers.Slugify(source)
+	if base == "" {
+		base = "post"
+	}
+
+	slug := base
+
+	for suffix := 2; service.repository.IsSlugTaken(ctx, tx, authorId, slug, postId); suffix++ {
+		ending := "-" + strconv.Itoa(suffix)
+		slug = strings.TrimRight(base[:min(len(base), helpers.SlugMaxLength-len(ending))], "-") + ending
+	}
+
+	return slug
+}
+
+// changeSlug keeps the old slug in the history so existing links redirect to
+// the new one.
+func (service *PostServiceImpl) changeSlug(ctx context.Context, tx *sql.Tx, post PostJoin, source string) {
+	service.repository.LockAuthor(ctx, tx, post.User.Id)
+
+	slug := service.uniqueSlug(ctx, tx, post.User.Id, post.Id, source)
+
+	if slug == post.Slug {
+		return
+	}
+
+	service.repository.DeleteSlugHistory(ctx, tx, post.User.Id, slug)
+	service.repository.SaveSlugHistory(ctx, tx, SlugHistory{Post_Id: post.Id, User_Id: post.User.Id, Slug: post.Slug})
+	service.repository.UpdateSlug(ctx, tx, post.Id, slug)
+}
@@ -0,0 +1,63 @@
+package reaction
+
+import (
+	"strings"
+	"time"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/user"
+)
+
+// DefaultSet is used when REACTION_SET is not configured.
+const DefaultSet = "👍,❤️,😂,😮,😢,🎉"
+
+type Reaction struct {
+	Post_Id    int
+	User_Id    int
+	Reaction   string
+	Created_At time.Time
+	Updated_At time.Time
+}
+
+type ReactionJoin struct {
+	Post_Id    int
+	Reaction   string
+	Created_At time.Time
+	Updated_At time.Time
+	User       user.User
+}
+
+// Summary is what a post response shows of its reactions: how many of each
+// and the one the caller left, if any.
+type Summary struct {
+	Counts map[string]int
+	Mine   string
+}
+
+// AllowedSet returns the configured reactions in the order they are listed.
+func AllowedSet() []string {
+	set := helpers.NewEnv().Reaction.Set
+	if set == "" {
+		set = DefaultSet
+	}
+
+	var reactions []string
+
+	for _, reaction := range strings.Split(set, ",") {
+		if reaction = strings.TrimSpace(reaction); reaction != "" {
+			reactions = append(reactions, reaction)
+		}
+	}
+
+	return reactions
+}
+
+func IsAllowed(value string) bool {
+	for _, reaction := range AllowedSet() {
+		if reaction == value {
+			return true
+		}
+	}
+
+	return false
+}
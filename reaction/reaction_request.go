@@ -0,0 +1,7 @@
+package reaction
+
+type ReactionRequest struct {
+	Post_Id  int    `json:"post_id" validate:"required"`
+	User_Id  int    `json:"user_id" validate:"required"`
+	Reaction string `json:"reaction" validate:"required,max=32"`
+}
@@ -0,0 +1,25 @@
+package reaction
+
+import (
+	"time"
+
+	"github.com/hutamatr/GoBlogify/user"
+)
+
+type ReactionResponse struct {
+	Post_Id    int                     `json:"post_id"`
+	Reaction   string                  `json:"reaction"`
+	Created_At time.Time               `json:"created_at"`
+	Updated_At time.Time               `json:"updated_at"`
+	User       user.UserFollowResponse `json:"user"`
+}
+
+func ToReactionResponse(reaction ReactionJoin) ReactionResponse {
+	return ReactionResponse{
+		Post_Id:    reaction.Post_Id,
+		Reaction:   reaction.Reaction,
+		Created_At: reaction.Created_At,
+		Updated_At: reaction.Updated_At,
+		User:       user.ToUserFollowResponse(reaction.User),
+	}
+}
@@ -0,0 +1,152 @@
+package reaction
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type ReactionRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, reaction Reaction)
+	Delete(ctx context.Context, tx *sql.Tx, postId, userId int)
+	FindAllByPost(ctx context.Context, tx *sql.Tx, postId int, reaction string, limit, offset int) []ReactionJoin
+	CountByPost(ctx context.Context, tx *sql.Tx, postId int, reaction string) int
+	FindSummaries(ctx context.Context, tx *sql.Tx, postIds []int, userId int) map[int]Summary
+}
+
+type ReactionRepositoryImpl struct {
+}
+
+func NewReactionRepository() ReactionRepository {
+	return &ReactionRepositoryImpl{}
+}
+
+// Save adds the user's reaction to the post, or replaces the one they left
+// before.
+func (repository *ReactionRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, reaction Reaction) {
+	query := "INSERT INTO post_reaction(post_id, user_id, reaction) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE reaction = VALUES(reaction)"
+
+	_, err := tx.ExecContext(ctx, query, reaction.Post_Id, reaction.User_Id, reaction.Reaction)
+	helpers.PanicError(err, "failed to exec query save reaction")
+}
+
+func (repository *ReactionRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, postId, userId int) {
+	query := "DELETE FROM post_reaction WHERE post_id = ? AND user_id = ?"
+
+	result, err := tx.ExecContext(ctx, query, postId, userId)
+	helpers.PanicError(err, "failed to exec query delete reaction")
+
+	resultRows, err := result.RowsAffected()
+	helpers.PanicError(err, "failed to display rows affected delete reaction")
+
+	if resultRows == 0 {
+		panic(exception.NewNotFoundError("reaction not found"))
+	}
+}
+
+// FindAllByPost lists who reacted to the post, newest first. An empty
+// reaction lists every reaction.
+func (repository *ReactionRepositoryImpl) FindAllByPost(ctx context.Context, tx *sql.Tx, postId int, reaction string, limit, offset int) []ReactionJoin {
+	query := `SELECT r.post_id, r.reaction, r.created_at, r.updated_at, u.id, u.username, u.first_name, u.last_name
+	FROM post_reaction r
+	JOIN user u ON u.id = r.user_id
+	WHERE r.post_id = ?
+	AND (? = '' OR r.reaction = ?)
+	AND u.is_deleted = false
+	AND u.is_deactivated = false
+	ORDER BY r.updated_at DESC, u.id DESC LIMIT ? OFFSET ?`
+
+	rows, err := tx.QueryContext(ctx, query, postId, reaction, reaction, limit, offset)
+	helpers.PanicError(err, "failed to query all reactions by post")
+
+	defer rows.Close()
+
+	reactions := []ReactionJoin{}
+
+	for rows.Next() {
+		var reaction ReactionJoin
+		var firstName sql.NullString
+		var lastName sql.NullString
+
+		err := rows.Scan(&reaction.Post_Id, &reaction.Reaction, &reaction.Created_At, &reaction.Updated_At, &reaction.User.Id, &reaction.User.Username, &firstName, &lastName)
+		helpers.PanicError(err, "failed to scan all reactions by post")
+
+		reaction.User.First_Name = firstName.String
+		reaction.User.Last_Name = lastName.String
+
+		reactions = append(reactions, reaction)
+	}
+
+	return reactions
+}
+
+func (repository *ReactionRepositoryImpl) CountByPost(ctx context.Context, tx *sql.Tx, postId int, reaction string) int {
+	query := `SELECT COUNT(*)
+	FROM post_reaction r
+	JOIN user u ON u.id = r.user_id
+	WHERE r.post_id = ?
+	AND (? = '' OR r.reaction = ?)
+	AND u.is_deleted = false
+	AND u.is_deactivated = false`
+
+	var countReactions int
+	err := tx.QueryRowContext(ctx, query, postId, reaction, reaction).Scan(&countReactions)
+	helpers.PanicError(err, "failed to query count reactions by post")
+
+	return countReactions
+}
+
+// FindSummaries counts the reactions of every given post and picks out the
+// user's own in a single query, so a page of posts costs one round trip.
+func (repository *ReactionRepositoryImpl) FindSummaries(ctx context.Context, tx *sql.Tx, postIds []int, userId int) map[int]Summary {
+	summaries := map[int]Summary{}
+
+	if len(postIds) == 0 {
+		return summaries
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(postIds)), ", ")
+	query := `SELECT r.post_id, r.reaction, COUNT(*), MAX(r.user_id = ?)
+	FROM post_reaction r
+	JOIN user u ON u.id = r.user_id
+	WHERE r.post_id IN (` + placeholders + `)
+	AND u.is_deleted = false
+	AND u.is_deactivated = false
+	GROUP BY r.post_id, r.reaction`
+
+	args := make([]interface{}, 0, len(postIds)+1)
+	args = append(args, userId)
+	for _, postId := range postIds {
+		args = append(args, postId)
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	helpers.PanicError(err, "failed to query reaction summaries")
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var postId, count, mine int
+		var reaction string
+
+		err := rows.Scan(&postId, &reaction, &count, &mine)
+		helpers.PanicError(err, "failed to scan reaction summaries")
+
+		summary, ok := summaries[postId]
+		if !ok {
+			summary.Counts = map[string]int{}
+		}
+
+		summary.Counts[reaction] = count
+		if mine == 1 {
+			summary.Mine = reaction
+		}
+
+		summaries[postId] = summary
+	}
+
+	return summaries
+}
@@ -0,0 +1,139 @@
+package report
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/julienschmidt/httprouter"
+)
+
+type ReportController interface {
+	CreateReportHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindMyReportsHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAllReportHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ResolveReportHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
+
+type ReportControllerImpl struct {
+	service ReportService
+}
+
+func NewReportController(service ReportService) ReportController {
+	return &ReportControllerImpl{
+		service: service,
+	}
+}
+
+func (controller *ReportControllerImpl) CreateReportHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	var reportRequest ReportCreateRequest
+	helpers.DecodeJSONFromRequest(request, &reportRequest)
+
+	reportRequest.Reporter_Id = helpers.GetUserId(request)
+	isModerator := helpers.IsModerator(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	report := controller.service.Create(request.Context(), reportRequest, isModerator, isAdmin)
+
+	reportResponse := helpers.ResponseJSON{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   report,
+	}
+
+	writer.WriteHeader(http.StatusCreated)
+	helpers.EncodeJSONFromResponse(writer, reportResponse)
+}
+
+func (controller *ReportControllerImpl) FindMyReportsHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	reporterId := helpers.GetUserId(request)
+	limit, offset := helpers.GetLimitOffset(request)
+
+	reports, countReports := controller.service.FindAllByReporter(request.Context(), reporterId, limit, offset)
+
+	reportResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"reports": reports,
+			"limit":   limit,
+			"offset":  offset,
+			"total":   countReports,
+		},
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, reportResponse)
+}
+
+func (controller *ReportControllerImpl) FindAllReportHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	isModerator := helpers.IsModerator(request)
+	limit, offset := helpers.GetLimitOffset(request)
+	query := request.URL.Query()
+
+	reporterId, err := parseIdQuery(query.Get("reporter_id"))
+	if err != nil {
+		panic(exception.NewBadRequestError("invalid reporter_id"))
+	}
+
+	targetUserId, err := parseIdQuery(query.Get("target_user_id"))
+	if err != nil {
+		panic(exception.NewBadRequestError("invalid target_user_id"))
+	}
+
+	filter := ReportFilterRequest{
+		Status:         query.Get("status"),
+		Target_Type:    query.Get("target_type"),
+		Reason:         query.Get("reason"),
+		Reporter_Id:    reporterId,
+		Target_User_Id: targetUserId,
+	}
+
+	reports, countReports := controller.service.FindAll(request.Context(), filter, limit, offset, isModerator)
+
+	reportResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"reports": reports,
+			"limit":   limit,
+			"offset":  offset,
+			"total":   countReports,
+		},
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, reportResponse)
+}
+
+func (controller *ReportControllerImpl) ResolveReportHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	var resolveRequest ReportResolveRequest
+	helpers.DecodeJSONFromRequest(request, &resolveRequest)
+
+	id := params.ByName("reportId")
+	reportId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid Report Id")
+
+	resolveRequest.Id = reportId
+	resolveRequest.Moderator_Id = helpers.GetUserId(request)
+	isModerator := helpers.IsModerator(request)
+
+	report := controller.service.Resolve(request.Context(), resolveRequest, isModerator)
+
+	reportResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "UPDATED",
+		Data:   report,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, reportResponse)
+}
+
+func parseIdQuery(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
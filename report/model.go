@@ -0,0 +1,38 @@
+package report
+
+import "time"
+
+const (
+	TargetPost    = "post"
+	TargetComment = "comment"
+	TargetUser    = "user"
+)
+
+const (
+	StatusOpen     = "open"
+	StatusResolved = "resolved"
+)
+
+const (
+	ActionDismiss = "dismiss"
+	ActionHide    = "hide"
+	ActionDelete  = "delete"
+	ActionSuspend = "suspend"
+)
+
+type Report struct {
+	Id              int
+	Reporter_Id     int
+	Target_Type     string
+	Target_Id       int
+	Target_User_Id  int
+	Reason          string
+	Note            string
+	Status          string
+	Action          string
+	Resolution_Note string
+	Resolved_By     int
+	Resolved_At     time.Time
+	Created_At      time.Time
+	Updated_At      time.Time
+}
@@ -0,0 +1,26 @@
+package report
+
+type ReportCreateRequest struct {
+	Reporter_Id int    `json:"reporter_id" validate:"required"`
+	Target_Type string `json:"target_type" validate:"required,oneof=post comment user"`
+	Target_Id   int    `json:"target_id" validate:"required"`
+	Reason      string `json:"reason" validate:"required,oneof=spam harassment hate violence sexual misinformation other"`
+	Note        string `json:"note" validate:"max=1000"`
+}
+
+type ReportResolveRequest struct {
+	Id               int    `json:"id" validate:"required"`
+	Moderator_Id     int    `json:"moderator_id" validate:"required"`
+	Action           string `json:"action" validate:"required,oneof=dismiss hide delete suspend"`
+	Note             string `json:"note" validate:"max=1000"`
+	Suspension_Hours int    `json:"suspension_hours" validate:"omitempty,min=1"`
+	Permanent        bool   `json:"permanent"`
+}
+
+type ReportFilterRequest struct {
+	Status         string `validate:"omitempty,oneof=open resolved all"`
+	Target_Type    string `validate:"omitempty,oneof=post comment user"`
+	Reason         string
+	Reporter_Id    int
+	Target_User_Id int
+}
@@ -0,0 +1,62 @@
+package report
+
+import (
+	"time"
+)
+
+type ReportResponse struct {
+	Id              int       `json:"id"`
+	Reporter_Id     int       `json:"reporter_id"`
+	Target_Type     string    `json:"target_type"`
+	Target_Id       int       `json:"target_id"`
+	Target_User_Id  int       `json:"target_user_id"`
+	Reason          string    `json:"reason"`
+	Note            string    `json:"note"`
+	Status          string    `json:"status"`
+	Action          string    `json:"action"`
+	Resolution_Note string    `json:"resolution_note"`
+	Outcome         string    `json:"outcome"`
+	Resolved_By     int       `json:"resolved_by"`
+	Resolved_At     time.Time `json:"resolved_at"`
+	Created_At      time.Time `json:"created_at"`
+	Updated_At      time.Time `json:"updated_at"`
+}
+
+func ToReportResponse(report Report) ReportResponse {
+	return ReportResponse{
+		Id:              report.Id,
+		Reporter_Id:     report.Reporter_Id,
+		Target_Type:     report.Target_Type,
+		Target_Id:       report.Target_Id,
+		Target_User_Id:  report.Target_User_Id,
+		Reason:          report.Reason,
+		Note:            report.Note,
+		Status:          report.Status,
+		Action:          report.Action,
+		Resolution_Note: report.Resolution_Note,
+		Outcome:         outcome(report),
+		Resolved_By:     report.Resolved_By,
+		Resolved_At:     report.Resolved_At,
+		Created_At:      report.Created_At,
+		Updated_At:      report.Updated_At,
+	}
+}
+
+// outcome is the message shown to the reporter when they check on their
+// reports.
+func outcome(report Report) string {
+	if report.Status != StatusResolved {
+		return "your report is waiting for review"
+	}
+
+	switch report.Action {
+	case ActionHide:
+		return "thanks, the reported content has been hidden"
+	case ActionDelete:
+		return "thanks, the reported content has been removed"
+	case ActionSuspend:
+		return "thanks, the reported account has been suspended"
+	default:
+		return "we reviewed your report and found no violation"
+	}
+}
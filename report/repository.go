@@ -0,0 +1,255 @@
+package report
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type ReportRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, report Report) Report
+	FindById(ctx context.Context, tx *sql.Tx, reportId int) Report
+	FindAll(ctx context.Context, tx *sql.Tx, filter ReportFilterRequest, limit, offset int) []Report
+	CountAll(ctx context.Context, tx *sql.Tx, filter ReportFilterRequest) int
+	HasOpenReport(ctx context.Context, tx *sql.Tx, reporterId int, targetType string, targetId int) bool
+	FindTargetUserId(ctx context.Context, tx *sql.Tx, targetType string, targetId int) int
+	FindCommentPostId(ctx context.Context, tx *sql.Tx, commentId int) int
+	ResolveAllForTarget(ctx context.Context, tx *sql.Tx, report Report) int
+	HideTarget(ctx context.Context, tx *sql.Tx, targetType string, targetId int)
+	DeleteTarget(ctx context.Context, tx *sql.Tx, targetType string, targetId int)
+}
+
+type ReportRepositoryImpl struct {
+}
+
+func NewReportRepository() ReportRepository {
+	return &ReportRepositoryImpl{}
+}
+
+const reportColumns = "id, reporter_id, target_type, target_id, target_user_id, reason, note, status, action, resolution_note, resolved_by, resolved_at, created_at, updated_at"
+
+func (repository *ReportRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, report Report) Report {
+	query := "INSERT INTO report(reporter_id, target_type, target_id, target_user_id, reason, note) VALUES (?, ?, ?, ?, ?, ?)"
+
+	result, err := tx.ExecContext(ctx, query, report.Reporter_Id, report.Target_Type, report.Target_Id, report.Target_User_Id, report.Reason, report.Note)
+	helpers.PanicError(err, "failed to exec query insert report")
+
+	id, err := result.LastInsertId()
+	helpers.PanicError(err, "failed to get last insert id report")
+
+	return repository.FindById(ctx, tx, int(id))
+}
+
+func (repository *ReportRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, reportId int) Report {
+	query := "SELECT " + reportColumns + " FROM report WHERE id = ?"
+
+	rows, err := tx.QueryContext(ctx, query, reportId)
+	helpers.PanicError(err, "failed to query report")
+
+	defer rows.Close()
+
+	if rows.Next() {
+		return scanReport(rows)
+	}
+
+	panic(exception.NewNotFoundError("report not found"))
+}
+
+func reportFilterQuery(filter ReportFilterRequest) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	switch filter.Status {
+	case "all":
+	case StatusResolved:
+		conditions = append(conditions, "status = ?")
+		args = append(args, StatusResolved)
+	default:
+		conditions = append(conditions, "status = ?")
+		args = append(args, StatusOpen)
+	}
+
+	if filter.Target_Type != "" {
+		conditions = append(conditions, "target_type = ?")
+		args = append(args, filter.Target_Type)
+	}
+
+	if filter.Reason != "" {
+		conditions = append(conditions, "reason = ?")
+		args = append(args, filter.Reason)
+	}
+
+	if filter.Reporter_Id > 0 {
+		conditions = append(conditions, "reporter_id = ?")
+		args = append(args, filter.Reporter_Id)
+	}
+
+	if filter.Target_User_Id > 0 {
+		conditions = append(conditions, "target_user_id = ?")
+		args = append(args, filter.Target_User_Id)
+	}
+
+	if len(conditions) == 0 {
+		return "true", args
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func (repository *ReportRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, filter ReportFilterRequest, limit, offset int) []Report {
+	where, args := reportFilterQuery(filter)
+
+	query := "SELECT " + reportColumns + " FROM report WHERE " + where + " ORDER BY created_at ASC, id ASC LIMIT ? OFFSET ?"
+	args = append(args, limit, offset)
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	helpers.PanicError(err, "failed to query reports")
+
+	defer rows.Close()
+
+	var reports []Report
+
+	for rows.Next() {
+		reports = append(reports, scanReport(rows))
+	}
+
+	return reports
+}
+
+func (repository *ReportRepositoryImpl) CountAll(ctx context.Context, tx *sql.Tx, filter ReportFilterRequest) int {
+	where, args := reportFilterQuery(filter)
+
+	query := "SELECT COUNT(*) FROM report WHERE " + where
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	helpers.PanicError(err, "failed to query count reports")
+
+	defer rows.Close()
+
+	var countReports int
+
+	if rows.Next() {
+		err := rows.Scan(&countReports)
+		helpers.PanicError(err, "failed to scan count reports")
+	}
+
+	return countReports
+}
+
+func (repository *ReportRepositoryImpl) HasOpenReport(ctx context.Context, tx *sql.Tx, reporterId int, targetType string, targetId int) bool {
+	query := "SELECT id FROM report WHERE reporter_id = ? AND target_type = ? AND target_id = ? AND status = ?"
+
+	rows, err := tx.QueryContext(ctx, query, reporterId, targetType, targetId, StatusOpen)
+	helpers.PanicError(err, "failed to query open report")
+
+	defer rows.Close()
+
+	return rows.Next()
+}
+
+func (repository *ReportRepositoryImpl) FindTargetUserId(ctx context.Context, tx *sql.Tx, targetType string, targetId int) int {
+	var query string
+
+	switch targetType {
+	case TargetPost:
+		query = "SELECT p.user_id FROM post p JOIN user u ON u.id = p.user_id WHERE p.id = ? AND p.is_deleted = false AND u.is_deleted = false"
+	case TargetComment:
+		query = "SELECT c.user_id FROM comment c JOIN user u ON u.id = c.user_id WHERE c.id = ? AND c.is_deleted = false AND u.is_deleted = false"
+	default:
+		query = "SELECT id FROM user WHERE id = ? AND is_deleted = false"
+	}
+
+	rows, err := tx.QueryContext(ctx, query, targetId)
+	helpers.PanicError(err, "failed to query report target")
+
+	defer rows.Close()
+
+	var userId int
+
+	if rows.Next() {
+		err := rows.Scan(&userId)
+		helpers.PanicError(err, "failed to scan report target")
+	}
+
+	return userId
+}
+
+func (repository *ReportRepositoryImpl) FindCommentPostId(ctx context.Context, tx *sql.Tx, commentId int) int {
+	query := "SELECT post_id FROM comment WHERE id = ? AND is_deleted = false"
+
+	rows, err := tx.QueryContext(ctx, query, commentId)
+	helpers.PanicError(err, "failed to query comment post")
+
+	defer rows.Close()
+
+	var postId int
+
+	if rows.Next() {
+		err := rows.Scan(&postId)
+		helpers.PanicError(err, "failed to scan comment post")
+	}
+
+	return postId
+}
+
+func (repository *ReportRepositoryImpl) ResolveAllForTarget(ctx context.Context, tx *sql.Tx, report Report) int {
+	query := "UPDATE report SET status = ?, action = ?, resolution_note = ?, resolved_by = ?, resolved_at = NOW() WHERE target_type = ? AND target_id = ? AND status = ?"
+
+	result, err := tx.ExecContext(ctx, query, StatusResolved, report.Action, report.Resolution_Note, report.Resolved_By, report.Target_Type, report.Target_Id, StatusOpen)
+	helpers.PanicError(err, "failed to exec query resolve reports")
+
+	rowsAffected, err := result.RowsAffected()
+	helpers.PanicError(err, "failed to get rows affected resolve reports")
+
+	return int(rowsAffected)
+}
+
+func (repository *ReportRepositoryImpl) HideTarget(ctx context.Context, tx *sql.Tx, targetType string, targetId int) {
+	query := "UPDATE post SET is_hidden = true WHERE id = ?"
+	if targetType == TargetComment {
+		query = "UPDATE comment SET is_hidden = true WHERE id = ?"
+	}
+
+	_, err := tx.ExecContext(ctx, query, targetId)
+	helpers.PanicError(err, "failed to exec query hide report target")
+}
+
+func (repository *ReportRepositoryImpl) DeleteTarget(ctx context.Context, tx *sql.Tx, targetType string, targetId int) {
+	query := "UPDATE post SET is_deleted = true, deleted_at = NOW() WHERE id = ?"
+	if targetType == TargetComment {
+		query = "UPDATE comment SET is_deleted = true, deleted_at = NOW() WHERE id = ?"
+	}
+
+	_, err := tx.ExecContext(ctx, query, targetId)
+	helpers.PanicError(err, "failed to exec query delete report target")
+}
+
+func scanReport(rows *sql.Rows) Report {
+	var report Report
+	var action sql.NullString
+	var resolutionNote sql.NullString
+	var resolvedBy sql.NullInt64
+	var resolvedAt sql.NullTime
+
+	err := rows.Scan(&report.Id, &report.Reporter_Id, &report.Target_Type, &report.Target_Id, &report.Target_User_Id, &report.Reason, &report.Note, &report.Status, &action, &resolutionNote, &resolvedBy, &resolvedAt, &report.Created_At, &report.Updated_At)
+	helpers.PanicError(err, "failed to scan report")
+
+	report.Action = action.String
+	report.Resolution_Note = resolutionNote.String
+
+	if resolvedBy.Valid {
+		report.Resolved_By = int(resolvedBy.Int64)
+	}
+
+	if resolvedAt.Valid {
+		report.Resolved_At = resolvedAt.Time
+	} else {
+		report.Resolved_At = time.Time{}
+	}
+
+	return report
+}
@@ -0,0 +1,232 @@
+package report
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/post"
+	"github.com/hutamatr/GoBlogify/suspension"
+)
+
+type ReportService interface {
+	Create(ctx context.Context, request ReportCreateRequest, isModerator, isAdmin bool) ReportResponse
+	FindAllByReporter(ctx context.Context, reporterId, limit, offset int) ([]ReportResponse, int)
+	FindAll(ctx context.Context, filter ReportFilterRequest, limit, offset int, isModerator bool) ([]ReportResponse, int)
+	Resolve(ctx context.Context, request ReportResolveRequest, isModerator bool) ReportResponse
+}
+
+type ReportServiceImpl struct {
+	repository           ReportRepository
+	postRepository       post.PostRepository
+	suspensionRepository suspension.SuspensionRepository
+	auditRepository      audit.AuditRepository
+	db                   *sql.DB
+	validator            *validator.Validate
+}
+
+func NewReportService(repository ReportRepository, postRepository post.PostRepository, suspensionRepository suspension.SuspensionRepository, auditRepository audit.AuditRepository, db *sql.DB, validator *validator.Validate) ReportService {
+	return &ReportServiceImpl{
+		repository:           repository,
+		postRepository:       postRepository,
+		suspensionRepository: suspensionRepository,
+		auditRepository:      auditRepository,
+		db:                   db,
+		validator:            validator,
+	}
+}
+
+func (service *ReportServiceImpl) Create(ctx context.Context, request ReportCreateRequest, isModerator, isAdmin bool) ReportResponse {
+	err := service.validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	targetUserId := service.repository.FindTargetUserId(ctx, tx, request.Target_Type, request.Target_Id)
+
+	if targetUserId <= 0 {
+		panic(exception.NewNotFoundError(request.Target_Type + " not found"))
+	}
+
+	switch request.Target_Type {
+	case TargetPost:
+		service.checkReadable(ctx, tx, request, request.Target_Id, isModerator, isAdmin)
+	case TargetComment:
+		service.checkReadable(ctx, tx, request, service.repository.FindCommentPostId(ctx, tx, request.Target_Id), isModerator, isAdmin)
+	}
+
+	if targetUserId == request.Reporter_Id {
+		panic(exception.NewBadRequestError("you cannot report your own content"))
+	}
+
+	if service.repository.HasOpenReport(ctx, tx, request.Reporter_Id, request.Target_Type, request.Target_Id) {
+		panic(exception.NewBadRequestError("you have already reported this " + request.Target_Type))
+	}
+
+	newReport := Report{
+		Reporter_Id:    request.Reporter_Id,
+		Target_Type:    request.Target_Type,
+		Target_Id:      request.Target_Id,
+		Target_User_Id: targetUserId,
+		Reason:         request.Reason,
+		Note:           request.Note,
+	}
+
+	report := service.repository.Save(ctx, tx, newReport)
+
+	return toReporterResponse(report)
+}
+
+func (service *ReportServiceImpl) FindAllByReporter(ctx context.Context, reporterId, limit, offset int) ([]ReportResponse, int) {
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	filter := ReportFilterRequest{
+		Status:      "all",
+		Reporter_Id: reporterId,
+	}
+
+	reports := service.repository.FindAll(ctx, tx, filter, limit, offset)
+	countReports := service.repository.CountAll(ctx, tx, filter)
+
+	var reportResponses []ReportResponse
+
+	for _, report := range reports {
+		reportResponses = append(reportResponses, toReporterResponse(report))
+	}
+
+	return reportResponses, countReports
+}
+
+func (service *ReportServiceImpl) FindAll(ctx context.Context, filter ReportFilterRequest, limit, offset int, isModerator bool) ([]ReportResponse, int) {
+	if !isModerator {
+		panic(exception.NewBadRequestError("only moderators can get the report queue"))
+	}
+
+	err := service.validator.Struct(filter)
+	helpers.PanicError(err, "invalid request")
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	reports := service.repository.FindAll(ctx, tx, filter, limit, offset)
+	countReports := service.repository.CountAll(ctx, tx, filter)
+
+	var reportResponses []ReportResponse
+
+	for _, report := range reports {
+		reportResponses = append(reportResponses, ToReportResponse(report))
+	}
+
+	return reportResponses, countReports
+}
+
+func (service *ReportServiceImpl) Resolve(ctx context.Context, request ReportResolveRequest, isModerator bool) ReportResponse {
+	if !isModerator {
+		panic(exception.NewBadRequestError("only moderators can resolve reports"))
+	}
+
+	err := service.validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	report := service.repository.FindById(ctx, tx, request.Id)
+
+	if report.Status != StatusOpen {
+		panic(exception.NewBadRequestError("report is already resolved"))
+	}
+
+	switch request.Action {
+	case ActionHide, ActionDelete:
+		if report.Target_Type == TargetUser {
+			panic(exception.NewBadRequestError("only posts and comments can be hidden or deleted"))
+		}
+
+		if request.Action == ActionHide {
+			service.repository.HideTarget(ctx, tx, report.Target_Type, report.Target_Id)
+		} else {
+			service.repository.DeleteTarget(ctx, tx, report.Target_Type, report.Target_Id)
+		}
+	case ActionSuspend:
+		service.suspendAuthor(ctx, tx, report, request)
+	}
+
+	before := ToReportResponse(report)
+
+	report.Action = request.Action
+	report.Resolution_Note = request.Note
+	report.Resolved_By = request.Moderator_Id
+
+	service.repository.ResolveAllForTarget(ctx, tx, report)
+
+	resolvedReport := service.repository.FindById(ctx, tx, report.Id)
+
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionReportResolve, audit.TargetReport, report.Id, before, ToReportResponse(resolvedReport)))
+
+	return ToReportResponse(resolvedReport)
+}
+
+func (service *ReportServiceImpl) suspendAuthor(ctx context.Context, tx *sql.Tx, report Report, request ReportResolveRequest) {
+	if !request.Permanent && request.Suspension_Hours <= 0 {
+		panic(exception.NewBadRequestError("suspension_hours or permanent is required to suspend"))
+	}
+
+	roleName := service.suspensionRepository.FindUserRole(ctx, tx, report.Target_User_Id)
+
+	if roleName == "" {
+		panic(exception.NewNotFoundError("user not found"))
+	}
+
+	if roleName == "admin" {
+		panic(exception.NewBadRequestError("admin accounts cannot be suspended"))
+	}
+
+	newSuspension := suspension.Suspension{
+		User_Id:  report.Target_User_Id,
+		Admin_Id: request.Moderator_Id,
+		Reason:   fmt.Sprintf("report #%d: %s", report.Id, report.Reason),
+	}
+
+	if !request.Permanent {
+		newSuspension.Expires_At = time.Now().Add(time.Duration(request.Suspension_Hours) * time.Hour)
+	}
+
+	createdSuspension := service.suspensionRepository.Save(ctx, tx, newSuspension)
+
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionUserSuspend, audit.TargetUser, createdSuspension.User_Id, nil, suspension.ToSuspensionResponse(createdSuspension)))
+}
+
+// toReporterResponse hides which moderator handled the report.
+func toReporterResponse(report Report) ReportResponse {
+	reportResponse := ToReportResponse(report)
+	reportResponse.Resolved_By = 0
+	return reportResponse
+}
+
+// checkReadable answers reports on posts, or comments on posts, the reporter
+// cannot read with the same not found as missing content, so reports cannot
+// be used to find out which private posts and drafts exist.
+func (service *ReportServiceImpl) checkReadable(ctx context.Context, tx *sql.Tx, request ReportCreateRequest, postId int, isModerator, isAdmin bool) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if _, ok := recovered.(exception.NotFoundError); ok {
+				panic(exception.NewNotFoundError(request.Target_Type + " not found"))
+			}
+			panic(recovered)
+		}
+	}()
+
+	post.FindReadable(ctx, tx, service.postRepository, postId, request.Reporter_Id, isModerator, isAdmin)
+}
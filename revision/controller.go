@@ -0,0 +1,60 @@
+package revision
+
+import (
+	"net/http"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/julienschmidt/httprouter"
+)
+
+type RevisionPolicyController interface {
+	FindRevisionPolicyHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	UpdateRevisionPolicyHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
+
+type RevisionPolicyControllerImpl struct {
+	service RevisionPolicyService
+}
+
+func NewRevisionPolicyController(service RevisionPolicyService) RevisionPolicyController {
+	return &RevisionPolicyControllerImpl{
+		service: service,
+	}
+}
+
+func (controller *RevisionPolicyControllerImpl) FindRevisionPolicyHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	isAdmin := helpers.IsAdmin(request)
+
+	policy := controller.service.FindPolicy(request.Context(), isAdmin)
+
+	policyResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   policy,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, policyResponse)
+}
+
+func (controller *RevisionPolicyControllerImpl) UpdateRevisionPolicyHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	var policyRequest PolicyUpdateRequest
+	helpers.DecodeJSONFromRequest(request, &policyRequest)
+
+	policyRequest.Updated_By = helpers.GetUserId(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	policy, pruned := controller.service.UpdatePolicy(request.Context(), policyRequest, isAdmin)
+
+	policyResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "UPDATED",
+		Data: map[string]interface{}{
+			"policy": policy,
+			"pruned": pruned,
+		},
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, policyResponse)
+}
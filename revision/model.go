@@ -0,0 +1,36 @@
+package revision
+
+import "time"
+
+const (
+	OpEqual  = "equal"
+	OpInsert = "insert"
+	OpDelete = "delete"
+)
+
+type Revision struct {
+	Id              int
+	Post_Id         int
+	Revision_Number int
+	User_Id         int
+	Title           string
+	Body            string
+	Body_Format     string
+	Category_Id     int
+	Restored_From   int
+	Created_At      time.Time
+}
+
+// Policy decides which old revisions are pruned. Zero disables a limit, and
+// the latest revision of a post is always kept.
+type Policy struct {
+	Keep_Last    int
+	Max_Age_Days int
+	Updated_By   int
+	Updated_At   time.Time
+}
+
+type DiffLine struct {
+	Op   string
+	Line string
+}
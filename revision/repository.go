@@ -0,0 +1,161 @@
+package revision
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type RevisionRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, revision Revision) Revision
+	LatestNumber(ctx context.Context, tx *sql.Tx, postId int) int
+	FindAllByPost(ctx context.Context, tx *sql.Tx, postId, limit, offset int) []Revision
+	CountByPost(ctx context.Context, tx *sql.Tx, postId int) int
+	FindByNumber(ctx context.Context, tx *sql.Tx, postId, revisionNumber int) Revision
+	Prune(ctx context.Context, tx *sql.Tx, postId int, policy Policy) int
+	FindPolicy(ctx context.Context, tx *sql.Tx) Policy
+	SavePolicy(ctx context.Context, tx *sql.Tx, policy Policy) Policy
+}
+
+type RevisionRepositoryImpl struct {
+}
+
+func NewRevisionRepository() RevisionRepository {
+	return &RevisionRepositoryImpl{}
+}
+
+func (repository *RevisionRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, revision Revision) Revision {
+	query := "INSERT INTO post_revision(post_id, revision_number, user_id, title, body, body_format, category_id, restored_from) VALUES (?, ?, ?, ?, ?, ?, ?, NULLIF(?, 0))"
+
+	_, err := tx.ExecContext(ctx, query, revision.Post_Id, revision.Revision_Number, revision.User_Id, revision.Title, revision.Body, revision.Body_Format, revision.Category_Id, revision.Restored_From)
+	helpers.PanicError(err, "failed to exec query insert post revision")
+
+	return repository.FindByNumber(ctx, tx, revision.Post_Id, revision.Revision_Number)
+}
+
+// LatestNumber locks the post's revisions so concurrent updates of the same
+// post get consecutive numbers.
+func (repository *RevisionRepositoryImpl) LatestNumber(ctx context.Context, tx *sql.Tx, postId int) int {
+	query := "SELECT COALESCE(MAX(revision_number), 0) FROM post_revision WHERE post_id = ? FOR UPDATE"
+
+	var latest int
+	err := tx.QueryRowContext(ctx, query, postId).Scan(&latest)
+	helpers.PanicError(err, "failed to query latest post revision")
+
+	return latest
+}
+
+func (repository *RevisionRepositoryImpl) FindAllByPost(ctx context.Context, tx *sql.Tx, postId, limit, offset int) []Revision {
+	query := `SELECT id, post_id, revision_number, user_id, title, body, body_format, category_id, restored_from, created_at 
+	FROM post_revision WHERE post_id = ? ORDER BY revision_number DESC LIMIT ? OFFSET ?`
+
+	rows, err := tx.QueryContext(ctx, query, postId, limit, offset)
+	helpers.PanicError(err, "failed to query post revisions")
+
+	defer rows.Close()
+
+	var revisions []Revision
+
+	for rows.Next() {
+		revisions = append(revisions, scanRevision(rows))
+	}
+
+	return revisions
+}
+
+func (repository *RevisionRepositoryImpl) CountByPost(ctx context.Context, tx *sql.Tx, postId int) int {
+	query := "SELECT COUNT(*) FROM post_revision WHERE post_id = ?"
+
+	var countRevisions int
+	err := tx.QueryRowContext(ctx, query, postId).Scan(&countRevisions)
+	helpers.PanicError(err, "failed to query count post revisions")
+
+	return countRevisions
+}
+
+func (repository *RevisionRepositoryImpl) FindByNumber(ctx context.Context, tx *sql.Tx, postId, revisionNumber int) Revision {
+	query := `SELECT id, post_id, revision_number, user_id, title, body, body_format, category_id, restored_from, created_at 
+	FROM post_revision WHERE post_id = ? AND revision_number = ?`
+
+	rows, err := tx.QueryContext(ctx, query, postId, revisionNumber)
+	helpers.PanicError(err, "failed to query post revision")
+
+	defer rows.Close()
+
+	if !rows.Next() {
+		panic(exception.NewNotFoundError("revision not found"))
+	}
+
+	return scanRevision(rows)
+}
+
+// Prune deletes the revisions the policy no longer keeps, for one post or,
+// with a zero postId, for every post.
+func (repository *RevisionRepositoryImpl) Prune(ctx context.Context, tx *sql.Tx, postId int, policy Policy) int {
+	if policy.Keep_Last == 0 && policy.Max_Age_Days == 0 {
+		return 0
+	}
+
+	query := `DELETE r FROM post_revision r 
+	JOIN (SELECT post_id, MAX(revision_number) AS latest FROM post_revision WHERE ? = 0 OR post_id = ? GROUP BY post_id) l 
+	ON l.post_id = r.post_id 
+	WHERE r.revision_number < l.latest 
+	AND ((? > 0 AND r.revision_number <= l.latest - ?) OR (? > 0 AND r.created_at < NOW() - INTERVAL ? DAY))`
+
+	result, err := tx.ExecContext(ctx, query, postId, postId, policy.Keep_Last, policy.Keep_Last, policy.Max_Age_Days, policy.Max_Age_Days)
+	helpers.PanicError(err, "failed to exec query prune post revisions")
+
+	pruned, err := result.RowsAffected()
+	helpers.PanicError(err, "failed to display rows affected prune post revisions")
+
+	return int(pruned)
+}
+
+func (repository *RevisionRepositoryImpl) FindPolicy(ctx context.Context, tx *sql.Tx) Policy {
+	query := "SELECT keep_last, max_age_days, updated_by, updated_at FROM revision_policy WHERE id = 1"
+
+	rows, err := tx.QueryContext(ctx, query)
+	helpers.PanicError(err, "failed to query revision policy")
+
+	defer rows.Close()
+
+	var policy Policy
+	var updatedBy sql.NullInt64
+
+	if rows.Next() {
+		err := rows.Scan(&policy.Keep_Last, &policy.Max_Age_Days, &updatedBy, &policy.Updated_At)
+		helpers.PanicError(err, "failed to scan revision policy")
+
+		if updatedBy.Valid {
+			policy.Updated_By = int(updatedBy.Int64)
+		}
+	}
+
+	return policy
+}
+
+func (repository *RevisionRepositoryImpl) SavePolicy(ctx context.Context, tx *sql.Tx, policy Policy) Policy {
+	query := `INSERT INTO revision_policy(id, keep_last, max_age_days, updated_by) VALUES (1, ?, ?, ?) 
+	ON DUPLICATE KEY UPDATE keep_last = VALUES(keep_last), max_age_days = VALUES(max_age_days), updated_by = VALUES(updated_by)`
+
+	_, err := tx.ExecContext(ctx, query, policy.Keep_Last, policy.Max_Age_Days, policy.Updated_By)
+	helpers.PanicError(err, "failed to exec query save revision policy")
+
+	return repository.FindPolicy(ctx, tx)
+}
+
+func scanRevision(rows *sql.Rows) Revision {
+	var revision Revision
+	var restoredFrom sql.NullInt64
+
+	err := rows.Scan(&revision.Id, &revision.Post_Id, &revision.Revision_Number, &revision.User_Id, &revision.Title, &revision.Body, &revision.Body_Format, &revision.Category_Id, &restoredFrom, &revision.Created_At)
+	helpers.PanicError(err, "failed to scan post revision")
+
+	if restoredFrom.Valid {
+		revision.Restored_From = int(restoredFrom.Int64)
+	}
+
+	return revision
+}
@@ -0,0 +1,7 @@
+package revision
+
+type PolicyUpdateRequest struct {
+	Keep_Last    int `json:"keep_last" validate:"min=0,max=10000"`
+	Max_Age_Days int `json:"max_age_days" validate:"min=0,max=36500"`
+	Updated_By   int `json:"updated_by" validate:"required"`
+}
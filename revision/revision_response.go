@@ -0,0 +1,82 @@
+package revision
+
+import "time"
+
+type RevisionResponse struct {
+	Id              int       `json:"id"`
+	Post_Id         int       `json:"post_id"`
+	Revision_Number int       `json:"revision_number"`
+	User_Id         int       `json:"user_id"`
+	Title           string    `json:"title"`
+	Body            string    `json:"body"`
+	Body_Format     string    `json:"body_format"`
+	Category_Id     int       `json:"category_id"`
+	Restored_From   int       `json:"restored_from"`
+	Created_At      time.Time `json:"created_at"`
+}
+
+func ToRevisionResponse(revision Revision) RevisionResponse {
+	return RevisionResponse{
+		Id:              revision.Id,
+		Post_Id:         revision.Post_Id,
+		Revision_Number: revision.Revision_Number,
+		User_Id:         revision.User_Id,
+		Title:           revision.Title,
+		Body:            revision.Body,
+		Body_Format:     revision.Body_Format,
+		Category_Id:     revision.Category_Id,
+		Restored_From:   revision.Restored_From,
+		Created_At:      revision.Created_At,
+	}
+}
+
+type DiffLineResponse struct {
+	Op   string `json:"op"`
+	Line string `json:"line"`
+}
+
+type DiffResponse struct {
+	Post_Id          int                `json:"post_id"`
+	From             int                `json:"from"`
+	To               int                `json:"to"`
+	Title            []DiffLineResponse `json:"title"`
+	Body             []DiffLineResponse `json:"body"`
+	Category_Changed bool               `json:"category_changed"`
+}
+
+func ToDiffResponse(from, to Revision) DiffResponse {
+	return DiffResponse{
+		Post_Id:          from.Post_Id,
+		From:             from.Revision_Number,
+		To:               to.Revision_Number,
+		Title:            toDiffLineResponses(Diff(from.Title, to.Title)),
+		Body:             toDiffLineResponses(Diff(from.Body, to.Body)),
+		Category_Changed: from.Category_Id != to.Category_Id,
+	}
+}
+
+func toDiffLineResponses(lines []DiffLine) []DiffLineResponse {
+	responses := []DiffLineResponse{}
+
+	for _, line := range lines {
+		responses = append(responses, DiffLineResponse{Op: line.Op, Line: line.Line})
+	}
+
+	return responses
+}
+
+type PolicyResponse struct {
+	Keep_Last    int       `json:"keep_last"`
+	Max_Age_Days int       `json:"max_age_days"`
+	Updated_By   int       `json:"updated_by"`
+	Updated_At   time.Time `json:"updated_at"`
+}
+
+func ToPolicyResponse(policy Policy) PolicyResponse {
+	return PolicyResponse{
+		Keep_Last:    policy.Keep_Last,
+		Max_Age_Days: policy.Max_Age_Days,
+		Updated_By:   policy.Updated_By,
+		Updated_At:   policy.Updated_At,
+	}
+}
@@ -0,0 +1,73 @@
+package revision
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type RevisionPolicyService interface {
+	FindPolicy(ctx context.Context, isAdmin bool) PolicyResponse
+	UpdatePolicy(ctx context.Context, request PolicyUpdateRequest, isAdmin bool) (PolicyResponse, int)
+}
+
+type RevisionPolicyServiceImpl struct {
+	repository      RevisionRepository
+	auditRepository audit.AuditRepository
+	db              *sql.DB
+	validator       *validator.Validate
+}
+
+func NewRevisionPolicyService(repository RevisionRepository, auditRepository audit.AuditRepository, db *sql.DB, validator *validator.Validate) RevisionPolicyService {
+	return &RevisionPolicyServiceImpl{
+		repository:      repository,
+		auditRepository: auditRepository,
+		db:              db,
+		validator:       validator,
+	}
+}
+
+func (service *RevisionPolicyServiceImpl) FindPolicy(ctx context.Context, isAdmin bool) PolicyResponse {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can get the revision policy"))
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	return ToPolicyResponse(service.repository.FindPolicy(ctx, tx))
+}
+
+// UpdatePolicy saves the policy and prunes every post's history to match it
+// right away, returning how many revisions were removed.
+func (service *RevisionPolicyServiceImpl) UpdatePolicy(ctx context.Context, request PolicyUpdateRequest, isAdmin bool) (PolicyResponse, int) {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can update the revision policy"))
+	}
+
+	err := service.validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	before := service.repository.FindPolicy(ctx, tx)
+
+	policy := service.repository.SavePolicy(ctx, tx, Policy{
+		Keep_Last:    request.Keep_Last,
+		Max_Age_Days: request.Max_Age_Days,
+		Updated_By:   request.Updated_By,
+	})
+
+	pruned := service.repository.Prune(ctx, tx, 0, policy)
+
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionRevisionPolicyUpdate, audit.TargetRevisionPolicy, 1, ToPolicyResponse(before), ToPolicyResponse(policy)))
+
+	return ToPolicyResponse(policy), pruned
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/hutamatr/GoBlogify/audit"
 	"github.com/hutamatr/GoBlogify/exception"
 	"github.com/hutamatr/GoBlogify/helpers"
 )
@@ -18,16 +19,18 @@ type RoleService interface {
 }
 
 type RoleServiceImpl struct {
-	repository RoleRepository
-	db         *sql.DB
-	validator  *validator.Validate
+	repository      RoleRepository
+	auditRepository audit.AuditRepository
+	db              *sql.DB
+	validator       *validator.Validate
 }
 
-func NewRoleService(roleRepository RoleRepository, db *sql.DB, validator *validator.Validate) RoleService {
+func NewRoleService(roleRepository RoleRepository, auditRepository audit.AuditRepository, db *sql.DB, validator *validator.Validate) RoleService {
 	return &RoleServiceImpl{
-		repository: roleRepository,
-		db:         db,
-		validator:  validator,
+		repository:      roleRepository,
+		auditRepository: auditRepository,
+		db:              db,
+		validator:       validator,
 	}
 }
 
@@ -49,6 +52,8 @@ func (service *RoleServiceImpl) Create(ctx context.Context, request RoleCreateRe
 
 	createdRole := service.repository.Save(ctx, tx, roleRequest)
 
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionRoleCreate, audit.TargetRole, createdRole.Id, nil, ToRoleResponse(createdRole)))
+
 	return ToRoleResponse(createdRole)
 }
 
@@ -102,11 +107,14 @@ func (service *RoleServiceImpl) Update(ctx context.Context, request RoleUpdateRe
 	defer helpers.TxRollbackCommit(tx)
 
 	role := service.repository.FindById(ctx, tx, request.Id)
+	before := ToRoleResponse(role)
 
 	role.Name = request.Name
 
 	updatedRole := service.repository.Update(ctx, tx, role)
 
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionRoleUpdate, audit.TargetRole, updatedRole.Id, before, ToRoleResponse(updatedRole)))
+
 	return ToRoleResponse(updatedRole)
 }
 
@@ -118,5 +126,9 @@ func (service *RoleServiceImpl) Delete(ctx context.Context, roleId int, isAdmin
 	helpers.PanicError(err, "failed to begin transaction")
 	defer helpers.TxRollbackCommit(tx)
 
+	role := service.repository.FindById(ctx, tx, roleId)
+
 	service.repository.Delete(ctx, tx, roleId)
+
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionRoleDelete, audit.TargetRole, roleId, ToRoleResponse(role), nil))
 }
@@ -2,27 +2,52 @@ package routes
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/hutamatr/GoBlogify/admin"
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/bulk"
 	"github.com/hutamatr/GoBlogify/category"
 	"github.com/hutamatr/GoBlogify/comment"
+	"github.com/hutamatr/GoBlogify/contentfilter"
+	"github.com/hutamatr/GoBlogify/erasure"
 	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/export"
 	"github.com/hutamatr/GoBlogify/follow"
 	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/impersonation"
 	"github.com/hutamatr/GoBlogify/post"
+	"github.com/hutamatr/GoBlogify/report"
+	"github.com/hutamatr/GoBlogify/revision"
 	"github.com/hutamatr/GoBlogify/role"
+	"github.com/hutamatr/GoBlogify/search"
+	"github.com/hutamatr/GoBlogify/stats"
+	"github.com/hutamatr/GoBlogify/suspension"
+	"github.com/hutamatr/GoBlogify/tag"
 	"github.com/hutamatr/GoBlogify/user"
 	"github.com/julienschmidt/httprouter"
 )
 
 type RouterControllers struct {
-	Admin    admin.AdminController
-	User     user.UserController
-	Post     post.PostController
-	Category category.CategoryController
-	Role     role.RoleController
-	Comment  comment.CommentController
-	Follow   follow.FollowController
+	Admin          admin.AdminController
+	User           user.UserController
+	Post           post.PostController
+	Category       category.CategoryController
+	Role           role.RoleController
+	Comment        comment.CommentController
+	Follow         follow.FollowController
+	Export         export.ExportController
+	Erasure        erasure.ErasureController
+	Suspension     suspension.SuspensionController
+	Audit          audit.AuditController
+	Stats          stats.StatsController
+	Report         report.ReportController
+	FilterRule     contentfilter.FilterRuleController
+	Bulk           bulk.BulkController
+	Impersonation  impersonation.ImpersonationController
+	RevisionPolicy revision.RevisionPolicyController
+	Tag            tag.TagController
+	Search         search.SearchController
 }
 
 func Router(route *RouterControllers) *httprouter.Router {
@@ -40,12 +65,57 @@ func Router(route *RouterControllers) *httprouter.Router {
 	router.GET("/api/v1/users/:userId", route.User.FindByIdUserHandler)
 	router.PUT("/api/v1/users/:userId", route.User.UpdateUserHandler)
 	router.DELETE("/api/v1/users/:userId", route.User.DeleteUserHandler)
+	router.POST("/api/v1/users/:userId/deactivate", route.User.DeactivateUserHandler)
+	router.GET("/api/v1/@:username", route.User.FindByUsernameHandler)
 
 	router.POST("/api/v1/users/:userId/follow/:toUserId", route.Follow.FollowUserHandler)
 	router.DELETE("/api/v1/users/:userId/unfollow/:toUserId", route.Follow.UnfollowUserHandler)
 	router.GET("/api/v1/users/:userId/follower", route.Follow.FindAllFollowerByUserHandler)
 	router.GET("/api/v1/users/:userId/following", route.Follow.FindAllFollowedByUserHandler)
 
+	router.POST("/api/v1/users/:userId/exports", route.Export.RequestExportHandler)
+	router.GET("/api/v1/users/:userId/exports/:exportId", route.Export.FindExportByIdHandler)
+	router.GET("/api/v1/exports/download/:token", route.Export.DownloadExportHandler)
+
+	router.POST("/api/v1/users/:userId/erasure", route.Erasure.RequestErasureHandler)
+	router.GET("/api/v1/users/:userId/erasure", route.Erasure.FindErasureHandler)
+
+	router.PUT("/api/v1/admin/users/:userId/role", route.User.UpdateUserRoleHandler)
+	router.GET("/api/v1/admin/users/:userId/role-changes", route.User.FindAllRoleChangesHandler)
+
+	router.POST("/api/v1/reports", route.Report.CreateReportHandler)
+	router.GET("/api/v1/reports/mine", route.Report.FindMyReportsHandler)
+	router.GET("/api/v1/moderation/reports", route.Report.FindAllReportHandler)
+	router.POST("/api/v1/moderation/reports/:reportId/resolve", route.Report.ResolveReportHandler)
+
+	router.POST("/api/v1/admin/filter-rules", route.FilterRule.CreateFilterRuleHandler)
+	router.GET("/api/v1/admin/filter-rules", route.FilterRule.FindAllFilterRuleHandler)
+	router.GET("/api/v1/admin/filter-rules/:ruleId", route.FilterRule.FindByIdFilterRuleHandler)
+	router.PUT("/api/v1/admin/filter-rules/:ruleId", route.FilterRule.UpdateFilterRuleHandler)
+	router.DELETE("/api/v1/admin/filter-rules/:ruleId", route.FilterRule.DeleteFilterRuleHandler)
+
+	router.GET("/api/v1/admin/stats", route.Stats.FindStatsHandler)
+
+	router.POST("/api/v1/admin/bulk/posts", route.Bulk.BulkPostHandler)
+	router.POST("/api/v1/admin/bulk/users", route.Bulk.BulkUserHandler)
+
+	router.POST("/api/v1/admin/impersonations", route.Impersonation.StartImpersonationHandler)
+	router.GET("/api/v1/admin/impersonations", route.Impersonation.FindAllImpersonationHandler)
+	router.DELETE("/api/v1/admin/impersonations/:impersonationId", route.Impersonation.EndImpersonationHandler)
+
+	router.GET("/api/v1/admin/revision-policy", route.RevisionPolicy.FindRevisionPolicyHandler)
+	router.PUT("/api/v1/admin/revision-policy", route.RevisionPolicy.UpdateRevisionPolicyHandler)
+
+	router.PUT("/api/v1/admin/tags/:tagId", route.Tag.RenameTagHandler)
+	router.POST("/api/v1/admin/tags/:tagId/merge", route.Tag.MergeTagHandler)
+
+	router.GET("/api/v1/admin/audit-logs", route.Audit.FindAllAuditLogHandler)
+	router.GET("/api/v1/admin/audit-logs/export", route.Audit.ExportAuditLogHandler)
+
+	router.POST("/api/v1/admin/users/:userId/suspensions", route.Suspension.SuspendUserHandler)
+	router.GET("/api/v1/admin/users/:userId/suspensions", route.Suspension.FindAllSuspensionByUserHandler)
+	router.DELETE("/api/v1/admin/users/:userId/suspensions", route.Suspension.LiftSuspensionHandler)
+
 	router.POST("/api/v1/roles", route.Role.CreateRoleHandler)
 	router.GET("/api/v1/roles", route.Role.FindAllRoleHandler)
 	router.GET("/api/v1/roles/:roleId", route.Role.FindRoleByIdHandler)
@@ -53,11 +123,30 @@ func Router(route *RouterControllers) *httprouter.Router {
 	router.DELETE("/api/v1/roles/:roleId", route.Role.DeleteRoleHandler)
 
 	router.POST("/api/v1/posts", route.Post.CreatePostHandler)
+	router.GET("/api/v1/posts", route.Post.FindAllPostHandler)
 	router.GET("/api/v1/posts/:userId", route.Post.FindAllPostByUserHandler)
-	router.GET("/api/v1/posts/:userId/following", route.Post.FindAllPostByFollowedHandler)
+	router.GET("/api/v1/posts/:userId/*path", postSubroutes(route.Post, router))
 	router.GET("/api/v1/post/:postId", route.Post.FindByIdPostHandler)
+	router.GET("/api/v1/post/:postId/revisions", route.Post.FindAllRevisionPostHandler)
+	router.GET("/api/v1/post/:postId/revisions/:revisionNumber/diff/:otherNumber", route.Post.DiffRevisionPostHandler)
+	router.GET("/api/v1/post/:postId/reactions", route.Post.FindAllReactionPostHandler)
 	router.PUT("/api/v1/posts/:postId", route.Post.UpdatePostHandler)
 	router.DELETE("/api/v1/posts/:postId", route.Post.DeletePostHandler)
+	router.PUT("/api/v1/posts/:postId/comment-policy", route.Post.UpdateCommentPolicyHandler)
+	router.PUT("/api/v1/posts/:postId/schedule", route.Post.ReschedulePostHandler)
+	router.POST("/api/v1/posts/:postId/revisions/:revisionNumber/restore", route.Post.RestoreRevisionPostHandler)
+	router.PUT("/api/v1/posts/:postId/reaction", route.Post.ReactPostHandler)
+	router.DELETE("/api/v1/posts/:postId/reaction", route.Post.UnreactPostHandler)
+
+	router.GET("/api/v1/tags", route.Tag.FindAllTagHandler)
+	router.GET("/api/v1/tags/autocomplete", route.Tag.AutocompleteTagHandler)
+	router.GET("/api/v1/tag/:tagName/posts", route.Post.FindAllPostByTagHandler)
+
+	router.GET("/api/v1/search/posts", route.Search.SearchPostHandler)
+
+	router.GET("/api/v1/moderation/posts", route.Post.FindAllPendingPostHandler)
+	router.POST("/api/v1/moderation/posts/:postId/approve", route.Post.ApprovePostHandler)
+	router.POST("/api/v1/moderation/posts/:postId/reject", route.Post.RejectPostHandler)
 
 	router.POST("/api/v1/comments", route.Comment.CreateCommentHandler)
 	router.GET("/api/v1/comments", route.Comment.FindCommentsByPostHandler)
@@ -65,6 +154,9 @@ func Router(route *RouterControllers) *httprouter.Router {
 	router.PUT("/api/v1/comments/:commentId", route.Comment.UpdateCommentHandler)
 	router.DELETE("/api/v1/comments/:commentId", route.Comment.DeleteCommentHandler)
 
+	router.GET("/api/v1/moderation/comments", route.Comment.FindAllModerationCommentHandler)
+	router.POST("/api/v1/moderation/comments/bulk", route.Comment.BulkModerateCommentHandler)
+
 	router.POST("/api/v1/categories", route.Category.CreateCategoryHandler)
 	router.GET("/api/v1/categories", route.Category.FindAllCategoryHandler)
 	router.GET("/api/v1/categories/:categoryId", route.Category.FindByIdCategoryHandler)
@@ -85,3 +177,28 @@ func Router(route *RouterControllers) *httprouter.Router {
 
 	return router
 }
+
+// postSubroutes dispatches GET routes below /api/v1/posts/:userId. httprouter
+// cannot register static segments such as by-slug next to the :userId
+// wildcard, so they share one catch-all route.
+func postSubroutes(controller post.PostController, router *httprouter.Router) httprouter.Handle {
+	return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+		segments := strings.Split(strings.Trim(params.ByName("path"), "/"), "/")
+
+		switch {
+		case params.ByName("userId") == "by-slug" && len(segments) == 2:
+			controller.FindBySlugPostHandler(writer, request, httprouter.Params{
+				{Key: "username", Value: segments[0]},
+				{Key: "slug", Value: segments[1]},
+			})
+		case len(segments) == 1 && segments[0] == "following":
+			controller.FindAllPostByFollowedHandler(writer, request, params)
+		case len(segments) == 1 && segments[0] == "scheduled":
+			controller.FindAllScheduledPostHandler(writer, request, params)
+		case len(segments) == 1 && segments[0] == "drafts":
+			controller.FindAllDraftPostHandler(writer, request, params)
+		default:
+			router.NotFound.ServeHTTP(writer, request)
+		}
+	}
+}
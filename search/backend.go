@@ -0,0 +1,116 @@
+package search
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/post"
+)
+
+// PostSearchBackend finds the posts matching a query, best match first,
+// together with the total number of matches. The MySQL backend below uses the
+// FULLTEXT index on post, a dedicated search engine only has to implement
+// this interface and be wired in its place.
+type PostSearchBackend interface {
+	SearchPosts(ctx context.Context, query PostQuery) ([]PostHit, int)
+}
+
+type MySQLPostSearchBackend struct {
+	db *sql.DB
+}
+
+func NewMySQLPostSearchBackend(db *sql.DB) PostSearchBackend {
+	return &MySQLPostSearchBackend{
+		db: db,
+	}
+}
+
+func (backend *MySQLPostSearchBackend) SearchPosts(ctx context.Context, query PostQuery) ([]PostHit, int) {
+	tx, err := backend.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	against := booleanQuery(query.Terms)
+	where, args := postQueryFilter(query)
+
+	queryCount := `SELECT COUNT(*) FROM post p 
+	JOIN user u ON u.id = p.user_id 
+	WHERE MATCH(p.title, p.body) AGAINST(? IN BOOLEAN MODE) AND ` + where
+
+	var countHits int
+	err = tx.QueryRowContext(ctx, queryCount, append([]interface{}{against}, args...)...).Scan(&countHits)
+	helpers.PanicError(err, "failed to query count search posts")
+
+	querySearch := `SELECT p.id, p.title, p.slug, p.body, MATCH(p.title, p.body) AGAINST(? IN BOOLEAN MODE) AS score, COALESCE(p.published_at, p.created_at), u.id, u.username, c.id, c.name 
+	FROM post p 
+	JOIN user u ON u.id = p.user_id 
+	JOIN category c ON c.id = p.category_id 
+	WHERE MATCH(p.title, p.body) AGAINST(? IN BOOLEAN MODE) AND ` + where + ` 
+	ORDER BY score DESC, COALESCE(p.published_at, p.created_at) DESC, p.id DESC LIMIT ? OFFSET ?`
+
+	searchArgs := append([]interface{}{against, against}, args...)
+	searchArgs = append(searchArgs, query.Limit, query.Offset)
+
+	rows, err := tx.QueryContext(ctx, querySearch, searchArgs...)
+	helpers.PanicError(err, "failed to query search posts")
+
+	defer rows.Close()
+
+	var hits []PostHit
+
+	for rows.Next() {
+		var hit PostHit
+		err := rows.Scan(&hit.Id, &hit.Title, &hit.Slug, &hit.Body, &hit.Score, &hit.Published_At, &hit.Author_Id, &hit.Author_Username, &hit.Category_Id, &hit.Category_Name)
+		helpers.PanicError(err, "failed to scan search posts")
+
+		hits = append(hits, hit)
+	}
+
+	return hits, countHits
+}
+
+// booleanQuery matches every word starting with one of the terms. Terms are
+// plain letters and digits, see Terms, so they cannot inject operators.
+func booleanQuery(terms []string) string {
+	words := make([]string, 0, len(terms))
+
+	for _, term := range terms {
+		words = append(words, term+"*")
+	}
+
+	return strings.Join(words, " ")
+}
+
+func postQueryFilter(query PostQuery) (string, []interface{}) {
+	conditions := []string{post.VisibleToCaller}
+	args := []interface{}{query.Caller_Id, query.Caller_Id}
+
+	if query.Author_Id > 0 {
+		conditions = append(conditions, "p.user_id = ?")
+		args = append(args, query.Author_Id)
+	}
+
+	if query.Category_Id > 0 {
+		conditions = append(conditions, "p.category_id = ?")
+		args = append(args, query.Category_Id)
+	}
+
+	if query.Tag != "" {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM post_tag pt JOIN tag t ON t.id = pt.tag_id WHERE pt.post_id = p.id AND t.name = ?)")
+		args = append(args, query.Tag)
+	}
+
+	if !query.From.IsZero() {
+		conditions = append(conditions, "COALESCE(p.published_at, p.created_at) >= ?")
+		args = append(args, query.From)
+	}
+
+	if !query.To.IsZero() {
+		conditions = append(conditions, "COALESCE(p.published_at, p.created_at) < ?")
+		args = append(args, query.To)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
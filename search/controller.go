@@ -0,0 +1,89 @@
+package search
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/julienschmidt/httprouter"
+)
+
+type SearchController interface {
+	SearchPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
+
+type SearchControllerImpl struct {
+	service SearchService
+}
+
+func NewSearchController(service SearchService) SearchController {
+	return &SearchControllerImpl{
+		service: service,
+	}
+}
+
+func (controller *SearchControllerImpl) SearchPostHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	limit, offset := helpers.GetLimitOffset(request)
+
+	posts, countPosts := controller.service.SearchPosts(request.Context(), searchPostFromRequest(request), limit, offset)
+
+	searchResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"posts":  posts,
+			"limit":  limit,
+			"offset": offset,
+			"total":  countPosts,
+		},
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, searchResponse)
+}
+
+func searchPostFromRequest(request *http.Request) SearchPostRequest {
+	query := request.URL.Query()
+
+	authorId, err := parseIdQuery(query.Get("author_id"))
+	if err != nil {
+		panic(exception.NewBadRequestError("invalid author_id"))
+	}
+
+	categoryId, err := parseIdQuery(query.Get("category_id"))
+	if err != nil {
+		panic(exception.NewBadRequestError("invalid category_id"))
+	}
+
+	from, err := helpers.ParseDateQuery(query.Get("from"))
+	if err != nil {
+		panic(exception.NewBadRequestError("invalid from, use YYYY-MM-DD"))
+	}
+
+	to, err := helpers.ParseDateQuery(query.Get("to"))
+	if err != nil {
+		panic(exception.NewBadRequestError("invalid to, use YYYY-MM-DD"))
+	}
+
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1)
+	}
+
+	return SearchPostRequest{
+		Caller_Id:   helpers.GetUserId(request),
+		Query:       query.Get("q"),
+		Author_Id:   authorId,
+		Category_Id: categoryId,
+		Tag:         query.Get("tag"),
+		From:        from,
+		To:          to,
+	}
+}
+
+func parseIdQuery(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
@@ -0,0 +1,36 @@
+package search
+
+import "time"
+
+const (
+	MaxQueryTerms = 10
+	SnippetLength = 160
+)
+
+// PostQuery is what a PostSearchBackend searches for. Terms are already
+// split and cleaned, the tag is normalised and To is exclusive. Caller_Id is
+// the user searching, whose visibility rules the results must follow.
+type PostQuery struct {
+	Caller_Id   int
+	Terms       []string
+	Author_Id   int
+	Category_Id int
+	Tag         string
+	From        time.Time
+	To          time.Time
+	Limit       int
+	Offset      int
+}
+
+type PostHit struct {
+	Id              int
+	Title           string
+	Slug            string
+	Body            string
+	Score           float64
+	Published_At    time.Time
+	Author_Id       int
+	Author_Username string
+	Category_Id     int
+	Category_Name   string
+}
@@ -0,0 +1,13 @@
+package search
+
+import "time"
+
+type SearchPostRequest struct {
+	Caller_Id   int
+	Query       string `validate:"required,max=200"`
+	Author_Id   int
+	Category_Id int
+	Tag         string `validate:"max=100"`
+	From        time.Time
+	To          time.Time
+}
@@ -0,0 +1,45 @@
+package search
+
+import "time"
+
+type SearchAuthorResponse struct {
+	Id       int    `json:"id"`
+	Username string `json:"username"`
+}
+
+type SearchCategoryResponse struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type SearchPostResponse struct {
+	Id              int                    `json:"id"`
+	Title           string                 `json:"title"`
+	Slug            string                 `json:"slug"`
+	Title_Highlight string                 `json:"title_highlight"`
+	Snippet         string                 `json:"snippet"`
+	Score           float64                `json:"score"`
+	Published_At    time.Time              `json:"published_at"`
+	Author          SearchAuthorResponse   `json:"author"`
+	Category        SearchCategoryResponse `json:"category"`
+}
+
+func ToSearchPostResponse(hit PostHit, terms []string) SearchPostResponse {
+	return SearchPostResponse{
+		Id:              hit.Id,
+		Title:           hit.Title,
+		Slug:            hit.Slug,
+		Title_Highlight: Highlight(hit.Title, terms, 0),
+		Snippet:         Highlight(hit.Body, terms, SnippetLength),
+		Score:           hit.Score,
+		Published_At:    hit.Published_At,
+		Author: SearchAuthorResponse{
+			Id:       hit.Author_Id,
+			Username: hit.Author_Username,
+		},
+		Category: SearchCategoryResponse{
+			Id:   hit.Category_Id,
+			Name: hit.Category_Name,
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package search
+
+import (
+	"context"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/tag"
+)
+
+type SearchService interface {
+	SearchPosts(ctx context.Context, request SearchPostRequest, limit, offset int) ([]SearchPostResponse, int)
+}
+
+type SearchServiceImpl struct {
+	backend   PostSearchBackend
+	validator *validator.Validate
+}
+
+func NewSearchService(backend PostSearchBackend, validator *validator.Validate) SearchService {
+	return &SearchServiceImpl{
+		backend:   backend,
+		validator: validator,
+	}
+}
+
+func (service *SearchServiceImpl) SearchPosts(ctx context.Context, request SearchPostRequest, limit, offset int) ([]SearchPostResponse, int) {
+	err := service.validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	terms := Terms(request.Query)
+	if len(terms) == 0 {
+		panic(exception.NewBadRequestError("search query must contain a word"))
+	}
+
+	if !request.From.IsZero() && !request.To.IsZero() && !request.To.After(request.From) {
+		panic(exception.NewBadRequestError("to must not be before from"))
+	}
+
+	hits, countHits := service.backend.SearchPosts(ctx, PostQuery{
+		Caller_Id:   request.Caller_Id,
+		Terms:       terms,
+		Author_Id:   request.Author_Id,
+		Category_Id: request.Category_Id,
+		Tag:         tag.Normalize(request.Tag),
+		From:        request.From,
+		To:          request.To,
+		Limit:       limit,
+		Offset:      offset,
+	})
+
+	postsData := []SearchPostResponse{}
+
+	for _, hit := range hits {
+		postsData = append(postsData, ToSearchPostResponse(hit, terms))
+	}
+
+	return postsData, countHits
+}
@@ -0,0 +1,125 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Terms splits a search query into lower-cased words. Anything that is not a
+// letter or digit separates words, which also strips the operators of the
+// MySQL boolean mode out of user input.
+func Terms(query string) []string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]bool)
+	var terms []string
+
+	for _, word := range words {
+		if seen[word] {
+			continue
+		}
+
+		seen[word] = true
+		terms = append(terms, word)
+
+		if len(terms) == MaxQueryTerms {
+			break
+		}
+	}
+
+	return terms
+}
+
+// Highlight escapes text for HTML and wraps every word starting with one of
+// the terms in <mark>. With a positive length the text is cut to a window of
+// about that many characters around the first match, with an ellipsis where
+// it was cut.
+func Highlight(text string, terms []string, length int) string {
+	runes := []rune(text)
+	matches := findMatches(runes, terms)
+
+	start, end := 0, len(runes)
+
+	if length > 0 && len(runes) > length {
+		if len(matches) > 0 {
+			start = max(0, matches[0][0]-length/4)
+			for start > 0 && !unicode.IsSpace(runes[start-1]) {
+				start--
+			}
+		}
+
+		end = min(len(runes), start+length)
+		for end < len(runes) && !unicode.IsSpace(runes[end]) {
+			end++
+		}
+	}
+
+	var builder strings.Builder
+
+	if start > 0 {
+		builder.WriteString("…")
+	}
+
+	position := start
+
+	for _, match := range matches {
+		if match[0] < start {
+			continue
+		}
+		if match[1] > end {
+			break
+		}
+
+		builder.WriteString(html.EscapeString(string(runes[position:match[0]])))
+		builder.WriteString("<mark>")
+		builder.WriteString(html.EscapeString(string(runes[match[0]:match[1]])))
+		builder.WriteString("</mark>")
+		position = match[1]
+	}
+
+	builder.WriteString(html.EscapeString(string(runes[position:end])))
+
+	if end < len(runes) {
+		builder.WriteString("…")
+	}
+
+	return strings.TrimSpace(builder.String())
+}
+
+// findMatches returns the [start, end) rune ranges of the words in text that
+// start with a term, in order.
+func findMatches(runes []rune, terms []string) [][2]int {
+	var matches [][2]int
+
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			i++
+			continue
+		}
+
+		end := i
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+
+		word := strings.ToLower(string(runes[i:end]))
+
+		for _, term := range terms {
+			if strings.HasPrefix(word, term) {
+				matches = append(matches, [2]int{i, end})
+				break
+			}
+		}
+
+		i = end
+	}
+
+	return matches
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
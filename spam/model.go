@@ -0,0 +1,17 @@
+package spam
+
+const (
+	LabelSpam = "spam"
+	LabelHam  = "ham"
+)
+
+type TokenCount struct {
+	Token      string
+	Spam_Count int
+	Ham_Count  int
+}
+
+type Corpus struct {
+	Spam_Documents int
+	Ham_Documents  int
+}
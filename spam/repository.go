@@ -0,0 +1,103 @@
+package spam
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type SpamRepository interface {
+	FindCorpus(ctx context.Context, tx *sql.Tx) Corpus
+	FindTokens(ctx context.Context, tx *sql.Tx, tokens []string) map[string]TokenCount
+	AdjustCorpus(ctx context.Context, tx *sql.Tx, label string, delta int)
+	AdjustTokens(ctx context.Context, tx *sql.Tx, tokens []string, label string, delta int)
+}
+
+type SpamRepositoryImpl struct {
+}
+
+func NewSpamRepository() SpamRepository {
+	return &SpamRepositoryImpl{}
+}
+
+func (repository *SpamRepositoryImpl) FindCorpus(ctx context.Context, tx *sql.Tx) Corpus {
+	query := "SELECT label, documents FROM spam_corpus"
+
+	rows, err := tx.QueryContext(ctx, query)
+	helpers.PanicError(err, "failed to query spam corpus")
+
+	defer rows.Close()
+
+	var corpus Corpus
+
+	for rows.Next() {
+		var label string
+		var documents int
+
+		err := rows.Scan(&label, &documents)
+		helpers.PanicError(err, "failed to scan spam corpus")
+
+		if label == LabelSpam {
+			corpus.Spam_Documents = documents
+		} else if label == LabelHam {
+			corpus.Ham_Documents = documents
+		}
+	}
+
+	return corpus
+}
+
+func (repository *SpamRepositoryImpl) FindTokens(ctx context.Context, tx *sql.Tx, tokens []string) map[string]TokenCount {
+	counts := map[string]TokenCount{}
+
+	if len(tokens) == 0 {
+		return counts
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(tokens)), ", ")
+	query := "SELECT token, spam_count, ham_count FROM spam_token WHERE token IN (" + placeholders + ")"
+
+	args := make([]interface{}, len(tokens))
+	for i, token := range tokens {
+		args[i] = token
+	}
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	helpers.PanicError(err, "failed to query spam tokens")
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var count TokenCount
+
+		err := rows.Scan(&count.Token, &count.Spam_Count, &count.Ham_Count)
+		helpers.PanicError(err, "failed to scan spam tokens")
+
+		counts[count.Token] = count
+	}
+
+	return counts
+}
+
+func (repository *SpamRepositoryImpl) AdjustCorpus(ctx context.Context, tx *sql.Tx, label string, delta int) {
+	query := "INSERT INTO spam_corpus(label, documents) VALUES (?, GREATEST(?, 0)) ON DUPLICATE KEY UPDATE documents = GREATEST(documents + ?, 0)"
+
+	_, err := tx.ExecContext(ctx, query, label, delta, delta)
+	helpers.PanicError(err, "failed to exec query adjust spam corpus")
+}
+
+func (repository *SpamRepositoryImpl) AdjustTokens(ctx context.Context, tx *sql.Tx, tokens []string, label string, delta int) {
+	column := "ham_count"
+	if label == LabelSpam {
+		column = "spam_count"
+	}
+
+	query := "INSERT INTO spam_token(token, " + column + ") VALUES (?, GREATEST(?, 0)) ON DUPLICATE KEY UPDATE " + column + " = GREATEST(" + column + " + ?, 0)"
+
+	for _, token := range tokens {
+		_, err := tx.ExecContext(ctx, query, token, delta, delta)
+		helpers.PanicError(err, "failed to exec query adjust spam token")
+	}
+}
@@ -0,0 +1,78 @@
+package spam
+
+import (
+	"context"
+	"database/sql"
+	"math"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+// SpamScorer estimates how likely a piece of text is spam and learns from
+// moderator decisions. Scores range from 0 (clean) to 1 (spam).
+type SpamScorer interface {
+	Score(ctx context.Context, tx *sql.Tx, text string) float64
+	Train(ctx context.Context, tx *sql.Tx, text, label string)
+	Untrain(ctx context.Context, tx *sql.Tx, text, label string)
+}
+
+// NaiveBayesScorer is a Bernoulli naive Bayes classifier over the distinct
+// words of a text. Word counts are kept in the database so every instance
+// shares what moderators have taught it.
+type NaiveBayesScorer struct {
+	repository SpamRepository
+}
+
+func NewNaiveBayesScorer(repository SpamRepository) SpamScorer {
+	return &NaiveBayesScorer{
+		repository: repository,
+	}
+}
+
+// Score returns 0 until both classes have seen enough documents, so a fresh
+// install does not hold back comments on the strength of a handful of votes.
+func (scorer *NaiveBayesScorer) Score(ctx context.Context, tx *sql.Tx, text string) float64 {
+	tokens := Tokenize(text)
+	if len(tokens) == 0 {
+		return 0
+	}
+
+	corpus := scorer.repository.FindCorpus(ctx, tx)
+	minDocuments := helpers.EnvInt(helpers.NewEnv().Spam.MinDocuments, 5)
+
+	if corpus.Spam_Documents < minDocuments || corpus.Ham_Documents < minDocuments {
+		return 0
+	}
+
+	counts := scorer.repository.FindTokens(ctx, tx, tokens)
+
+	spamDocuments := float64(corpus.Spam_Documents)
+	hamDocuments := float64(corpus.Ham_Documents)
+	totalDocuments := spamDocuments + hamDocuments
+
+	logSpam := math.Log(spamDocuments / totalDocuments)
+	logHam := math.Log(hamDocuments / totalDocuments)
+
+	for _, token := range tokens {
+		count := counts[token]
+		logSpam += math.Log((float64(count.Spam_Count) + 1) / (spamDocuments + 2))
+		logHam += math.Log((float64(count.Ham_Count) + 1) / (hamDocuments + 2))
+	}
+
+	return 1 / (1 + math.Exp(logHam-logSpam))
+}
+
+func (scorer *NaiveBayesScorer) Train(ctx context.Context, tx *sql.Tx, text, label string) {
+	scorer.adjust(ctx, tx, text, label, 1)
+}
+
+// Untrain reverses an earlier Train call, used when a moderator changes
+// their mind about a comment.
+func (scorer *NaiveBayesScorer) Untrain(ctx context.Context, tx *sql.Tx, text, label string) {
+	scorer.adjust(ctx, tx, text, label, -1)
+}
+
+func (scorer *NaiveBayesScorer) adjust(ctx context.Context, tx *sql.Tx, text, label string, delta int) {
+	scorer.repository.AdjustCorpus(ctx, tx, label, delta)
+	scorer.repository.AdjustTokens(ctx, tx, Tokenize(text), label, delta)
+}
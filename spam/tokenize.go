@@ -0,0 +1,43 @@
+package spam
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+const (
+	linkToken      = "__link__"
+	minTokenLength = 2
+	maxTokenLength = 64
+)
+
+// Tokenize splits text into the distinct lowercase words the classifier
+// learns from. Links are collapsed into a single marker token because spam
+// rarely repeats the exact same URL.
+func Tokenize(text string) []string {
+	seen := map[string]bool{}
+	var tokens []string
+
+	if helpers.ContainsLink(text) {
+		seen[linkToken] = true
+		tokens = append(tokens, linkToken)
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	for _, word := range words {
+		length := len([]rune(word))
+		if length < minTokenLength || length > maxTokenLength || seen[word] {
+			continue
+		}
+
+		seen[word] = true
+		tokens = append(tokens, word)
+	}
+
+	return tokens
+}
@@ -0,0 +1,59 @@
+package stats
+
+import (
+	"net/http"
+
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/julienschmidt/httprouter"
+)
+
+type StatsController interface {
+	FindStatsHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
+
+type StatsControllerImpl struct {
+	service StatsService
+}
+
+func NewStatsController(service StatsService) StatsController {
+	return &StatsControllerImpl{
+		service: service,
+	}
+}
+
+func (controller *StatsControllerImpl) FindStatsHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	isAdmin := helpers.IsAdmin(request)
+	query := request.URL.Query()
+
+	from, err := helpers.ParseDateQuery(query.Get("from"))
+	if err != nil {
+		panic(exception.NewBadRequestError("invalid from, use YYYY-MM-DD"))
+	}
+
+	to, err := helpers.ParseDateQuery(query.Get("to"))
+	if err != nil {
+		panic(exception.NewBadRequestError("invalid to, use YYYY-MM-DD"))
+	}
+
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1)
+	}
+
+	statsRequest := StatsRequest{
+		Interval: query.Get("interval"),
+		From:     from,
+		To:       to,
+	}
+
+	stats := controller.service.Find(request.Context(), statsRequest, isAdmin)
+
+	statsResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   stats,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, statsResponse)
+}
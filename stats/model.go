@@ -0,0 +1,70 @@
+package stats
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+const (
+	SeriesSignups        = "signups"
+	SeriesPostsCreated   = "posts_created"
+	SeriesPostsPublished = "posts_published"
+	SeriesComments       = "comments"
+	SeriesFollows        = "follows"
+)
+
+type Totals struct {
+	Users           int
+	Posts           int
+	Published_Posts int
+	Comments        int
+	Follows         int
+}
+
+type SeriesPoint struct {
+	Period string
+	Count  int
+}
+
+type TopAuthor struct {
+	User_Id  int
+	Username string
+	Posts    int
+}
+
+type TopCategory struct {
+	Category_Id int
+	Name        string
+	Posts       int
+}
+
+// periodKey formats t the same way the repository's DATE_FORMAT patterns do,
+// so that empty periods can be filled in with a zero count.
+func periodKey(t time.Time, interval string) string {
+	switch interval {
+	case IntervalWeek:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%04d-W%02d", year, week)
+	case IntervalMonth:
+		return t.Format("2006-01")
+	default:
+		return t.Format("2006-01-02")
+	}
+}
+
+func nextPeriod(t time.Time, interval string) time.Time {
+	switch interval {
+	case IntervalWeek:
+		return t.AddDate(0, 0, 7)
+	case IntervalMonth:
+		return time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
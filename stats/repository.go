@@ -0,0 +1,137 @@
+package stats
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type StatsRepository interface {
+	CountTotals(ctx context.Context, tx *sql.Tx) Totals
+	CountSeries(ctx context.Context, tx *sql.Tx, series, interval string, from, to time.Time) []SeriesPoint
+	FindTopAuthors(ctx context.Context, tx *sql.Tx, from, to time.Time, limit int) []TopAuthor
+	FindTopCategories(ctx context.Context, tx *sql.Tx, from, to time.Time, limit int) []TopCategory
+}
+
+type StatsRepositoryImpl struct {
+}
+
+func NewStatsRepository() StatsRepository {
+	return &StatsRepositoryImpl{}
+}
+
+var seriesSources = map[string]struct {
+	table  string
+	column string
+	where  string
+}{
+	SeriesSignups:        {table: "user", column: "created_at", where: "true"},
+	SeriesPostsCreated:   {table: "post", column: "created_at", where: "true"},
+	SeriesPostsPublished: {table: "post", column: "published_at", where: "published_at IS NOT NULL"},
+	SeriesComments:       {table: "comment", column: "created_at", where: "true"},
+	SeriesFollows:        {table: "follow", column: "created_at", where: "true"},
+}
+
+var intervalFormats = map[string]string{
+	IntervalDay:   "%Y-%m-%d",
+	IntervalWeek:  "%x-W%v",
+	IntervalMonth: "%Y-%m",
+}
+
+func (repository *StatsRepositoryImpl) CountTotals(ctx context.Context, tx *sql.Tx) Totals {
+	query := `SELECT
+		(SELECT COUNT(*) FROM user WHERE is_deleted = false),
+		(SELECT COUNT(*) FROM post WHERE is_deleted = false),
+		(SELECT COUNT(*) FROM post WHERE is_deleted = false AND is_published = true),
+		(SELECT COUNT(*) FROM comment WHERE is_deleted = false),
+		(SELECT COUNT(*) FROM follow)`
+
+	rows, err := tx.QueryContext(ctx, query)
+	helpers.PanicError(err, "failed to query stats totals")
+
+	defer rows.Close()
+
+	var totals Totals
+
+	if rows.Next() {
+		err := rows.Scan(&totals.Users, &totals.Posts, &totals.Published_Posts, &totals.Comments, &totals.Follows)
+		helpers.PanicError(err, "failed to scan stats totals")
+	}
+
+	return totals
+}
+
+func (repository *StatsRepositoryImpl) CountSeries(ctx context.Context, tx *sql.Tx, series, interval string, from, to time.Time) []SeriesPoint {
+	source := seriesSources[series]
+	format := intervalFormats[interval]
+
+	query := "SELECT DATE_FORMAT(" + source.column + ", ?) AS period, COUNT(*) FROM " + source.table +
+		" WHERE " + source.where + " AND " + source.column + " >= ? AND " + source.column + " < ? GROUP BY period ORDER BY period"
+
+	rows, err := tx.QueryContext(ctx, query, format, from, to)
+	helpers.PanicError(err, "failed to query stats series")
+
+	defer rows.Close()
+
+	var points []SeriesPoint
+
+	for rows.Next() {
+		var point SeriesPoint
+		err := rows.Scan(&point.Period, &point.Count)
+		helpers.PanicError(err, "failed to scan stats series")
+
+		points = append(points, point)
+	}
+
+	return points
+}
+
+func (repository *StatsRepositoryImpl) FindTopAuthors(ctx context.Context, tx *sql.Tx, from, to time.Time, limit int) []TopAuthor {
+	query := `SELECT u.id, u.username, COUNT(p.id) AS posts FROM post p
+		JOIN user u ON u.id = p.user_id
+		WHERE p.is_deleted = false AND p.is_published = true AND u.is_deleted = false AND p.published_at >= ? AND p.published_at < ?
+		GROUP BY u.id, u.username ORDER BY posts DESC, u.id ASC LIMIT ?`
+
+	rows, err := tx.QueryContext(ctx, query, from, to, limit)
+	helpers.PanicError(err, "failed to query stats top authors")
+
+	defer rows.Close()
+
+	var authors []TopAuthor
+
+	for rows.Next() {
+		var author TopAuthor
+		err := rows.Scan(&author.User_Id, &author.Username, &author.Posts)
+		helpers.PanicError(err, "failed to scan stats top authors")
+
+		authors = append(authors, author)
+	}
+
+	return authors
+}
+
+func (repository *StatsRepositoryImpl) FindTopCategories(ctx context.Context, tx *sql.Tx, from, to time.Time, limit int) []TopCategory {
+	query := `SELECT c.id, c.name, COUNT(p.id) AS posts FROM post p
+		JOIN category c ON c.id = p.category_id
+		WHERE p.is_deleted = false AND p.is_published = true AND p.published_at >= ? AND p.published_at < ?
+		GROUP BY c.id, c.name ORDER BY posts DESC, c.id ASC LIMIT ?`
+
+	rows, err := tx.QueryContext(ctx, query, from, to, limit)
+	helpers.PanicError(err, "failed to query stats top categories")
+
+	defer rows.Close()
+
+	var categories []TopCategory
+
+	for rows.Next() {
+		var category TopCategory
+		err := rows.Scan(&category.Category_Id, &category.Name, &category.Posts)
+		helpers.PanicError(err, "failed to scan stats top categories")
+
+		categories = append(categories, category)
+	}
+
+	return categories
+}
@@ -0,0 +1,220 @@
+package stats
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+const topLimit = 10
+
+// maxPoints bounds every series to a year of days, or the same number of
+// weeks or months, and maxCacheEntries bounds how many ranges are cached.
+const (
+	maxPoints       = 366
+	maxCacheEntries = 100
+)
+
+type StatsService interface {
+	Find(ctx context.Context, request StatsRequest, isAdmin bool) StatsResponse
+}
+
+type cacheEntry struct {
+	response  StatsResponse
+	expiresAt time.Time
+}
+
+type StatsServiceImpl struct {
+	repository StatsRepository
+	db         *sql.DB
+	validator  *validator.Validate
+	mutex      sync.Mutex
+	cache      map[string]cacheEntry
+}
+
+func NewStatsService(repository StatsRepository, db *sql.DB, validator *validator.Validate) StatsService {
+	return &StatsServiceImpl{
+		repository: repository,
+		db:         db,
+		validator:  validator,
+		cache:      map[string]cacheEntry{},
+	}
+}
+
+func (service *StatsServiceImpl) Find(ctx context.Context, request StatsRequest, isAdmin bool) StatsResponse {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can get stats"))
+	}
+
+	err := service.validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	request = withDefaultRange(request)
+
+	if !request.From.Before(request.To) {
+		panic(exception.NewBadRequestError("from must be before to"))
+	}
+
+	if exceedsMaxPoints(request) {
+		panic(exception.NewBadRequestError(fmt.Sprintf("range is too long, at most %d points per %s", maxPoints, request.Interval)))
+	}
+
+	cacheKey := request.Interval + "|" + request.From.Format(time.RFC3339) + "|" + request.To.Format(time.RFC3339)
+
+	service.mutex.Lock()
+	entry, ok := service.cache[cacheKey]
+	service.mutex.Unlock()
+
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.response
+	}
+
+	response := service.generate(ctx, request)
+
+	cacheSeconds := helpers.EnvInt(helpers.NewEnv().Stats.CacheSeconds, 300)
+
+	service.store(cacheKey, cacheEntry{
+		response:  response,
+		expiresAt: time.Now().Add(time.Duration(cacheSeconds) * time.Second),
+	})
+
+	return response
+}
+
+// store drops expired entries before adding one and, when every cached range
+// is still fresh, the one expiring first, so the cache never outgrows
+// maxCacheEntries.
+func (service *StatsServiceImpl) store(cacheKey string, entry cacheEntry) {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+
+	now := time.Now()
+
+	for key, cached := range service.cache {
+		if !now.Before(cached.expiresAt) {
+			delete(service.cache, key)
+		}
+	}
+
+	if _, ok := service.cache[cacheKey]; !ok && len(service.cache) >= maxCacheEntries {
+		oldestKey := ""
+		for key, cached := range service.cache {
+			if oldestKey == "" || cached.expiresAt.Before(service.cache[oldestKey].expiresAt) {
+				oldestKey = key
+			}
+		}
+		delete(service.cache, oldestKey)
+	}
+
+	service.cache[cacheKey] = entry
+}
+
+func (service *StatsServiceImpl) generate(ctx context.Context, request StatsRequest) StatsResponse {
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	totals := service.repository.CountTotals(ctx, tx)
+
+	series := map[string][]SeriesPointResponse{}
+
+	for _, name := range []string{SeriesSignups, SeriesPostsCreated, SeriesPostsPublished, SeriesComments, SeriesFollows} {
+		points := service.repository.CountSeries(ctx, tx, name, request.Interval, request.From, request.To)
+		series[name] = fillSeries(points, request)
+	}
+
+	topAuthors := []TopAuthorResponse{}
+
+	for _, author := range service.repository.FindTopAuthors(ctx, tx, request.From, request.To, topLimit) {
+		topAuthors = append(topAuthors, ToTopAuthorResponse(author))
+	}
+
+	topCategories := []TopCategoryResponse{}
+
+	for _, category := range service.repository.FindTopCategories(ctx, tx, request.From, request.To, topLimit) {
+		topCategories = append(topCategories, ToTopCategoryResponse(category))
+	}
+
+	return StatsResponse{
+		Interval:       request.Interval,
+		From:           request.From,
+		To:             request.To,
+		Totals:         ToTotalsResponse(totals),
+		Series:         series,
+		Top_Authors:    topAuthors,
+		Top_Categories: topCategories,
+		Generated_At:   time.Now(),
+	}
+}
+
+func withDefaultRange(request StatsRequest) StatsRequest {
+	if request.Interval == "" {
+		request.Interval = IntervalDay
+	}
+
+	if request.To.IsZero() {
+		now := time.Now().UTC()
+		request.To = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	}
+
+	if request.From.IsZero() {
+		switch request.Interval {
+		case IntervalWeek:
+			request.From = request.To.AddDate(0, 0, -12*7)
+		case IntervalMonth:
+			request.From = request.To.AddDate(0, -12, 0)
+		default:
+			request.From = request.To.AddDate(0, 0, -30)
+		}
+	}
+
+	return request
+}
+
+// fillSeries returns one point per period between from and to, using zero
+// for periods the database returned no rows for.
+func fillSeries(points []SeriesPoint, request StatsRequest) []SeriesPointResponse {
+	counts := map[string]int{}
+	for _, point := range points {
+		counts[point.Period] = point.Count
+	}
+
+	filled := []SeriesPointResponse{}
+
+	for t := firstPeriod(request); t.Before(request.To); t = nextPeriod(t, request.Interval) {
+		period := periodKey(t, request.Interval)
+		filled = append(filled, SeriesPointResponse{Period: period, Count: counts[period]})
+	}
+
+	return filled
+}
+
+// firstPeriod is the start of the period from falls in, weeks start on Monday.
+func firstPeriod(request StatsRequest) time.Time {
+	start := request.From
+	switch request.Interval {
+	case IntervalWeek:
+		start = start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+	case IntervalMonth:
+		start = time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, start.Location())
+	}
+
+	return start
+}
+
+// exceedsMaxPoints reports whether fillSeries would return more than
+// maxPoints points for the request, without building them.
+func exceedsMaxPoints(request StatsRequest) bool {
+	t := firstPeriod(request)
+	for i := 0; i < maxPoints; i++ {
+		t = nextPeriod(t, request.Interval)
+	}
+
+	return t.Before(request.To)
+}
@@ -0,0 +1,9 @@
+package stats
+
+import "time"
+
+type StatsRequest struct {
+	Interval string `validate:"omitempty,oneof=day week month"`
+	From     time.Time
+	To       time.Time
+}
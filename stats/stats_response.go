@@ -0,0 +1,65 @@
+package stats
+
+import "time"
+
+type TotalsResponse struct {
+	Users           int `json:"users"`
+	Posts           int `json:"posts"`
+	Published_Posts int `json:"published_posts"`
+	Comments        int `json:"comments"`
+	Follows         int `json:"follows"`
+}
+
+type SeriesPointResponse struct {
+	Period string `json:"period"`
+	Count  int    `json:"count"`
+}
+
+type TopAuthorResponse struct {
+	User_Id  int    `json:"user_id"`
+	Username string `json:"username"`
+	Posts    int    `json:"posts"`
+}
+
+type TopCategoryResponse struct {
+	Category_Id int    `json:"category_id"`
+	Name        string `json:"name"`
+	Posts       int    `json:"posts"`
+}
+
+type StatsResponse struct {
+	Interval       string                           `json:"interval"`
+	From           time.Time                        `json:"from"`
+	To             time.Time                        `json:"to"`
+	Totals         TotalsResponse                   `json:"totals"`
+	Series         map[string][]SeriesPointResponse `json:"series"`
+	Top_Authors    []TopAuthorResponse              `json:"top_authors"`
+	Top_Categories []TopCategoryResponse            `json:"top_categories"`
+	Generated_At   time.Time                        `json:"generated_at"`
+}
+
+func ToTotalsResponse(totals Totals) TotalsResponse {
+	return TotalsResponse{
+		Users:           totals.Users,
+		Posts:           totals.Posts,
+		Published_Posts: totals.Published_Posts,
+		Comments:        totals.Comments,
+		Follows:         totals.Follows,
+	}
+}
+
+func ToTopAuthorResponse(author TopAuthor) TopAuthorResponse {
+	return TopAuthorResponse{
+		User_Id:  author.User_Id,
+		Username: author.Username,
+		Posts:    author.Posts,
+	}
+}
+
+func ToTopCategoryResponse(category TopCategory) TopCategoryResponse {
+	return TopCategoryResponse{
+		Category_Id: category.Category_Id,
+		Name:        category.Name,
+		Posts:       category.Posts,
+	}
+}
@@ -0,0 +1,94 @@
+package suspension
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/julienschmidt/httprouter"
+)
+
+type SuspensionController interface {
+	SuspendUserHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	LiftSuspensionHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAllSuspensionByUserHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
+
+type SuspensionControllerImpl struct {
+	service SuspensionService
+}
+
+func NewSuspensionController(service SuspensionService) SuspensionController {
+	return &SuspensionControllerImpl{
+		service: service,
+	}
+}
+
+func (controller *SuspensionControllerImpl) SuspendUserHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	var suspensionRequest SuspensionCreateRequest
+	helpers.DecodeJSONFromRequest(request, &suspensionRequest)
+
+	id := params.ByName("userId")
+	userId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid User Id")
+
+	suspensionRequest.User_Id = userId
+	suspensionRequest.Admin_Id = helpers.GetUserId(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	suspension := controller.service.Suspend(request.Context(), suspensionRequest, isAdmin)
+
+	suspensionResponse := helpers.ResponseJSON{
+		Code:   http.StatusCreated,
+		Status: "CREATED",
+		Data:   suspension,
+	}
+
+	writer.WriteHeader(http.StatusCreated)
+	helpers.EncodeJSONFromResponse(writer, suspensionResponse)
+}
+
+func (controller *SuspensionControllerImpl) LiftSuspensionHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("userId")
+	userId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid User Id")
+
+	adminId := helpers.GetUserId(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	controller.service.Lift(request.Context(), userId, adminId, isAdmin)
+
+	suspensionResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "DELETED",
+		Data:   "suspension lifted",
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, suspensionResponse)
+}
+
+func (controller *SuspensionControllerImpl) FindAllSuspensionByUserHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("userId")
+	userId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid User Id")
+
+	isAdmin := helpers.IsAdmin(request)
+	limit, offset := helpers.GetLimitOffset(request)
+
+	suspensions, countSuspensions := controller.service.FindAllByUser(request.Context(), userId, limit, offset, isAdmin)
+
+	suspensionResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"suspensions": suspensions,
+			"limit":       limit,
+			"offset":      offset,
+			"total":       countSuspensions,
+		},
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, suspensionResponse)
+}
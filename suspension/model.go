@@ -0,0 +1,23 @@
+package suspension
+
+import "time"
+
+type Suspension struct {
+	Id         int
+	User_Id    int
+	Admin_Id   int
+	Reason     string
+	Expires_At time.Time
+	Lifted_At  time.Time
+	Lifted_By  int
+	Created_At time.Time
+	Updated_At time.Time
+}
+
+func (suspension Suspension) IsPermanent() bool {
+	return suspension.Expires_At.IsZero()
+}
+
+func (suspension Suspension) IsActive() bool {
+	return suspension.Lifted_At.IsZero() && (suspension.IsPermanent() || time.Now().Before(suspension.Expires_At))
+}
@@ -0,0 +1,151 @@
+package suspension
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type SuspensionRepository interface {
+	Save(ctx context.Context, tx *sql.Tx, suspension Suspension) Suspension
+	FindById(ctx context.Context, tx *sql.Tx, suspensionId int) Suspension
+	FindAllByUser(ctx context.Context, tx *sql.Tx, userId, limit, offset int) []Suspension
+	CountAllByUser(ctx context.Context, tx *sql.Tx, userId int) int
+	LiftActive(ctx context.Context, tx *sql.Tx, userId, adminId int) int
+	FindUserRole(ctx context.Context, tx *sql.Tx, userId int) string
+}
+
+type SuspensionRepositoryImpl struct {
+}
+
+func NewSuspensionRepository() SuspensionRepository {
+	return &SuspensionRepositoryImpl{}
+}
+
+func (repository *SuspensionRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, suspension Suspension) Suspension {
+	query := "INSERT INTO user_suspension(user_id, admin_id, reason, expires_at) VALUES (?, ?, ?, ?)"
+
+	var expiresAt sql.NullTime
+	if !suspension.Expires_At.IsZero() {
+		expiresAt = sql.NullTime{Time: suspension.Expires_At, Valid: true}
+	}
+
+	result, err := tx.ExecContext(ctx, query, suspension.User_Id, suspension.Admin_Id, suspension.Reason, expiresAt)
+	helpers.PanicError(err, "failed to exec query insert suspension")
+
+	id, err := result.LastInsertId()
+	helpers.PanicError(err, "failed to get last insert id suspension")
+
+	return repository.FindById(ctx, tx, int(id))
+}
+
+func (repository *SuspensionRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, suspensionId int) Suspension {
+	query := "SELECT id, user_id, admin_id, reason, expires_at, lifted_at, lifted_by, created_at, updated_at FROM user_suspension WHERE id = ?"
+
+	rows, err := tx.QueryContext(ctx, query, suspensionId)
+	helpers.PanicError(err, "failed to query suspension")
+
+	defer rows.Close()
+
+	if rows.Next() {
+		return scanSuspension(rows)
+	}
+
+	panic(exception.NewNotFoundError("suspension not found"))
+}
+
+func (repository *SuspensionRepositoryImpl) FindAllByUser(ctx context.Context, tx *sql.Tx, userId, limit, offset int) []Suspension {
+	query := "SELECT id, user_id, admin_id, reason, expires_at, lifted_at, lifted_by, created_at, updated_at FROM user_suspension WHERE user_id = ? ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?"
+
+	rows, err := tx.QueryContext(ctx, query, userId, limit, offset)
+	helpers.PanicError(err, "failed to query suspensions")
+
+	defer rows.Close()
+
+	var suspensions []Suspension
+
+	for rows.Next() {
+		suspensions = append(suspensions, scanSuspension(rows))
+	}
+
+	return suspensions
+}
+
+func (repository *SuspensionRepositoryImpl) CountAllByUser(ctx context.Context, tx *sql.Tx, userId int) int {
+	query := "SELECT COUNT(*) FROM user_suspension WHERE user_id = ?"
+
+	rows, err := tx.QueryContext(ctx, query, userId)
+	helpers.PanicError(err, "failed to query count suspensions")
+
+	defer rows.Close()
+
+	var countSuspensions int
+
+	if rows.Next() {
+		err := rows.Scan(&countSuspensions)
+		helpers.PanicError(err, "failed to scan count suspensions")
+	}
+
+	return countSuspensions
+}
+
+func (repository *SuspensionRepositoryImpl) LiftActive(ctx context.Context, tx *sql.Tx, userId, adminId int) int {
+	query := "UPDATE user_suspension SET lifted_at = NOW(), lifted_by = ? WHERE user_id = ? AND lifted_at IS NULL AND (expires_at IS NULL OR expires_at > NOW())"
+
+	result, err := tx.ExecContext(ctx, query, adminId, userId)
+	helpers.PanicError(err, "failed to exec query lift suspension")
+
+	rowsAffected, err := result.RowsAffected()
+	helpers.PanicError(err, "failed to get rows affected suspension")
+
+	return int(rowsAffected)
+}
+
+func (repository *SuspensionRepositoryImpl) FindUserRole(ctx context.Context, tx *sql.Tx, userId int) string {
+	query := "SELECT r.name FROM user u JOIN role r ON r.id = u.role_id WHERE u.id = ? AND u.is_deleted = false"
+
+	rows, err := tx.QueryContext(ctx, query, userId)
+	helpers.PanicError(err, "failed to query suspension user role")
+
+	defer rows.Close()
+
+	var roleName string
+
+	if rows.Next() {
+		err := rows.Scan(&roleName)
+		helpers.PanicError(err, "failed to scan suspension user role")
+	}
+
+	return roleName
+}
+
+func scanSuspension(rows *sql.Rows) Suspension {
+	var suspension Suspension
+	var expiresAt sql.NullTime
+	var liftedAt sql.NullTime
+	var liftedBy sql.NullInt64
+
+	err := rows.Scan(&suspension.Id, &suspension.User_Id, &suspension.Admin_Id, &suspension.Reason, &expiresAt, &liftedAt, &liftedBy, &suspension.Created_At, &suspension.Updated_At)
+	helpers.PanicError(err, "failed to scan suspension")
+
+	if expiresAt.Valid {
+		suspension.Expires_At = expiresAt.Time
+	} else {
+		suspension.Expires_At = time.Time{}
+	}
+
+	if liftedAt.Valid {
+		suspension.Lifted_At = liftedAt.Time
+	} else {
+		suspension.Lifted_At = time.Time{}
+	}
+
+	if liftedBy.Valid {
+		suspension.Lifted_By = int(liftedBy.Int64)
+	}
+
+	return suspension
+}
@@ -0,0 +1,116 @@
+package suspension
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type SuspensionService interface {
+	Suspend(ctx context.Context, request SuspensionCreateRequest, isAdmin bool) SuspensionResponse
+	Lift(ctx context.Context, userId, adminId int, isAdmin bool)
+	FindAllByUser(ctx context.Context, userId, limit, offset int, isAdmin bool) ([]SuspensionResponse, int)
+}
+
+type SuspensionServiceImpl struct {
+	repository      SuspensionRepository
+	auditRepository audit.AuditRepository
+	db              *sql.DB
+	validator       *validator.Validate
+}
+
+func NewSuspensionService(repository SuspensionRepository, auditRepository audit.AuditRepository, db *sql.DB, validator *validator.Validate) SuspensionService {
+	return &SuspensionServiceImpl{
+		repository:      repository,
+		auditRepository: auditRepository,
+		db:              db,
+		validator:       validator,
+	}
+}
+
+func (service *SuspensionServiceImpl) Suspend(ctx context.Context, request SuspensionCreateRequest, isAdmin bool) SuspensionResponse {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can suspend users"))
+	}
+
+	err := service.validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	if request.User_Id == request.Admin_Id {
+		panic(exception.NewBadRequestError("admin cannot suspend their own account"))
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	roleName := service.repository.FindUserRole(ctx, tx, request.User_Id)
+
+	if roleName == "" {
+		panic(exception.NewNotFoundError("user not found"))
+	}
+
+	if roleName == "admin" {
+		panic(exception.NewBadRequestError("admin accounts cannot be suspended"))
+	}
+
+	newSuspension := Suspension{
+		User_Id:  request.User_Id,
+		Admin_Id: request.Admin_Id,
+		Reason:   request.Reason,
+	}
+
+	if !request.Permanent {
+		newSuspension.Expires_At = time.Now().Add(time.Duration(request.Duration_Hours) * time.Hour)
+	}
+
+	suspension := service.repository.Save(ctx, tx, newSuspension)
+
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionUserSuspend, audit.TargetUser, suspension.User_Id, nil, ToSuspensionResponse(suspension)))
+
+	return ToSuspensionResponse(suspension)
+}
+
+func (service *SuspensionServiceImpl) Lift(ctx context.Context, userId, adminId int, isAdmin bool) {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can lift suspensions"))
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	liftedSuspensions := service.repository.LiftActive(ctx, tx, userId, adminId)
+
+	if liftedSuspensions == 0 {
+		panic(exception.NewNotFoundError("active suspension not found"))
+	}
+
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionUserSuspendLift, audit.TargetUser, userId, nil, nil))
+}
+
+func (service *SuspensionServiceImpl) FindAllByUser(ctx context.Context, userId, limit, offset int, isAdmin bool) ([]SuspensionResponse, int) {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can get user suspensions"))
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	suspensions := service.repository.FindAllByUser(ctx, tx, userId, limit, offset)
+	countSuspensions := service.repository.CountAllByUser(ctx, tx, userId)
+
+	var suspensionResponses []SuspensionResponse
+
+	for _, suspension := range suspensions {
+		suspensionResponses = append(suspensionResponses, ToSuspensionResponse(suspension))
+	}
+
+	return suspensionResponses, countSuspensions
+}
@@ -0,0 +1,9 @@
+package suspension
+
+type SuspensionCreateRequest struct {
+	User_Id        int    `json:"user_id" validate:"required"`
+	Admin_Id       int    `json:"admin_id" validate:"required"`
+	Reason         string `json:"reason" validate:"required,min=1,max=500"`
+	Duration_Hours int    `json:"duration_hours" validate:"required_without=Permanent,omitempty,min=1"`
+	Permanent      bool   `json:"permanent"`
+}
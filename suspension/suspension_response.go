@@ -0,0 +1,35 @@
+package suspension
+
+import (
+	"time"
+)
+
+type SuspensionResponse struct {
+	Id         int       `json:"id"`
+	User_Id    int       `json:"user_id"`
+	Admin_Id   int       `json:"admin_id"`
+	Reason     string    `json:"reason"`
+	Permanent  bool      `json:"permanent"`
+	Active     bool      `json:"active"`
+	Expires_At time.Time `json:"expires_at"`
+	Lifted_At  time.Time `json:"lifted_at"`
+	Lifted_By  int       `json:"lifted_by"`
+	Created_At time.Time `json:"created_at"`
+	Updated_At time.Time `json:"updated_at"`
+}
+
+func ToSuspensionResponse(suspension Suspension) SuspensionResponse {
+	return SuspensionResponse{
+		Id:         suspension.Id,
+		User_Id:    suspension.User_Id,
+		Admin_Id:   suspension.Admin_Id,
+		Reason:     suspension.Reason,
+		Permanent:  suspension.IsPermanent(),
+		Active:     suspension.IsActive(),
+		Expires_At: suspension.Expires_At,
+		Lifted_At:  suspension.Lifted_At,
+		Lifted_By:  suspension.Lifted_By,
+		Created_At: suspension.Created_At,
+		Updated_At: suspension.Updated_At,
+	}
+}
@@ -0,0 +1,108 @@
+package tag
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/julienschmidt/httprouter"
+)
+
+type TagController interface {
+	FindAllTagHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	AutocompleteTagHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	RenameTagHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	MergeTagHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
+
+type TagControllerImpl struct {
+	service TagService
+}
+
+func NewTagController(service TagService) TagController {
+	return &TagControllerImpl{
+		service: service,
+	}
+}
+
+func (controller *TagControllerImpl) FindAllTagHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	limit, offset := helpers.GetLimitOffset(request)
+
+	tags, countTags := controller.service.FindAll(request.Context(), limit, offset)
+
+	tagResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"tags":   tags,
+			"limit":  limit,
+			"offset": offset,
+			"total":  countTags,
+		},
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, tagResponse)
+}
+
+func (controller *TagControllerImpl) AutocompleteTagHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	limit, _ := helpers.GetLimitOffset(request)
+	query := request.URL.Query().Get("q")
+
+	tags := controller.service.Autocomplete(request.Context(), query, limit)
+
+	tagResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   tags,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, tagResponse)
+}
+
+func (controller *TagControllerImpl) RenameTagHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("tagId")
+	tagId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid Tag Id")
+
+	var renameRequest TagRenameRequest
+	helpers.DecodeJSONFromRequest(request, &renameRequest)
+
+	renameRequest.Id = tagId
+	isAdmin := helpers.IsAdmin(request)
+
+	tag := controller.service.Rename(request.Context(), renameRequest, isAdmin)
+
+	tagResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "UPDATED",
+		Data:   tag,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, tagResponse)
+}
+
+func (controller *TagControllerImpl) MergeTagHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("tagId")
+	tagId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid Tag Id")
+
+	var mergeRequest TagMergeRequest
+	helpers.DecodeJSONFromRequest(request, &mergeRequest)
+
+	mergeRequest.Id = tagId
+	isAdmin := helpers.IsAdmin(request)
+
+	tag := controller.service.Merge(request.Context(), mergeRequest, isAdmin)
+
+	tagResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "UPDATED",
+		Data:   tag,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, tagResponse)
+}
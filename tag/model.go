@@ -0,0 +1,16 @@
+package tag
+
+import "time"
+
+const (
+	MaxNameLength  = 50
+	MaxTagsPerPost = 10
+)
+
+type Tag struct {
+	Id         int
+	Name       string
+	Post_Count int
+	Created_At time.Time
+	Updated_At time.Time
+}
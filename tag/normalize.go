@@ -0,0 +1,50 @@
+package tag
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Normalize lower-cases a tag and joins its words with hyphens, so "Go",
+// "#go" and " GO " are the same tag and "Machine Learning" becomes
+// "machine-learning". Commas are dropped since post queries use them to
+// join tag names.
+func Normalize(name string) string {
+	name = strings.ToLower(norm.NFKC.String(name))
+	name = strings.Map(func(r rune) rune {
+		if r == ',' || r == '#' || unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, name)
+	name = strings.Join(strings.Fields(name), "-")
+
+	for utf8.RuneCountInString(name) > MaxNameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+
+	return strings.Trim(name, "-")
+}
+
+// NormalizeAll normalises names and drops empty and duplicate tags while
+// keeping the order they were given in.
+func NormalizeAll(names []string) []string {
+	seen := make(map[string]bool)
+	var tags []string
+
+	for _, name := range names {
+		normalized := Normalize(name)
+		if normalized == "" || seen[normalized] {
+			continue
+		}
+
+		seen[normalized] = true
+		tags = append(tags, normalized)
+	}
+
+	return tags
+}
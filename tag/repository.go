@@ -0,0 +1,155 @@
+package tag
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type TagRepository interface {
+	FindOrCreate(ctx context.Context, tx *sql.Tx, name string) int
+	FindAll(ctx context.Context, tx *sql.Tx, limit, offset int) []Tag
+	CountAll(ctx context.Context, tx *sql.Tx) int
+	FindAllByPrefix(ctx context.Context, tx *sql.Tx, prefix string, limit int) []Tag
+	FindById(ctx context.Context, tx *sql.Tx, tagId int) Tag
+	FindIdByName(ctx context.Context, tx *sql.Tx, name string) int
+	Rename(ctx context.Context, tx *sql.Tx, tagId int, name string)
+	Merge(ctx context.Context, tx *sql.Tx, tagId, intoTagId int)
+	SetPostTags(ctx context.Context, tx *sql.Tx, postId int, tagIds []int)
+}
+
+type TagRepositoryImpl struct {
+}
+
+func NewTagRepository() TagRepository {
+	return &TagRepositoryImpl{}
+}
+
+// Tag counts only include public posts anyone can read.
+const selectTagWithCount = `SELECT t.id, t.name, COUNT(u.id) AS post_count, t.created_at, t.updated_at 
+	FROM tag t 
+	LEFT JOIN post_tag pt ON pt.tag_id = t.id 
+	LEFT JOIN post p ON p.id = pt.post_id AND p.is_deleted = false AND p.is_hidden = false AND p.is_published = true AND p.moderation_status = 'approved' AND p.visibility = 'public' 
+	LEFT JOIN user u ON u.id = p.user_id AND u.is_deleted = false AND u.is_deactivated = false `
+
+// FindOrCreate returns the id of the tag with the given normalised name,
+// creating it when it does not exist yet.
+func (repository *TagRepositoryImpl) FindOrCreate(ctx context.Context, tx *sql.Tx, name string) int {
+	query := "INSERT INTO tag(name) VALUES (?) ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id)"
+
+	result, err := tx.ExecContext(ctx, query, name)
+	helpers.PanicError(err, "failed to exec query insert tag")
+
+	id, err := result.LastInsertId()
+	helpers.PanicError(err, "failed to get last insert id tag")
+
+	return int(id)
+}
+
+func (repository *TagRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, limit, offset int) []Tag {
+	query := selectTagWithCount + "GROUP BY t.id, t.name, t.created_at, t.updated_at ORDER BY post_count DESC, t.name ASC LIMIT ? OFFSET ?"
+
+	return repository.findTags(ctx, tx, query, limit, offset)
+}
+
+func (repository *TagRepositoryImpl) CountAll(ctx context.Context, tx *sql.Tx) int {
+	query := "SELECT COUNT(*) FROM tag"
+
+	var countTags int
+	err := tx.QueryRowContext(ctx, query).Scan(&countTags)
+	helpers.PanicError(err, "failed to query count tags")
+
+	return countTags
+}
+
+func (repository *TagRepositoryImpl) FindAllByPrefix(ctx context.Context, tx *sql.Tx, prefix string, limit int) []Tag {
+	query := selectTagWithCount + "WHERE t.name LIKE ? GROUP BY t.id, t.name, t.created_at, t.updated_at ORDER BY post_count DESC, t.name ASC LIMIT ?"
+
+	return repository.findTags(ctx, tx, query, helpers.LikePrefixPattern(prefix), limit)
+}
+
+func (repository *TagRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, tagId int) Tag {
+	query := selectTagWithCount + "WHERE t.id = ? GROUP BY t.id, t.name, t.created_at, t.updated_at"
+
+	tags := repository.findTags(ctx, tx, query, tagId)
+
+	if len(tags) == 0 {
+		panic(exception.NewNotFoundError("tag not found"))
+	}
+
+	return tags[0]
+}
+
+func (repository *TagRepositoryImpl) FindIdByName(ctx context.Context, tx *sql.Tx, name string) int {
+	query := "SELECT id FROM tag WHERE name = ?"
+
+	rows, err := tx.QueryContext(ctx, query, name)
+	helpers.PanicError(err, "failed to query tag by name")
+
+	defer rows.Close()
+
+	var tagId int
+
+	if rows.Next() {
+		err := rows.Scan(&tagId)
+		helpers.PanicError(err, "failed to scan tag by name")
+	}
+
+	return tagId
+}
+
+func (repository *TagRepositoryImpl) Rename(ctx context.Context, tx *sql.Tx, tagId int, name string) {
+	query := "UPDATE tag SET name = ? WHERE id = ?"
+
+	_, err := tx.ExecContext(ctx, query, name, tagId)
+	helpers.PanicError(err, "failed to exec query rename tag")
+}
+
+// Merge moves every post of tagId over to intoTagId and deletes tagId. Posts
+// that already had both tags keep a single one.
+func (repository *TagRepositoryImpl) Merge(ctx context.Context, tx *sql.Tx, tagId, intoTagId int) {
+	queryMove := "INSERT IGNORE INTO post_tag(post_id, tag_id, created_at) SELECT post_id, ?, created_at FROM post_tag WHERE tag_id = ?"
+
+	_, err := tx.ExecContext(ctx, queryMove, intoTagId, tagId)
+	helpers.PanicError(err, "failed to exec query move post tags")
+
+	queryDelete := "DELETE FROM tag WHERE id = ?"
+
+	_, err = tx.ExecContext(ctx, queryDelete, tagId)
+	helpers.PanicError(err, "failed to exec query delete tag")
+}
+
+func (repository *TagRepositoryImpl) SetPostTags(ctx context.Context, tx *sql.Tx, postId int, tagIds []int) {
+	queryDelete := "DELETE FROM post_tag WHERE post_id = ?"
+
+	_, err := tx.ExecContext(ctx, queryDelete, postId)
+	helpers.PanicError(err, "failed to exec query delete post tags")
+
+	queryInsert := "INSERT INTO post_tag(post_id, tag_id) VALUES (?, ?)"
+
+	for _, tagId := range tagIds {
+		_, err := tx.ExecContext(ctx, queryInsert, postId, tagId)
+		helpers.PanicError(err, "failed to exec query insert post tag")
+	}
+}
+
+func (repository *TagRepositoryImpl) findTags(ctx context.Context, tx *sql.Tx, query string, args ...interface{}) []Tag {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	helpers.PanicError(err, "failed to query tags")
+
+	defer rows.Close()
+
+	var tags []Tag
+
+	for rows.Next() {
+		var tag Tag
+		err := rows.Scan(&tag.Id, &tag.Name, &tag.Post_Count, &tag.Created_At, &tag.Updated_At)
+		helpers.PanicError(err, "failed to scan tags")
+
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
@@ -0,0 +1,134 @@
+package tag
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/exception"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type TagService interface {
+	FindAll(ctx context.Context, limit, offset int) ([]TagResponse, int)
+	Autocomplete(ctx context.Context, query string, limit int) []TagResponse
+	Rename(ctx context.Context, request TagRenameRequest, isAdmin bool) TagResponse
+	Merge(ctx context.Context, request TagMergeRequest, isAdmin bool) TagResponse
+}
+
+type TagServiceImpl struct {
+	repository      TagRepository
+	auditRepository audit.AuditRepository
+	db              *sql.DB
+	validator       *validator.Validate
+}
+
+func NewTagService(repository TagRepository, auditRepository audit.AuditRepository, db *sql.DB, validator *validator.Validate) TagService {
+	return &TagServiceImpl{
+		repository:      repository,
+		auditRepository: auditRepository,
+		db:              db,
+		validator:       validator,
+	}
+}
+
+func (service *TagServiceImpl) FindAll(ctx context.Context, limit, offset int) ([]TagResponse, int) {
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	tags := service.repository.FindAll(ctx, tx, limit, offset)
+	countTags := service.repository.CountAll(ctx, tx)
+
+	var tagsData []TagResponse
+
+	for _, tag := range tags {
+		tagsData = append(tagsData, ToTagResponse(tag))
+	}
+
+	return tagsData, countTags
+}
+
+// Autocomplete matches the normalised query against the start of tag names,
+// most used tags first.
+func (service *TagServiceImpl) Autocomplete(ctx context.Context, query string, limit int) []TagResponse {
+	prefix := Normalize(query)
+	if prefix == "" {
+		return []TagResponse{}
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	tags := service.repository.FindAllByPrefix(ctx, tx, prefix, limit)
+
+	tagsData := []TagResponse{}
+
+	for _, tag := range tags {
+		tagsData = append(tagsData, ToTagResponse(tag))
+	}
+
+	return tagsData
+}
+
+func (service *TagServiceImpl) Rename(ctx context.Context, request TagRenameRequest, isAdmin bool) TagResponse {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can rename tags"))
+	}
+
+	err := service.validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	name := Normalize(request.Name)
+	if name == "" {
+		panic(exception.NewBadRequestError("invalid tag name"))
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	before := service.repository.FindById(ctx, tx, request.Id)
+
+	if existingId := service.repository.FindIdByName(ctx, tx, name); existingId != 0 && existingId != before.Id {
+		panic(exception.NewBadRequestError("tag already exists, merge the tags instead"))
+	}
+
+	service.repository.Rename(ctx, tx, before.Id, name)
+
+	renamed := service.repository.FindById(ctx, tx, before.Id)
+
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionTagRename, audit.TargetTag, renamed.Id, ToTagResponse(before), ToTagResponse(renamed)))
+
+	return ToTagResponse(renamed)
+}
+
+func (service *TagServiceImpl) Merge(ctx context.Context, request TagMergeRequest, isAdmin bool) TagResponse {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can merge tags"))
+	}
+
+	err := service.validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	if request.Id == request.Into_Tag_Id {
+		panic(exception.NewBadRequestError("cannot merge a tag into itself"))
+	}
+
+	tx, err := service.db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	source := service.repository.FindById(ctx, tx, request.Id)
+	target := service.repository.FindById(ctx, tx, request.Into_Tag_Id)
+
+	service.repository.Merge(ctx, tx, source.Id, target.Id)
+
+	merged := service.repository.FindById(ctx, tx, target.Id)
+
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionTagMerge, audit.TargetTag, source.Id, ToTagResponse(source), ToTagResponse(merged)))
+
+	return ToTagResponse(merged)
+}
@@ -0,0 +1,11 @@
+package tag
+
+type TagRenameRequest struct {
+	Id   int    `json:"id" validate:"required"`
+	Name string `json:"name" validate:"required,min=1,max=100"`
+}
+
+type TagMergeRequest struct {
+	Id          int `json:"id" validate:"required"`
+	Into_Tag_Id int `json:"into_tag_id" validate:"required"`
+}
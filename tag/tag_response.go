@@ -0,0 +1,21 @@
+package tag
+
+import "time"
+
+type TagResponse struct {
+	Id         int       `json:"id"`
+	Name       string    `json:"name"`
+	Post_Count int       `json:"post_count"`
+	Created_At time.Time `json:"created_at"`
+	Updated_At time.Time `json:"updated_at"`
+}
+
+func ToTagResponse(tag Tag) TagResponse {
+	return TagResponse{
+		Id:         tag.Id,
+		Name:       tag.Name,
+		Post_Count: tag.Post_Count,
+		Created_At: tag.Created_At,
+		Updated_At: tag.Updated_At,
+	}
+}
@@ -179,4 +179,28 @@ func TestLoginAdmin(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, responseBody.Code)
 		assert.Equal(t, "BAD REQUEST", responseBody.Status)
 	})
+
+	t.Run("deactivated admin signs in the same way as a user", func(t *testing.T) {
+		signInBody := `{"email": "admin@example.com", "password": "Admin123!"}`
+
+		_, err := db.Exec("UPDATE user SET is_deactivated = true, deactivated_at = NOW() - INTERVAL 400 DAY WHERE id = ?", admin.Id)
+		helpers.PanicError(err, "failed to deactivate admin")
+
+		response, _ := requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/signin-admin", "", signInBody)
+
+		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+		_, err = db.Exec("UPDATE user SET deactivated_at = NOW() - INTERVAL 1 DAY WHERE id = ?", admin.Id)
+		helpers.PanicError(err, "failed to deactivate admin")
+
+		response, _ = requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/signin-admin", "", signInBody)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		var deactivated bool
+		err = db.QueryRow("SELECT is_deactivated FROM user WHERE id = ?", admin.Id).Scan(&deactivated)
+		helpers.PanicError(err, "failed to query admin")
+
+		assert.False(t, deactivated)
+	})
 }
@@ -0,0 +1,102 @@
+package test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindAllAuditLog(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	_, accessToken := createUserTestUser(db)
+	admin, adminAccessToken := createAdminTestAdmin(db)
+
+	categoryBody := strings.NewReader(`{"name": "audited"}`)
+
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/categories", categoryBody)
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+	request.Header.Add("User-Agent", "audit-test")
+
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	assert.Equal(t, http.StatusCreated, recorder.Result().StatusCode)
+
+	t.Run("success find all audit logs", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/admin/audit-logs?action=category.create&actor_id="+strconv.Itoa(admin.Id), nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		auditLog := responseBody.Data.(map[string]interface{})["audit_logs"].([]interface{})[0].(map[string]interface{})
+
+		assert.Equal(t, float64(1), responseBody.Data.(map[string]interface{})["total"])
+		assert.Equal(t, "category", auditLog["target_type"])
+		assert.Equal(t, "audit-test", auditLog["user_agent"])
+		assert.Nil(t, auditLog["before"])
+		assert.Equal(t, "audited", auditLog["after"].(map[string]interface{})["name"])
+	})
+
+	t.Run("success export audit logs as csv", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/admin/audit-logs/export?target_type=category", nil)
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "text/csv", response.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(response.Body)
+		helpers.PanicError(err, "failed to read response body")
+
+		lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+
+		assert.Len(t, lines, 2)
+		assert.True(t, strings.HasPrefix(lines[0], "id,actor_id,action"))
+		assert.Contains(t, lines[1], "category.create")
+	})
+
+	t.Run("failed find all audit logs as non admin", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/admin/audit-logs", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+	})
+}
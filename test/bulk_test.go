@@ -0,0 +1,213 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hutamatr/GoBlogify/category"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func runTestBulk(router http.Handler, url, accessToken, body string) (*http.Response, map[string]interface{}) {
+	request := httptest.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("Authorization", "Bearer "+accessToken)
+
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+
+	responseBodyBytes, err := io.ReadAll(response.Body)
+
+	var responseBody helpers.ResponseJSON
+
+	json.Unmarshal(responseBodyBytes, &responseBody)
+
+	helpers.PanicError(err, "failed to read response body")
+
+	data, _ := responseBody.Data.(map[string]interface{})
+
+	return response, data
+}
+
+func findPostStateTestBulk(db *sql.DB, postId int) (bool, bool, int) {
+	var deleted, published bool
+	var categoryId int
+
+	err := db.QueryRow("SELECT is_deleted, is_published, category_id FROM post WHERE id = ?", postId).Scan(&deleted, &published, &categoryId)
+	helpers.PanicError(err, "failed to query post state")
+
+	return deleted, published, categoryId
+}
+
+func TestBulkPosts(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	categoryOne := createCategoryTestPost(db)
+	user, accessToken := createUserTestUser(db)
+	_, adminAccessToken := createAdminTestAdmin(db)
+	postOne := createPostTestComment(db, user.Id, categoryOne.Id)
+	postTwo := createPostTestComment(db, user.Id, categoryOne.Id)
+
+	tx, err := db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	categoryTwo := category.NewCategoryRepository().Save(context.Background(), tx, category.Category{Name: "category-bulk"})
+	tx.Commit()
+
+	url := "http://localhost:8080/api/v1/admin/bulk/posts"
+	ids := strconv.Itoa(postOne.Id) + ", " + strconv.Itoa(postTwo.Id)
+
+	t.Run("dry run does not change posts", func(t *testing.T) {
+		response, result := runTestBulk(router, url, adminAccessToken, `{"action": "soft_delete", "ids": [`+ids+`], "dry_run": true}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, true, result["dry_run"])
+		assert.Equal(t, 2, int(result["succeeded"].(float64)))
+
+		deleted, _, _ := findPostStateTestBulk(db, postOne.Id)
+		assert.False(t, deleted)
+	})
+
+	t.Run("success soft delete posts by filter", func(t *testing.T) {
+		response, result := runTestBulk(router, url, adminAccessToken, `{"action": "soft_delete", "filter": {"user_id": `+strconv.Itoa(user.Id)+`}}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 2, int(result["matched"].(float64)))
+		assert.Equal(t, 2, int(result["succeeded"].(float64)))
+
+		deleted, _, _ := findPostStateTestBulk(db, postTwo.Id)
+		assert.True(t, deleted)
+	})
+
+	t.Run("per item results report failures", func(t *testing.T) {
+		response, result := runTestBulk(router, url, adminAccessToken, `{"action": "restore", "ids": [`+strconv.Itoa(postOne.Id)+`, 999999]}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 1, int(result["succeeded"].(float64)))
+		assert.Equal(t, 1, int(result["failed"].(float64)))
+		assert.Equal(t, "post not found", result["results"].([]interface{})[1].(map[string]interface{})["error"])
+
+		deleted, _, _ := findPostStateTestBulk(db, postOne.Id)
+		assert.False(t, deleted)
+	})
+
+	t.Run("atomic run rolls back on failure", func(t *testing.T) {
+		response, result := runTestBulk(router, url, adminAccessToken, `{"action": "restore", "ids": [`+ids+`], "atomic": true}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 0, int(result["succeeded"].(float64)))
+		assert.Equal(t, 1, int(result["failed"].(float64)))
+		assert.Equal(t, "failed", result["results"].([]interface{})[0].(map[string]interface{})["status"])
+		assert.Equal(t, "rolled_back", result["results"].([]interface{})[1].(map[string]interface{})["status"])
+
+		deleted, _, _ := findPostStateTestBulk(db, postTwo.Id)
+		assert.True(t, deleted)
+	})
+
+	t.Run("success unpublish and recategorize posts", func(t *testing.T) {
+		response, result := runTestBulk(router, url, adminAccessToken, `{"action": "unpublish", "ids": [`+strconv.Itoa(postOne.Id)+`]}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 1, int(result["succeeded"].(float64)))
+
+		response, result = runTestBulk(router, url, adminAccessToken, `{"action": "recategorize", "ids": [`+ids+`], "category_id": `+strconv.Itoa(categoryTwo.Id)+`}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 2, int(result["succeeded"].(float64)))
+
+		_, published, categoryId := findPostStateTestBulk(db, postOne.Id)
+		assert.False(t, published)
+		assert.Equal(t, categoryTwo.Id, categoryId)
+	})
+
+	t.Run("bad request bulk posts", func(t *testing.T) {
+		response, _ := runTestBulk(router, url, accessToken, `{"action": "soft_delete", "ids": [`+ids+`]}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		response, _ = runTestBulk(router, url, adminAccessToken, `{"action": "soft_delete"}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		response, _ = runTestBulk(router, url, adminAccessToken, `{"action": "recategorize", "ids": [`+ids+`]}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		response, _ = runTestBulk(router, url, adminAccessToken, `{"action": "recategorize", "ids": [`+ids+`], "category_id": 999999}`)
+
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+}
+
+func TestBulkUsers(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	user, accessToken := createUserTestUser(db)
+	admin, adminAccessToken := createAdminTestAdmin(db)
+
+	url := "http://localhost:8080/api/v1/admin/bulk/users"
+	ids := strconv.Itoa(user.Id) + ", " + strconv.Itoa(admin.Id)
+
+	t.Run("success suspend users", func(t *testing.T) {
+		response, result := runTestBulk(router, url, adminAccessToken, `{"action": "suspend", "ids": [`+ids+`], "reason": "spam wave", "duration_hours": 24}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 1, int(result["succeeded"].(float64)))
+		assert.Equal(t, 1, int(result["failed"].(float64)))
+
+		var countSuspensions int
+		err := db.QueryRow("SELECT COUNT(*) FROM user_suspension WHERE user_id = ?", user.Id).Scan(&countSuspensions)
+		helpers.PanicError(err, "failed to count suspensions")
+
+		assert.Equal(t, 1, countSuspensions)
+	})
+
+	t.Run("success delete users with dry run", func(t *testing.T) {
+		response, result := runTestBulk(router, url, adminAccessToken, `{"action": "delete", "ids": [`+strconv.Itoa(user.Id)+`], "dry_run": true}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 1, int(result["succeeded"].(float64)))
+
+		var deleted bool
+		err := db.QueryRow("SELECT is_deleted FROM user WHERE id = ?", user.Id).Scan(&deleted)
+		helpers.PanicError(err, "failed to query user")
+
+		assert.False(t, deleted)
+
+		response, result = runTestBulk(router, url, adminAccessToken, `{"action": "delete", "ids": [`+strconv.Itoa(user.Id)+`]}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 1, int(result["succeeded"].(float64)))
+
+		err = db.QueryRow("SELECT is_deleted FROM user WHERE id = ?", user.Id).Scan(&deleted)
+		helpers.PanicError(err, "failed to query user")
+
+		assert.True(t, deleted)
+	})
+
+	t.Run("bad request bulk users", func(t *testing.T) {
+		response, _ := runTestBulk(router, url, accessToken, `{"action": "delete", "ids": [`+ids+`]}`)
+
+		assert.NotEqual(t, http.StatusOK, response.StatusCode)
+
+		response, _ = runTestBulk(router, url, adminAccessToken, `{"action": "suspend", "ids": [`+ids+`], "reason": "spam wave"}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+}
@@ -0,0 +1,165 @@
+package test
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func createCommentTestCommentModeration(t *testing.T, router http.Handler, accessToken string, postId int, content string) map[string]interface{} {
+	_, responseBody := requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/comments", accessToken, `{
+		"content": `+strconv.Quote(content)+`,
+		"post_id": `+strconv.Itoa(postId)+`
+	}`)
+
+	return responseBody.Data.(map[string]interface{})
+}
+
+func TestCommentModeration(t *testing.T) {
+	t.Setenv("SPAM_MIN_DOCUMENTS", "1")
+
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	category := createCategoryTestPost(db)
+	_, accessToken := createUserTestUser(db)
+	admin, adminAccessToken := createAdminTestAdmin(db)
+	post := createPostTestComment(db, admin.Id, category.Id)
+	postUrl := "http://localhost:8080/api/v1/posts/" + strconv.Itoa(post.Id)
+
+	var spamCommentId, hamCommentId int
+
+	t.Run("open policy approves comments", func(t *testing.T) {
+		comment := createCommentTestCommentModeration(t, router, accessToken, post.Id, "thanks for the great write up")
+
+		assert.Equal(t, "approved", comment["moderation_status"])
+	})
+
+	t.Run("hold links policy holds comments with links", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPut, postUrl+"/comment-policy", adminAccessToken, `{"comment_policy": "hold_links"}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "hold_links", responseBody.Data.(map[string]interface{})["comment_policy"])
+
+		comment := createCommentTestCommentModeration(t, router, accessToken, post.Id, "cheap pills at https://example.com/pills")
+		spamCommentId = int(comment["id"].(float64))
+
+		assert.Equal(t, "pending", comment["moderation_status"])
+
+		comment = createCommentTestCommentModeration(t, router, accessToken, post.Id, "no links here, just a nice read")
+
+		assert.Equal(t, "approved", comment["moderation_status"])
+	})
+
+	t.Run("hold all policy holds every comment but the author's", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodPut, postUrl+"/comment-policy", adminAccessToken, `{"comment_policy": "hold_all"}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		comment := createCommentTestCommentModeration(t, router, accessToken, post.Id, "a thoughtful reply about the article")
+		hamCommentId = int(comment["id"].(float64))
+
+		assert.Equal(t, "pending", comment["moderation_status"])
+
+		comment = createCommentTestCommentModeration(t, router, adminAccessToken, post.Id, "author reply")
+
+		assert.Equal(t, "approved", comment["moderation_status"])
+	})
+
+	t.Run("bad request update comment policy", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodPut, postUrl+"/comment-policy", accessToken, `{"comment_policy": "open"}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodPut, postUrl+"/comment-policy", adminAccessToken, `{"comment_policy": "whatever"}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("success get comment moderation queue", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodGet, "http://localhost:8080/api/v1/moderation/comments", adminAccessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 2, int(responseBody.Data.(map[string]interface{})["total"].(float64)))
+	})
+
+	t.Run("bad request get comment moderation queue", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodGet, "http://localhost:8080/api/v1/moderation/comments", accessToken, "")
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("success bulk moderate comments", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/moderation/comments/bulk", adminAccessToken, `{
+			"ids": [`+strconv.Itoa(spamCommentId)+`, 999999],
+			"status": "spam"
+		}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		result := responseBody.Data.(map[string]interface{})
+
+		assert.Equal(t, 1, int(result["succeeded"].(float64)))
+		assert.Equal(t, 1, int(result["failed"].(float64)))
+		assert.Equal(t, "", result["results"].([]interface{})[0].(map[string]interface{})["error"])
+		assert.NotEqual(t, "", result["results"].([]interface{})[1].(map[string]interface{})["error"])
+
+		response, _ = requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/moderation/comments/bulk", adminAccessToken, `{
+			"ids": [`+strconv.Itoa(hamCommentId)+`],
+			"status": "approved"
+		}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("bad request bulk moderate comments", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/moderation/comments/bulk", accessToken, `{
+			"ids": [`+strconv.Itoa(hamCommentId)+`],
+			"status": "hidden"
+		}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 1, int(responseBody.Data.(map[string]interface{})["failed"].(float64)))
+
+		response, _ = requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/moderation/comments/bulk", adminAccessToken, `{
+			"ids": [`+strconv.Itoa(hamCommentId)+`],
+			"status": "deleted"
+		}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("trained scorer marks similar comments as spam", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodPut, postUrl+"/comment-policy", adminAccessToken, `{"comment_policy": "open"}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		comment := createCommentTestCommentModeration(t, router, accessToken, post.Id, "cheap pills at https://example.com/pills")
+
+		assert.Equal(t, "spam", comment["moderation_status"])
+	})
+
+	t.Run("editing a trained comment untrains its old text", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodPut, "http://localhost:8080/api/v1/comments/"+strconv.Itoa(hamCommentId), accessToken, `{"content": "an edited reply"}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		var label sql.NullString
+		err := db.QueryRow("SELECT spam_label FROM comment WHERE id = ?", hamCommentId).Scan(&label)
+		helpers.PanicError(err, "failed to query comment spam label")
+
+		assert.False(t, label.Valid)
+
+		var hamCount int
+		err = db.QueryRow("SELECT ham_count FROM spam_token WHERE token = 'thoughtful'").Scan(&hamCount)
+		helpers.PanicError(err, "failed to query spam token")
+
+		assert.Equal(t, 0, hamCount)
+	})
+}
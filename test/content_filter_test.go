@@ -0,0 +1,258 @@
+package test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func createFilterRuleTestContentFilter(router http.Handler, accessToken, kind, pattern, action string) (*http.Response, helpers.ResponseJSON) {
+	ruleBody := strings.NewReader(`{
+		"kind": "` + kind + `",
+		"pattern": ` + strconv.Quote(pattern) + `,
+		"action": "` + action + `"
+	}`)
+
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/admin/filter-rules", ruleBody)
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("Authorization", "Bearer "+accessToken)
+
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+
+	body, err := io.ReadAll(response.Body)
+
+	var responseBody helpers.ResponseJSON
+
+	json.Unmarshal(body, &responseBody)
+
+	helpers.PanicError(err, "failed to read response body")
+
+	return response, responseBody
+}
+
+func TestContentFilter(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	category := createCategoryTestPost(db)
+	user, accessToken := createUserTestUser(db)
+	_, adminAccessToken := createAdminTestAdmin(db)
+	post := createPostTestComment(db, user.Id, category.Id)
+
+	var rejectRuleId int
+
+	t.Run("success create filter rules", func(t *testing.T) {
+		response, responseBody := createFilterRuleTestContentFilter(router, adminAccessToken, "word", "darn", "mask")
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+		assert.Equal(t, "CREATED", responseBody.Status)
+		assert.Equal(t, true, responseBody.Data.(map[string]interface{})["enabled"])
+
+		response, responseBody = createFilterRuleTestContentFilter(router, adminAccessToken, "regex", `casino\d+`, "reject")
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		rejectRuleId = int(responseBody.Data.(map[string]interface{})["id"].(float64))
+
+		response, _ = createFilterRuleTestContentFilter(router, adminAccessToken, "word", "crypto", "moderate")
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+	})
+
+	t.Run("bad request create filter rule", func(t *testing.T) {
+		response, _ := createFilterRuleTestContentFilter(router, accessToken, "word", "heck", "mask")
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		response, _ = createFilterRuleTestContentFilter(router, adminAccessToken, "regex", "([a-z", "reject")
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		response, _ = createFilterRuleTestContentFilter(router, adminAccessToken, "word", "darn", "reject")
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("success find all filter rules", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/admin/filter-rules?kind=word", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, float64(2), responseBody.Data.(map[string]interface{})["total"])
+	})
+
+	t.Run("masked words are replaced in comments", func(t *testing.T) {
+		commentBody := strings.NewReader(`{
+			"content": "well Darn, that is good",
+			"post_id": ` + strconv.Itoa(post.Id) + `,
+			"user_id": ` + strconv.Itoa(user.Id) + `
+		}`)
+
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/comments", commentBody)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, "well ****, that is good", responseBody.Data.(map[string]interface{})["content"])
+		assert.Equal(t, "approved", responseBody.Data.(map[string]interface{})["moderation_status"])
+	})
+
+	t.Run("flagged comments wait for moderation", func(t *testing.T) {
+		commentBody := strings.NewReader(`{
+			"content": "free crypto here",
+			"post_id": ` + strconv.Itoa(post.Id) + `,
+			"user_id": ` + strconv.Itoa(user.Id) + `
+		}`)
+
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/comments", commentBody)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, "pending", responseBody.Data.(map[string]interface{})["moderation_status"])
+	})
+
+	t.Run("rejected content is refused", func(t *testing.T) {
+		postBody := strings.NewReader(`{
+			"title": "big win",
+			"body": "visit casino777 today",
+			"published": true,
+			"category_id": ` + strconv.Itoa(category.Id) + `
+		}`)
+
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/posts", postBody)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ErrorResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, "content was rejected by the content filter", responseBody.Error)
+	})
+
+	t.Run("disabled rules are skipped", func(t *testing.T) {
+		ruleBody := strings.NewReader(`{
+			"kind": "regex",
+			"pattern": "casino\\d+",
+			"action": "reject",
+			"enabled": false
+		}`)
+
+		request := httptest.NewRequest(http.MethodPut, "http://localhost:8080/api/v1/admin/filter-rules/"+strconv.Itoa(rejectRuleId), ruleBody)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		postBody := strings.NewReader(`{
+			"title": "big win",
+			"body": "visit casino777 today",
+			"published": true,
+			"category_id": ` + strconv.Itoa(category.Id) + `
+		}`)
+
+		request = httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/posts", postBody)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder = httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response = recorder.Result()
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+	})
+
+	t.Run("success delete filter rule", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodDelete, "http://localhost:8080/api/v1/admin/filter-rules/"+strconv.Itoa(rejectRuleId), nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+}
@@ -0,0 +1,259 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/erasure"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/post"
+	"github.com/hutamatr/GoBlogify/role"
+	"github.com/hutamatr/GoBlogify/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestErasure(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	user, accessToken := createUserTestUser(db)
+	admin, adminAccessToken := createAdminTestAdmin(db)
+	category := createCategoryTestPost(db)
+	post := createPostTestComment(db, user.Id, category.Id)
+
+	_, err := db.Exec("INSERT INTO audit_log(actor_id, action, target_type, target_id, before_data, ip, user_agent) VALUES (?, 'user.role_update', 'user', ?, JSON_OBJECT('email', ?), '10.0.0.1', 'test')", admin.Id, user.Id, user.Email)
+	helpers.PanicError(err, "failed to insert audit log")
+	_, err = db.Exec("INSERT INTO audit_log(actor_id, action, target_type, target_id, ip, user_agent) VALUES (?, 'post.delete', 'post', ?, '10.0.0.2', 'test')", user.Id, post.Id)
+	helpers.PanicError(err, "failed to insert audit log")
+	_, err = db.Exec("INSERT INTO user_suspension(user_id, admin_id, reason) VALUES (?, ?, ?)", user.Id, admin.Id, "spam from "+user.Email)
+	helpers.PanicError(err, "failed to insert suspension")
+
+	t.Run("success request erasure", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/users/"+strconv.Itoa(user.Id)+"/erasure", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusAccepted, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, http.StatusAccepted, responseBody.Code)
+		assert.Equal(t, "ACCEPTED", responseBody.Status)
+	})
+
+	t.Run("success complete erasure", func(t *testing.T) {
+		var status string
+
+		for i := 0; i < 50 && status != "completed"; i++ {
+			request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/users/"+strconv.Itoa(user.Id)+"/erasure", nil)
+			request.Header.Add("Content-Type", "application/json")
+			request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+			recorder := httptest.NewRecorder()
+
+			router.ServeHTTP(recorder, request)
+
+			body, err := io.ReadAll(recorder.Result().Body)
+
+			var responseBody helpers.ResponseJSON
+
+			json.Unmarshal(body, &responseBody)
+
+			helpers.PanicError(err, "failed to read response body")
+
+			status = responseBody.Data.(map[string]interface{})["status"].(string)
+			if status != "completed" {
+				time.Sleep(100 * time.Millisecond)
+			}
+		}
+
+		assert.Equal(t, "completed", status)
+
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/post/"+strconv.Itoa(post.Id), nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		body, err := io.ReadAll(recorder.Result().Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, http.StatusOK, responseBody.Code)
+		assert.Equal(t, "deleted-user", responseBody.Data.(map[string]interface{})["user"].(map[string]interface{})["username"])
+	})
+
+	t.Run("erased user has no personal data", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/users/"+strconv.Itoa(user.Id), nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		body, err := io.ReadAll(recorder.Result().Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.NotEqual(t, user.Email, responseBody.Data.(map[string]interface{})["email"])
+	})
+
+	t.Run("erased user is redacted from kept records", func(t *testing.T) {
+		var snapshots int
+		err := db.QueryRow("SELECT COUNT(*) FROM audit_log WHERE target_type = 'user' AND target_id = ? AND before_data IS NOT NULL", user.Id).Scan(&snapshots)
+		helpers.PanicError(err, "failed to query audit log")
+
+		assert.Equal(t, 0, snapshots)
+
+		var ip string
+		err = db.QueryRow("SELECT ip FROM audit_log WHERE actor_id = ? AND action = 'post.delete'", user.Id).Scan(&ip)
+		helpers.PanicError(err, "failed to query audit log")
+
+		assert.Equal(t, "", ip)
+
+		var reason string
+		err = db.QueryRow("SELECT reason FROM user_suspension WHERE user_id = ?", user.Id).Scan(&reason)
+		helpers.PanicError(err, "failed to query suspension")
+
+		assert.Equal(t, erasure.Redacted, reason)
+	})
+
+	t.Run("failed request erasure for other user", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/users/"+strconv.Itoa(user.Id+100)+"/erasure", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+	})
+}
+
+func waitTestErasure(router http.Handler, userId int, accessToken string) string {
+	var status string
+
+	for i := 0; i < 50 && status != "completed" && status != "failed"; i++ {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/users/"+strconv.Itoa(userId)+"/erasure", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		body, err := io.ReadAll(recorder.Result().Body)
+		helpers.PanicError(err, "failed to read response body")
+
+		var responseBody helpers.ResponseJSON
+
+		helpers.PanicError(json.Unmarshal(body, &responseBody), "failed to unmarshal response body")
+
+		status = responseBody.Data.(map[string]interface{})["status"].(string)
+		if status != "completed" {
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+
+	return status
+}
+
+func TestErasureSharedSlug(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	category := createCategoryTestPost(db)
+	_, adminAccessToken := createAdminTestAdmin(db)
+
+	userService := user.NewUserService(user.NewUserRepository(), role.NewRoleRepository(), audit.NewAuditRepository(), db, helpers.Validate)
+
+	var postIds []int
+
+	for _, username := range []string{"erasureFirst", "erasureSecond"} {
+		erasedUser, accessToken, _ := userService.SignUp(context.Background(), user.UserCreateRequest{Username: username, Email: username + "@example.com", Password: "Password123!", Confirm_Password: "Password123!"})
+
+		tx, err := db.Begin()
+		helpers.PanicError(err, "failed to begin transaction")
+
+		createdPost := post.NewPostRepository().Save(context.Background(), tx, post.Post{Title: "Hello world", Slug: "hello-world", Body: "Body", Published: true, User_Id: erasedUser.Id, Category_Id: category.Id})
+
+		_, err = tx.Exec("INSERT INTO post_slug_history(post_id, user_id, slug) VALUES (?, ?, 'hello-old')", createdPost.Id, erasedUser.Id)
+		helpers.PanicError(err, "failed to insert post slug history")
+
+		helpers.PanicError(tx.Commit(), "failed to commit transaction")
+
+		postIds = append(postIds, createdPost.Id)
+
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/users/"+strconv.Itoa(erasedUser.Id)+"/erasure", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusAccepted, recorder.Result().StatusCode)
+
+		t.Run("erasure of "+username+" completes", func(t *testing.T) {
+			assert.Equal(t, "completed", waitTestErasure(router, erasedUser.Id, adminAccessToken))
+		})
+	}
+
+	t.Run("both posts belong to the tombstone under distinct slugs", func(t *testing.T) {
+		slugs := map[string]bool{}
+
+		for _, postId := range postIds {
+			var username, slug string
+			err := db.QueryRow("SELECT u.username, p.slug FROM post p JOIN user u ON u.id = p.user_id WHERE p.id = ?", postId).Scan(&username, &slug)
+			helpers.PanicError(err, "failed to query post")
+
+			assert.Equal(t, erasure.TombstoneUsername, username)
+			assert.Equal(t, "hello-world-"+strconv.Itoa(postId), slug)
+
+			slugs[slug] = true
+		}
+
+		assert.Len(t, slugs, 2)
+
+		var history int
+		err := db.QueryRow("SELECT COUNT(*) FROM post_slug_history WHERE slug = 'hello-old'").Scan(&history)
+		helpers.PanicError(err, "failed to query post slug history")
+
+		assert.Equal(t, 0, history)
+	})
+}
@@ -0,0 +1,170 @@
+package test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hutamatr/GoBlogify/export"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestExport(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	user, accessToken := createUserTestUser(db)
+	category := createCategoryTestPost(db)
+	createPostTestComment(db, user.Id, category.Id)
+
+	var exportId int
+	var downloadUrl string
+
+	t.Run("success request export", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/users/"+strconv.Itoa(user.Id)+"/exports", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusAccepted, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, http.StatusAccepted, responseBody.Code)
+		assert.Equal(t, "ACCEPTED", responseBody.Status)
+
+		exportId = int(responseBody.Data.(map[string]interface{})["id"].(float64))
+	})
+
+	t.Run("success download export", func(t *testing.T) {
+		for i := 0; i < 50 && downloadUrl == ""; i++ {
+			request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/users/"+strconv.Itoa(user.Id)+"/exports/"+strconv.Itoa(exportId), nil)
+			request.Header.Add("Content-Type", "application/json")
+			request.Header.Add("Authorization", "Bearer "+accessToken)
+
+			recorder := httptest.NewRecorder()
+
+			router.ServeHTTP(recorder, request)
+
+			body, err := io.ReadAll(recorder.Result().Body)
+
+			var responseBody helpers.ResponseJSON
+
+			json.Unmarshal(body, &responseBody)
+
+			helpers.PanicError(err, "failed to read response body")
+
+			if url, ok := responseBody.Data.(map[string]interface{})["download_url"].(string); ok {
+				downloadUrl = url
+			} else {
+				time.Sleep(100 * time.Millisecond)
+			}
+		}
+
+		assert.NotEmpty(t, downloadUrl)
+
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080"+downloadUrl, nil)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+		helpers.PanicError(err, "failed to read response body")
+
+		archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		helpers.PanicError(err, "failed to read export archive")
+
+		var names []string
+		for _, file := range archive.File {
+			names = append(names, file.Name)
+		}
+
+		assert.Contains(t, names, "profile.json")
+		assert.Contains(t, names, "posts.json")
+		assert.Contains(t, names, "audit_log.json")
+		assert.Contains(t, names, "README.md")
+	})
+
+	t.Run("success remove expired export files", func(t *testing.T) {
+		var filePath string
+		err := db.QueryRow("SELECT file_path FROM data_export WHERE id = ?", exportId).Scan(&filePath)
+		helpers.PanicError(err, "failed to query export file path")
+
+		_, err = db.Exec("UPDATE data_export SET expires_at = NOW() - INTERVAL 1 HOUR WHERE id = ?", exportId)
+		helpers.PanicError(err, "failed to expire export")
+
+		cleaner := export.NewExportCleaner(export.NewExportRepository(), db)
+
+		assert.Equal(t, 1, cleaner.RunDue(context.Background()))
+
+		_, err = os.Stat(filePath)
+		assert.True(t, os.IsNotExist(err))
+
+		var status string
+		err = db.QueryRow("SELECT status FROM data_export WHERE id = ?", exportId).Scan(&status)
+		helpers.PanicError(err, "failed to query export status")
+
+		assert.Equal(t, export.StatusExpired, status)
+
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080"+downloadUrl, nil)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Result().StatusCode)
+	})
+
+	t.Run("failed request export for other user", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/users/"+strconv.Itoa(user.Id+1)+"/exports", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("failed download with invalid token", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/exports/download/invalid", nil)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/hutamatr/GoBlogify/audit"
 	"github.com/hutamatr/GoBlogify/follow"
 	"github.com/hutamatr/GoBlogify/helpers"
 	"github.com/hutamatr/GoBlogify/role"
@@ -45,7 +46,7 @@ func TestFollowUser(t *testing.T) {
 
 		userRepository := user.NewUserRepository()
 		roleRepository := role.NewRoleRepository()
-		userService := user.NewUserService(userRepository, roleRepository, db, helpers.Validate)
+		userService := user.NewUserService(userRepository, roleRepository, audit.NewAuditRepository(), db, helpers.Validate)
 		newUser2, _, _ := userService.SignUp(ctx, user.UserCreateRequest{Username: "userTest2", Email: "testing2@example.com", Password: "Password123!", Confirm_Password: "Password123!"})
 
 		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/users/"+strconv.Itoa(newUser1.Id)+"/follow/"+strconv.Itoa(newUser2.Id), nil)
@@ -111,7 +112,7 @@ func TestUnfollowUser(t *testing.T) {
 
 		userRepository := user.NewUserRepository()
 		roleRepository := role.NewRoleRepository()
-		userService := user.NewUserService(userRepository, roleRepository, db, helpers.Validate)
+		userService := user.NewUserService(userRepository, roleRepository, audit.NewAuditRepository(), db, helpers.Validate)
 		newUser2, _, _ := userService.SignUp(ctx, user.UserCreateRequest{Username: "userTest2", Email: "testing2@example.com", Password: "Password123!", Confirm_Password: "Password123!"})
 
 		followRepository := follow.NewFollowRepository()
@@ -183,7 +184,7 @@ func TestFindAllFollowerByUser(t *testing.T) {
 
 		userRepository := user.NewUserRepository()
 		roleRepository := role.NewRoleRepository()
-		userService := user.NewUserService(userRepository, roleRepository, db, helpers.Validate)
+		userService := user.NewUserService(userRepository, roleRepository, audit.NewAuditRepository(), db, helpers.Validate)
 		newUser2, _, _ := userService.SignUp(ctx, user.UserCreateRequest{Username: "userTest2", Email: "testing2@example.com", Password: "Password123!", Confirm_Password: "Password123!"})
 
 		followRepository := follow.NewFollowRepository()
@@ -256,7 +257,7 @@ func TestFindAllFollowedByUser(t *testing.T) {
 
 		userRepository := user.NewUserRepository()
 		roleRepository := role.NewRoleRepository()
-		userService := user.NewUserService(userRepository, roleRepository, db, helpers.Validate)
+		userService := user.NewUserService(userRepository, roleRepository, audit.NewAuditRepository(), db, helpers.Validate)
 		newUser2, _, _ := userService.SignUp(ctx, user.UserCreateRequest{Username: "userTest2", Email: "testing2@example.com", Password: "Password123!", Confirm_Password: "Password123!"})
 
 		followRepository := follow.NewFollowRepository()
@@ -295,7 +296,7 @@ func TestFindAllFollowedByUser(t *testing.T) {
 
 		userRepository := user.NewUserRepository()
 		roleRepository := role.NewRoleRepository()
-		userService := user.NewUserService(userRepository, roleRepository, db, helpers.Validate)
+		userService := user.NewUserService(userRepository, roleRepository, audit.NewAuditRepository(), db, helpers.Validate)
 		newUser3, _, _ := userService.SignUp(ctx, user.UserCreateRequest{Username: "userTest3", Email: "testing3@example.com", Password: "Password123!", Confirm_Password: "Password123!"})
 
 		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/users/"+strconv.Itoa(newUser3.Id)+"/following", nil)
@@ -0,0 +1,111 @@
+package test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImpersonation(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	user, accessToken := createUserTestUser(db)
+	admin, adminAccessToken := createAdminTestAdmin(db)
+
+	url := "http://localhost:8080/api/v1/admin/impersonations"
+	userUrl := "http://localhost:8080/api/v1/users/" + strconv.Itoa(user.Id)
+
+	var impersonationId int
+	var impersonationToken string
+
+	t.Run("success start impersonation", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPost, url, adminAccessToken, `{
+			"user_id": `+strconv.Itoa(user.Id)+`,
+			"reason": "ticket 42, user cannot see their drafts"
+		}`)
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+		assert.Equal(t, "CREATED", responseBody.Status)
+
+		data := responseBody.Data.(map[string]interface{})
+		impersonation := data["impersonation"].(map[string]interface{})
+
+		assert.Equal(t, "impersonation", data["token_type"])
+		assert.Equal(t, true, impersonation["active"])
+		assert.Equal(t, admin.Id, int(impersonation["admin_id"].(float64)))
+
+		impersonationId = int(impersonation["id"].(float64))
+		impersonationToken = data["access_token"].(string)
+	})
+
+	t.Run("bad request start impersonation", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodPost, url, accessToken, `{"user_id": `+strconv.Itoa(admin.Id)+`, "reason": "curious"}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodPost, url, adminAccessToken, `{"user_id": `+strconv.Itoa(admin.Id)+`, "reason": "self"}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodPost, url, adminAccessToken, `{"user_id": `+strconv.Itoa(user.Id)+`}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("success request as impersonated user", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodGet, userUrl, impersonationToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, strconv.Itoa(admin.Id), response.Header.Get("X-Impersonated-By"))
+		assert.Equal(t, "userTest", responseBody.Data.(map[string]interface{})["username"])
+	})
+
+	t.Run("forbidden destructive actions while impersonating", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodDelete, userUrl, impersonationToken, "")
+
+		assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodPost, userUrl+"/deactivate", impersonationToken, "")
+
+		assert.Equal(t, http.StatusForbidden, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodPost, url, impersonationToken, `{"user_id": `+strconv.Itoa(user.Id)+`, "reason": "nested"}`)
+
+		assert.Equal(t, http.StatusForbidden, response.StatusCode)
+	})
+
+	t.Run("impersonated requests are audit logged", func(t *testing.T) {
+		var countRequests int
+		err := db.QueryRow("SELECT COUNT(*) FROM audit_log WHERE action = 'impersonation.request' AND actor_id = ? AND target_id = ?", admin.Id, user.Id).Scan(&countRequests)
+		helpers.PanicError(err, "failed to count audit logs")
+
+		assert.Equal(t, 4, countRequests)
+	})
+
+	t.Run("success find all impersonations", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodGet, url, adminAccessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 1, int(responseBody.Data.(map[string]interface{})["total"].(float64)))
+	})
+
+	t.Run("success end impersonation", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodDelete, url+"/"+strconv.Itoa(impersonationId), impersonationToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodGet, userUrl, impersonationToken, "")
+
+		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodDelete, url+"/"+strconv.Itoa(impersonationId), adminAccessToken, "")
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+}
@@ -0,0 +1,163 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/role"
+	"github.com/hutamatr/GoBlogify/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostDraft(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	category := createCategoryTestPost(db)
+	author, accessToken := createUserTestUser(db)
+	_, adminAccessToken := createAdminTestAdmin(db)
+
+	userService := user.NewUserService(user.NewUserRepository(), role.NewRoleRepository(), audit.NewAuditRepository(), db, helpers.Validate)
+	other, otherAccessToken, _ := userService.SignUp(context.Background(), user.UserCreateRequest{Username: "draftOther", Email: "draft-other@example.com", Password: "Password123!", Confirm_Password: "Password123!"})
+
+	createPost := func(title string, published bool) int {
+		response, responseBody := requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/posts", accessToken, `{
+			"title": `+strconv.Quote(title)+`,
+			"body": "body",
+			"published": `+strconv.FormatBool(published)+`,
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		return int(responseBody.Data.(map[string]interface{})["id"].(float64))
+	}
+
+	published := createPost("Published", true)
+	draft := createPost("Draft", false)
+
+	postUrl := "http://localhost:8080/api/v1/post/"
+	userPostsUrl := "http://localhost:8080/api/v1/posts/" + strconv.Itoa(author.Id)
+
+	t.Run("success author and admin see the draft", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodGet, postUrl+strconv.Itoa(draft), accessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, false, responseBody.Data.(map[string]interface{})["published"])
+
+		response, _ = requestTest(t, router, http.MethodGet, postUrl+strconv.Itoa(draft), adminAccessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("not found draft for other users", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodGet, postUrl+strconv.Itoa(draft), otherAccessToken, "")
+
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodGet, postUrl+strconv.Itoa(published), otherAccessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("success lists hide drafts from other users", func(t *testing.T) {
+		_, responseBody := requestTest(t, router, http.MethodGet, userPostsUrl, accessToken, "")
+
+		assert.Equal(t, 2, int(responseBody.Data.(map[string]interface{})["total"].(float64)))
+
+		_, responseBody = requestTest(t, router, http.MethodGet, userPostsUrl, adminAccessToken, "")
+
+		assert.Equal(t, 2, int(responseBody.Data.(map[string]interface{})["total"].(float64)))
+
+		_, responseBody = requestTest(t, router, http.MethodGet, userPostsUrl, otherAccessToken, "")
+
+		data := responseBody.Data.(map[string]interface{})
+
+		assert.Equal(t, 1, int(data["total"].(float64)))
+		assert.Equal(t, published, int(data["posts"].([]interface{})[0].(map[string]interface{})["id"].(float64)))
+	})
+
+	t.Run("success followed feed hides drafts", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/users/"+strconv.Itoa(other.Id)+"/follow/"+strconv.Itoa(author.Id), otherAccessToken, "")
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		_, responseBody := requestTest(t, router, http.MethodGet, "http://localhost:8080/api/v1/posts/"+strconv.Itoa(other.Id)+"/following", otherAccessToken, "")
+
+		posts := responseBody.Data.(map[string]interface{})["posts"].([]interface{})
+
+		assert.Equal(t, 1, len(posts))
+		assert.Equal(t, published, int(posts[0].(map[string]interface{})["id"].(float64)))
+	})
+
+	t.Run("success my drafts", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodGet, userPostsUrl+"/drafts", accessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		data := responseBody.Data.(map[string]interface{})
+
+		assert.Equal(t, 1, int(data["total"].(float64)))
+		assert.Equal(t, draft, int(data["posts"].([]interface{})[0].(map[string]interface{})["id"].(float64)))
+
+		response, _ = requestTest(t, router, http.MethodGet, userPostsUrl+"/drafts", otherAccessToken, "")
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("bad request other users cannot update a draft", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPut, "http://localhost:8080/api/v1/posts/"+strconv.Itoa(draft), otherAccessToken, `{
+			"title": "Taken over",
+			"body": "body",
+			"user_id": `+strconv.Itoa(other.Id)+`,
+			"published": true,
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+		assert.Nil(t, responseBody.Data)
+	})
+
+	t.Run("success editing a draft keeps it a draft", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPut, "http://localhost:8080/api/v1/posts/"+strconv.Itoa(draft), accessToken, `{
+			"title": "Draft",
+			"body": "edited body",
+			"published": false,
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		data := responseBody.Data.(map[string]interface{})
+
+		assert.Equal(t, false, data["published"])
+		assert.Equal(t, "edited body", data["body"])
+		assert.Equal(t, author.Id, int(data["user"].(map[string]interface{})["id"].(float64)))
+	})
+
+	t.Run("success publishing a draft makes it visible", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodPut, "http://localhost:8080/api/v1/posts/"+strconv.Itoa(draft), accessToken, `{
+			"title": "Draft",
+			"body": "body",
+			"user_id": `+strconv.Itoa(author.Id)+`,
+			"published": true,
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodGet, postUrl+strconv.Itoa(draft), otherAccessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		_, responseBody := requestTest(t, router, http.MethodGet, userPostsUrl+"/drafts", accessToken, "")
+
+		assert.Equal(t, 0, int(responseBody.Data.(map[string]interface{})["total"].(float64)))
+	})
+}
@@ -0,0 +1,130 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/category"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/role"
+	"github.com/hutamatr/GoBlogify/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func feedIdsTestPostFeed(responseBody helpers.ResponseJSON) []int {
+	var ids []int
+
+	for _, post := range responseBody.Data.(map[string]interface{})["posts"].([]interface{}) {
+		ids = append(ids, int(post.(map[string]interface{})["id"].(float64)))
+	}
+
+	return ids
+}
+
+func TestPostFeed(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	firstCategory := createCategoryTestPost(db)
+	author, accessToken := createUserTestUser(db)
+
+	tx, err := db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	secondCategory := category.NewCategoryRepository().Save(context.Background(), tx, category.Category{Name: "feed-category"})
+	helpers.PanicError(tx.Commit(), "failed to commit transaction")
+
+	userService := user.NewUserService(user.NewUserRepository(), role.NewRoleRepository(), audit.NewAuditRepository(), db, helpers.Validate)
+	other, otherAccessToken, _ := userService.SignUp(context.Background(), user.UserCreateRequest{Username: "feedOther", Email: "feed-other@example.com", Password: "Password123!", Confirm_Password: "Password123!"})
+
+	createPost := func(accessToken, title string, categoryId int, publishedAt string) int {
+		response, responseBody := requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/posts", accessToken, `{
+			"title": `+strconv.Quote(title)+`,
+			"body": "body",
+			"published": true,
+			"category_id": `+strconv.Itoa(categoryId)+`
+		}`)
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		postId := int(responseBody.Data.(map[string]interface{})["id"].(float64))
+
+		_, err := db.Exec("UPDATE post SET published_at = ? WHERE id = ?", publishedAt, postId)
+		helpers.PanicError(err, "failed to update published_at")
+
+		return postId
+	}
+
+	oldest := createPost(accessToken, "Oldest", firstCategory.Id, "2026-01-10 10:00:00")
+	middle := createPost(otherAccessToken, "Middle", secondCategory.Id, "2026-02-10 10:00:00")
+	newest := createPost(accessToken, "Newest", firstCategory.Id, "2026-03-10 10:00:00")
+	draft := createPost(accessToken, "Draft", firstCategory.Id, "2026-03-11 10:00:00")
+	deleted := createPost(accessToken, "Deleted", firstCategory.Id, "2026-03-12 10:00:00")
+
+	_, err = db.Exec("UPDATE post SET is_published = false WHERE id = ?", draft)
+	helpers.PanicError(err, "failed to unpublish post")
+	_, err = db.Exec("UPDATE post SET is_deleted = true, deleted_at = NOW() WHERE id = ?", deleted)
+	helpers.PanicError(err, "failed to delete post")
+
+	for i := 0; i < 2; i++ {
+		_, err = db.Exec("INSERT INTO comment(user_id, post_id, content) VALUES(?, ?, ?)", author.Id, middle, "comment")
+		helpers.PanicError(err, "failed to insert comment")
+	}
+	_, err = db.Exec("INSERT INTO comment(user_id, post_id, content) VALUES(?, ?, ?)", other.Id, oldest, "comment")
+	helpers.PanicError(err, "failed to insert comment")
+
+	url := "http://localhost:8080/api/v1/posts"
+
+	t.Run("success newest first with only published posts", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodGet, url, accessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, []int{newest, middle, oldest}, feedIdsTestPostFeed(responseBody))
+		assert.Equal(t, 3, int(responseBody.Data.(map[string]interface{})["total"].(float64)))
+	})
+
+	t.Run("success sort oldest and most commented", func(t *testing.T) {
+		_, responseBody := requestTest(t, router, http.MethodGet, url+"?sort=oldest", accessToken, "")
+
+		assert.Equal(t, []int{oldest, middle, newest}, feedIdsTestPostFeed(responseBody))
+
+		_, responseBody = requestTest(t, router, http.MethodGet, url+"?sort=most_commented", accessToken, "")
+
+		assert.Equal(t, []int{middle, oldest, newest}, feedIdsTestPostFeed(responseBody))
+	})
+
+	t.Run("success filter by category author and date", func(t *testing.T) {
+		_, responseBody := requestTest(t, router, http.MethodGet, url+"?category_id="+strconv.Itoa(secondCategory.Id), accessToken, "")
+
+		assert.Equal(t, []int{middle}, feedIdsTestPostFeed(responseBody))
+
+		_, responseBody = requestTest(t, router, http.MethodGet, url+"?author_id="+strconv.Itoa(author.Id), accessToken, "")
+
+		assert.Equal(t, []int{newest, oldest}, feedIdsTestPostFeed(responseBody))
+
+		_, responseBody = requestTest(t, router, http.MethodGet, url+"?from=2026-02-01&to=2026-03-10", accessToken, "")
+
+		assert.Equal(t, []int{newest, middle}, feedIdsTestPostFeed(responseBody))
+	})
+
+	t.Run("success paginate", func(t *testing.T) {
+		_, responseBody := requestTest(t, router, http.MethodGet, url+"?limit=2&offset=2", accessToken, "")
+
+		assert.Equal(t, []int{oldest}, feedIdsTestPostFeed(responseBody))
+		assert.Equal(t, 3, int(responseBody.Data.(map[string]interface{})["total"].(float64)))
+	})
+
+	t.Run("bad request feed", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodGet, url+"?sort=random", accessToken, "")
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodGet, url+"?category_id=abc", accessToken, "")
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+}
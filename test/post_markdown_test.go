@@ -0,0 +1,99 @@
+package test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostMarkdown(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	category := createCategoryTestPost(db)
+	user, accessToken := createUserTestUser(db)
+
+	url := "http://localhost:8080/api/v1/posts"
+	markdownBody := "# Getting Started\n\n## Install\n\n| a | b |\n|---|---|\n| 1 | 2 |\n\n```go\nfmt.Println(\"hi\")\n```\n\n<script>alert(1)</script>\n"
+
+	var postId int
+
+	t.Run("success create markdown post", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPost, url, accessToken, `{
+			"title": "Markdown",
+			"body": `+strconv.Quote(markdownBody)+`,
+			"body_format": "markdown",
+			"published": true,
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		post := responseBody.Data.(map[string]interface{})
+		postId = int(post["id"].(float64))
+		bodyHtml := post["body_html"].(string)
+
+		assert.Equal(t, "markdown", post["body_format"])
+		assert.Equal(t, markdownBody, post["body"])
+		assert.Contains(t, bodyHtml, `<h1 id="getting-started">`)
+		assert.Contains(t, bodyHtml, "<table>")
+		assert.Contains(t, bodyHtml, `class="chroma"`)
+		assert.NotContains(t, bodyHtml, "<script>")
+
+		toc := post["toc"].([]interface{})
+
+		assert.Len(t, toc, 2)
+		assert.Equal(t, "install", toc[1].(map[string]interface{})["id"])
+	})
+
+	t.Run("success update refreshes rendered html", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPut, url+"/"+strconv.Itoa(postId), accessToken, `{
+			"title": "Markdown",
+			"body": "## Changed",
+			"user_id": `+strconv.Itoa(user.Id)+`,
+			"published": true,
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		post := responseBody.Data.(map[string]interface{})
+
+		assert.Equal(t, "markdown", post["body_format"])
+		assert.Contains(t, post["body_html"], `<h2 id="changed">Changed</h2>`)
+		assert.NotContains(t, post["body_html"], "getting-started")
+	})
+
+	t.Run("plain is the default format", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPost, url, accessToken, `{
+			"title": "Plain",
+			"body": "# not a heading <b>",
+			"published": true,
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		post := responseBody.Data.(map[string]interface{})
+
+		assert.Equal(t, "plain", post["body_format"])
+		assert.Contains(t, post["body_html"], "<p># not a heading &lt;b&gt;</p>")
+		assert.Nil(t, post["toc"])
+	})
+
+	t.Run("bad request unknown body format", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodPost, url, accessToken, `{
+			"title": "Unknown",
+			"body": "body",
+			"body_format": "rst",
+			"published": true,
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+}
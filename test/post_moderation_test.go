@@ -0,0 +1,217 @@
+package test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func createPostTestModeration(t *testing.T, router http.Handler, accessToken string, categoryId int, title string) map[string]interface{} {
+	postBody := strings.NewReader(`{
+		"title": "` + title + `",
+		"body": "body",
+		"published": true,
+		"category_id": ` + strconv.Itoa(categoryId) + `
+	}`)
+
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/posts", postBody)
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("Authorization", "Bearer "+accessToken)
+
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+	body, err := io.ReadAll(response.Body)
+
+	var responseBody helpers.ResponseJSON
+
+	json.Unmarshal(body, &responseBody)
+
+	helpers.PanicError(err, "failed to read response body")
+
+	return responseBody.Data.(map[string]interface{})
+}
+
+func moderatePostTestModeration(router http.Handler, accessToken string, postId int, decision string) (*http.Response, helpers.ResponseJSON) {
+	moderationBody := strings.NewReader(`{
+		"note": "reviewed"
+	}`)
+
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/moderation/posts/"+strconv.Itoa(postId)+"/"+decision, moderationBody)
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("Authorization", "Bearer "+accessToken)
+
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+
+	body, err := io.ReadAll(response.Body)
+
+	var responseBody helpers.ResponseJSON
+
+	json.Unmarshal(body, &responseBody)
+
+	helpers.PanicError(err, "failed to read response body")
+
+	return response, responseBody
+}
+
+func TestPostModeration(t *testing.T) {
+	t.Setenv("TRUST_PROMOTION_APPROVED_POSTS", "2")
+	t.Setenv("TRUST_PROMOTION_ACCOUNT_DAYS", "30")
+
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	category := createCategoryTestPost(db)
+	user, accessToken := createUserTestUser(db)
+	_, adminAccessToken := createAdminTestAdmin(db)
+
+	var pendingPostId int
+
+	t.Run("post by new user waits for review", func(t *testing.T) {
+		post := createPostTestModeration(t, router, accessToken, category.Id, "first-post")
+
+		assert.Equal(t, "pending", post["moderation_status"])
+
+		pendingPostId = int(post["id"].(float64))
+
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/posts/"+strconv.Itoa(user.Id), nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+
+	t.Run("author can still see the pending post", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/post/"+strconv.Itoa(pendingPostId), nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("non moderator cannot see the queue", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/moderation/posts", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("moderator sees pending posts", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/moderation/posts", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, float64(1), responseBody.Data.(map[string]interface{})["total"])
+	})
+
+	t.Run("success reject post", func(t *testing.T) {
+		response, responseBody := moderatePostTestModeration(router, adminAccessToken, pendingPostId, "reject")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "rejected", responseBody.Data.(map[string]interface{})["moderation_status"])
+		assert.Equal(t, "reviewed", responseBody.Data.(map[string]interface{})["moderation_note"])
+
+		response, _ = moderatePostTestModeration(router, adminAccessToken, pendingPostId, "approve")
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("draft published by rescheduling still waits for review", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/posts", accessToken, `{
+			"title": "scheduled-draft",
+			"body": "body",
+			"published": false,
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+		assert.Equal(t, "pending", responseBody.Data.(map[string]interface{})["moderation_status"])
+
+		draftId := int(responseBody.Data.(map[string]interface{})["id"].(float64))
+		anHourAgo := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+		response, responseBody = requestTest(t, router, http.MethodPut, "http://localhost:8080/api/v1/posts/"+strconv.Itoa(draftId)+"/schedule", accessToken, `{
+			"publish_at": "`+anHourAgo+`"
+		}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, true, responseBody.Data.(map[string]interface{})["published"])
+		assert.Equal(t, "pending", responseBody.Data.(map[string]interface{})["moderation_status"])
+
+		response, responseBody = moderatePostTestModeration(router, adminAccessToken, draftId, "reject")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "rejected", responseBody.Data.(map[string]interface{})["moderation_status"])
+	})
+
+	t.Run("author is promoted after enough approved posts", func(t *testing.T) {
+		for _, title := range []string{"second-post", "third-post"} {
+			post := createPostTestModeration(t, router, accessToken, category.Id, title)
+
+			assert.Equal(t, "pending", post["moderation_status"])
+
+			response, responseBody := moderatePostTestModeration(router, adminAccessToken, int(post["id"].(float64)), "approve")
+
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+			assert.Equal(t, "approved", responseBody.Data.(map[string]interface{})["moderation_status"])
+		}
+
+		post := createPostTestModeration(t, router, accessToken, category.Id, "fourth-post")
+
+		assert.Equal(t, "approved", post["moderation_status"])
+	})
+}
@@ -0,0 +1,158 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/role"
+	"github.com/hutamatr/GoBlogify/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostReaction(t *testing.T) {
+	t.Setenv("TRUST_PROMOTION_ACCOUNT_DAYS", "0")
+
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	category := createCategoryTestPost(db)
+	author, accessToken := createUserTestUser(db)
+
+	userService := user.NewUserService(user.NewUserRepository(), role.NewRoleRepository(), audit.NewAuditRepository(), db, helpers.Validate)
+	reader, readerAccessToken, _ := userService.SignUp(context.Background(), user.UserCreateRequest{Username: "reactionReader", Email: "reaction-reader@example.com", Password: "Password123!", Confirm_Password: "Password123!"})
+	_, otherAccessToken, _ := userService.SignUp(context.Background(), user.UserCreateRequest{Username: "reactionOther", Email: "reaction-other@example.com", Password: "Password123!", Confirm_Password: "Password123!"})
+
+	createPost := func(title, visibility string) int {
+		response, responseBody := requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/posts", accessToken, `{
+			"title": `+strconv.Quote(title)+`,
+			"body": "body",
+			"published": true,
+			"visibility": "`+visibility+`",
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		return int(responseBody.Data.(map[string]interface{})["id"].(float64))
+	}
+
+	postId := createPost("Reacted", "public")
+	otherPostId := createPost("Not reacted", "public")
+	privatePostId := createPost("Private", "private")
+
+	reactionUrl := "http://localhost:8080/api/v1/posts/" + strconv.Itoa(postId) + "/reaction"
+	reactionsUrl := "http://localhost:8080/api/v1/post/" + strconv.Itoa(postId) + "/reactions"
+
+	t.Run("success add reaction", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPut, reactionUrl, readerAccessToken, `{"reaction": "👍"}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		data := responseBody.Data.(map[string]interface{})
+
+		assert.Equal(t, map[string]interface{}{"👍": float64(1)}, data["reactions"])
+		assert.Equal(t, "👍", data["my_reaction"])
+
+		response, _ = requestTest(t, router, http.MethodPut, reactionUrl, otherAccessToken, `{"reaction": "👍"}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("success change reaction keeps one per user", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPut, reactionUrl, readerAccessToken, `{"reaction": "🎉"}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		data := responseBody.Data.(map[string]interface{})
+
+		assert.Equal(t, map[string]interface{}{"👍": float64(1), "🎉": float64(1)}, data["reactions"])
+		assert.Equal(t, "🎉", data["my_reaction"])
+	})
+
+	t.Run("success post responses include counts and own reaction", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodGet, "http://localhost:8080/api/v1/post/"+strconv.Itoa(postId), readerAccessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "🎉", responseBody.Data.(map[string]interface{})["my_reaction"])
+
+		response, responseBody = requestTest(t, router, http.MethodGet, "http://localhost:8080/api/v1/posts/"+strconv.Itoa(author.Id), accessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		for _, post := range responseBody.Data.(map[string]interface{})["posts"].([]interface{}) {
+			post := post.(map[string]interface{})
+
+			assert.Equal(t, "", post["my_reaction"])
+
+			switch int(post["id"].(float64)) {
+			case postId:
+				assert.Equal(t, map[string]interface{}{"👍": float64(1), "🎉": float64(1)}, post["reactions"])
+			case otherPostId:
+				assert.Equal(t, map[string]interface{}{}, post["reactions"])
+			}
+		}
+	})
+
+	t.Run("success list who reacted", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodGet, reactionsUrl, accessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 2, int(responseBody.Data.(map[string]interface{})["total"].(float64)))
+
+		response, responseBody = requestTest(t, router, http.MethodGet, reactionsUrl+"?reaction="+url.QueryEscape("🎉"), accessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		data := responseBody.Data.(map[string]interface{})
+		reactions := data["reactions"].([]interface{})
+
+		assert.Equal(t, 1, int(data["total"].(float64)))
+		assert.Equal(t, reader.Id, int(reactions[0].(map[string]interface{})["user"].(map[string]interface{})["id"].(float64)))
+	})
+
+	t.Run("success remove reaction", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodDelete, reactionUrl, readerAccessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		data := responseBody.Data.(map[string]interface{})
+
+		assert.Equal(t, map[string]interface{}{"👍": float64(1)}, data["reactions"])
+		assert.Equal(t, "", data["my_reaction"])
+	})
+
+	t.Run("not found remove missing reaction", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodDelete, reactionUrl, readerAccessToken, "")
+
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+
+	t.Run("bad request reaction outside the set", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodPut, reactionUrl, readerAccessToken, `{"reaction": "🍕"}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodPut, reactionUrl, readerAccessToken, `{"reaction": ""}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("not found react to a post the caller cannot read", func(t *testing.T) {
+		privateUrl := "http://localhost:8080/api/v1/posts/" + strconv.Itoa(privatePostId) + "/reaction"
+
+		response, _ := requestTest(t, router, http.MethodPut, privateUrl, readerAccessToken, `{"reaction": "👍"}`)
+
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodGet, "http://localhost:8080/api/v1/post/"+strconv.Itoa(privatePostId)+"/reactions", readerAccessToken, "")
+
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+}
@@ -0,0 +1,167 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/role"
+	"github.com/hutamatr/GoBlogify/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostRevision(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	category := createCategoryTestPost(db)
+	author, accessToken := createUserTestUser(db)
+	_, adminAccessToken := createAdminTestAdmin(db)
+
+	userService := user.NewUserService(user.NewUserRepository(), role.NewRoleRepository(), audit.NewAuditRepository(), db, helpers.Validate)
+	_, otherAccessToken, _ := userService.SignUp(context.Background(), user.UserCreateRequest{Username: "revisionOther", Email: "revision-other@example.com", Password: "Password123!", Confirm_Password: "Password123!"})
+
+	_, responseBody := requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/posts", accessToken, `{
+		"title": "First title",
+		"body": "line one\nline two\nline three",
+		"published": true,
+		"category_id": `+strconv.Itoa(category.Id)+`
+	}`)
+
+	postId := int(responseBody.Data.(map[string]interface{})["id"].(float64))
+	postUrl := "http://localhost:8080/api/v1/posts/" + strconv.Itoa(postId)
+	revisionsUrl := "http://localhost:8080/api/v1/post/" + strconv.Itoa(postId) + "/revisions"
+
+	updatePost := func(title, body string) {
+		response, _ := requestTest(t, router, http.MethodPut, postUrl, accessToken, `{
+			"title": `+strconv.Quote(title)+`,
+			"body": `+strconv.Quote(body)+`,
+			"user_id": `+strconv.Itoa(author.Id)+`,
+			"published": true,
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	}
+
+	t.Run("success every update stores a revision", func(t *testing.T) {
+		updatePost("Second title", "line one\nline 2\nline three")
+		updatePost("Third title", "line one\nline 2\nline three\nline four")
+
+		response, responseBody := requestTest(t, router, http.MethodGet, revisionsUrl, accessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		data := responseBody.Data.(map[string]interface{})
+		revisions := data["revisions"].([]interface{})
+
+		assert.Equal(t, 3, int(data["total"].(float64)))
+		assert.Equal(t, 3, int(revisions[0].(map[string]interface{})["revision_number"].(float64)))
+		assert.Equal(t, "First title", revisions[2].(map[string]interface{})["title"])
+		assert.Equal(t, author.Id, int(revisions[0].(map[string]interface{})["user_id"].(float64)))
+	})
+
+	t.Run("success diff two revisions", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodGet, revisionsUrl+"/1/diff/3", accessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		diff := responseBody.Data.(map[string]interface{})
+		body := diff["body"].([]interface{})
+
+		assert.Equal(t, false, diff["category_changed"])
+		assert.Equal(t, map[string]interface{}{"op": "equal", "line": "line one"}, body[0])
+		assert.Equal(t, map[string]interface{}{"op": "delete", "line": "line two"}, body[1])
+		assert.Equal(t, map[string]interface{}{"op": "insert", "line": "line 2"}, body[2])
+		assert.Equal(t, map[string]interface{}{"op": "equal", "line": "line three"}, body[3])
+		assert.Equal(t, map[string]interface{}{"op": "insert", "line": "line four"}, body[4])
+	})
+
+	t.Run("success restore revision as a new revision", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPost, postUrl+"/revisions/1/restore", accessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "First title", responseBody.Data.(map[string]interface{})["title"])
+
+		_, responseBody = requestTest(t, router, http.MethodGet, revisionsUrl, accessToken, "")
+
+		data := responseBody.Data.(map[string]interface{})
+		latest := data["revisions"].([]interface{})[0].(map[string]interface{})
+
+		assert.Equal(t, 4, int(data["total"].(float64)))
+		assert.Equal(t, 1, int(latest["restored_from"].(float64)))
+		assert.Equal(t, "line one\nline two\nline three", latest["body"])
+	})
+
+	t.Run("bad request revisions of another author", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodGet, revisionsUrl, otherAccessToken, "")
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodPost, postUrl+"/revisions/1/restore", otherAccessToken, "")
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodGet, revisionsUrl, adminAccessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("not found revision", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodGet, revisionsUrl+"/1/diff/99", accessToken, "")
+
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+
+	t.Run("success retention policy prunes old revisions", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPut, "http://localhost:8080/api/v1/admin/revision-policy", adminAccessToken, `{"keep_last": 2}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 2, int(responseBody.Data.(map[string]interface{})["pruned"].(float64)))
+
+		_, responseBody = requestTest(t, router, http.MethodGet, revisionsUrl, accessToken, "")
+
+		assert.Equal(t, 2, int(responseBody.Data.(map[string]interface{})["total"].(float64)))
+
+		updatePost("Fifth title", "body")
+
+		_, responseBody = requestTest(t, router, http.MethodGet, revisionsUrl, accessToken, "")
+
+		data := responseBody.Data.(map[string]interface{})
+
+		assert.Equal(t, 2, int(data["total"].(float64)))
+		assert.Equal(t, 5, int(data["revisions"].([]interface{})[0].(map[string]interface{})["revision_number"].(float64)))
+	})
+
+	t.Run("bad request update retention policy", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodPut, "http://localhost:8080/api/v1/admin/revision-policy", accessToken, `{"keep_last": 1}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodPut, "http://localhost:8080/api/v1/admin/revision-policy", adminAccessToken, `{"keep_last": -1}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		response, responseBody := requestTest(t, router, http.MethodGet, "http://localhost:8080/api/v1/admin/revision-policy", adminAccessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 2, int(responseBody.Data.(map[string]interface{})["keep_last"].(float64)))
+	})
+
+	t.Run("success restored revision goes through the content filter", func(t *testing.T) {
+		response, _ := createFilterRuleTestContentFilter(router, adminAccessToken, "word", "fifth", "mask")
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		response, responseBody := requestTest(t, router, http.MethodPost, postUrl+"/revisions/5/restore", accessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "***** title", responseBody.Data.(map[string]interface{})["title"])
+		assert.Equal(t, "pending", responseBody.Data.(map[string]interface{})["moderation_status"])
+	})
+}
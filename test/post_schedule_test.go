@@ -0,0 +1,230 @@
+package test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/post"
+	"github.com/hutamatr/GoBlogify/role"
+	"github.com/hutamatr/GoBlogify/user"
+	"github.com/hutamatr/GoBlogify/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func findScheduleTestPostSchedule(db *sql.DB, postId int) (bool, bool, bool) {
+	var published bool
+	var publishAt, unpublishAt sql.NullTime
+
+	err := db.QueryRow("SELECT is_published, publish_at, unpublish_at FROM post WHERE id = ?", postId).Scan(&published, &publishAt, &unpublishAt)
+	helpers.PanicError(err, "failed to query post schedule")
+
+	return published, publishAt.Valid, unpublishAt.Valid
+}
+
+func TestPostSchedule(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	category := createCategoryTestPost(db)
+	user, accessToken := createUserTestUser(db)
+	_, adminAccessToken := createAdminTestAdmin(db)
+	scheduler := utils.InitializedPostScheduler(db)
+
+	url := "http://localhost:8080/api/v1/posts"
+	inAnHour := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	inTwoHours := time.Now().Add(2 * time.Hour).UTC().Format(time.RFC3339)
+
+	createScheduledPost := func(title string) int {
+		response, responseBody := requestTest(t, router, http.MethodPost, url, accessToken, `{
+			"title": `+strconv.Quote(title)+`,
+			"body": "body",
+			"published": true,
+			"publish_at": "`+inAnHour+`",
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		return int(responseBody.Data.(map[string]interface{})["id"].(float64))
+	}
+
+	var postId int
+
+	t.Run("success create scheduled post", func(t *testing.T) {
+		postId = createScheduledPost("Scheduled")
+
+		published, hasPublishAt, _ := findScheduleTestPostSchedule(db, postId)
+
+		assert.False(t, published)
+		assert.True(t, hasPublishAt)
+	})
+
+	t.Run("success find all scheduled posts", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodGet, url+"/"+strconv.Itoa(user.Id)+"/scheduled", accessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, 1, int(responseBody.Data.(map[string]interface{})["total"].(float64)))
+
+		response, _ = requestTest(t, router, http.MethodGet, url+"/"+strconv.Itoa(user.Id)+"/scheduled", adminAccessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("scheduler leaves posts that are not due", func(t *testing.T) {
+		published, unpublished := scheduler.RunDue(context.Background())
+
+		assert.Equal(t, 0, published)
+		assert.Equal(t, 0, unpublished)
+	})
+
+	t.Run("scheduler publishes due posts exactly once", func(t *testing.T) {
+		_, err := db.Exec("UPDATE post SET publish_at = NOW() - INTERVAL 1 MINUTE WHERE id = ?", postId)
+		helpers.PanicError(err, "failed to update publish_at")
+
+		published, _ := scheduler.RunDue(context.Background())
+
+		assert.Equal(t, 1, published)
+
+		published, _ = scheduler.RunDue(context.Background())
+
+		assert.Equal(t, 0, published)
+
+		isPublished, hasPublishAt, _ := findScheduleTestPostSchedule(db, postId)
+
+		assert.True(t, isPublished)
+		assert.False(t, hasPublishAt)
+	})
+
+	t.Run("concurrent schedulers do not publish twice", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			createScheduledPost("Concurrent " + strconv.Itoa(i))
+		}
+
+		_, err := db.Exec("UPDATE post SET publish_at = NOW() - INTERVAL 1 MINUTE WHERE publish_at IS NOT NULL")
+		helpers.PanicError(err, "failed to update publish_at")
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		total := 0
+
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				published, _ := utils.InitializedPostScheduler(db).RunDue(context.Background())
+
+				mu.Lock()
+				total += published
+				mu.Unlock()
+			}()
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, 5, total)
+	})
+
+	t.Run("success reschedule post with expiry", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPut, url+"/"+strconv.Itoa(postId)+"/schedule", accessToken, `{
+			"unpublish_at": "`+inTwoHours+`"
+		}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, true, responseBody.Data.(map[string]interface{})["published"])
+
+		_, err := db.Exec("UPDATE post SET unpublish_at = NOW() - INTERVAL 1 MINUTE WHERE id = ?", postId)
+		helpers.PanicError(err, "failed to update unpublish_at")
+
+		_, unpublished := scheduler.RunDue(context.Background())
+
+		assert.Equal(t, 1, unpublished)
+
+		isPublished, _, hasUnpublishAt := findScheduleTestPostSchedule(db, postId)
+
+		assert.False(t, isPublished)
+		assert.False(t, hasUnpublishAt)
+	})
+
+	t.Run("success reschedule publish into the future", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPut, url+"/"+strconv.Itoa(postId)+"/schedule", accessToken, `{
+			"publish_at": "`+inAnHour+`",
+			"unpublish_at": "`+inTwoHours+`"
+		}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, false, responseBody.Data.(map[string]interface{})["published"])
+
+		_, hasPublishAt, hasUnpublishAt := findScheduleTestPostSchedule(db, postId)
+
+		assert.True(t, hasPublishAt)
+		assert.True(t, hasUnpublishAt)
+	})
+
+	t.Run("bad request reschedule post", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodPut, url+"/"+strconv.Itoa(postId)+"/schedule", accessToken, `{
+			"publish_at": "`+inTwoHours+`",
+			"unpublish_at": "`+inAnHour+`"
+		}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		_, otherAccessToken := createOtherUserTestPostSchedule(db, "scheduleOther")
+
+		response, _ = requestTest(t, router, http.MethodPut, url+"/"+strconv.Itoa(postId)+"/schedule", otherAccessToken, `{"publish_at": "`+inAnHour+`"}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodGet, url+"/"+strconv.Itoa(user.Id)+"/scheduled", otherAccessToken, "")
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("scheduler skips due posts of deactivated authors", func(t *testing.T) {
+		author, _ := createOtherUserTestPostSchedule(db, "scheduleDeactivated")
+
+		tx, err := db.Begin()
+		helpers.PanicError(err, "failed to begin transaction")
+
+		stuckPost := post.NewPostRepository().Save(context.Background(), tx, post.Post{Title: "Stuck", Body: "body", Publish_At: time.Now().Add(time.Hour), User_Id: author.Id, Category_Id: category.Id})
+
+		helpers.PanicError(tx.Commit(), "failed to commit transaction")
+
+		duePostId := createScheduledPost("Due next to a stuck post")
+
+		_, err = db.Exec("UPDATE user SET is_deactivated = true, deactivated_at = NOW() WHERE id = ?", author.Id)
+		helpers.PanicError(err, "failed to deactivate user")
+
+		_, err = db.Exec("UPDATE post SET publish_at = NOW() - INTERVAL 2 MINUTE WHERE id = ?", stuckPost.Id)
+		helpers.PanicError(err, "failed to update publish_at")
+
+		_, err = db.Exec("UPDATE post SET publish_at = NOW() - INTERVAL 1 MINUTE WHERE id = ?", duePostId)
+		helpers.PanicError(err, "failed to update publish_at")
+
+		published, _ := scheduler.RunDue(context.Background())
+
+		assert.Equal(t, 1, published)
+
+		isPublished, _, _ := findScheduleTestPostSchedule(db, duePostId)
+		assert.True(t, isPublished)
+
+		isPublished, hasPublishAt, _ := findScheduleTestPostSchedule(db, stuckPost.Id)
+		assert.False(t, isPublished)
+		assert.True(t, hasPublishAt)
+	})
+}
+
+func createOtherUserTestPostSchedule(db *sql.DB, username string) (user.UserResponse, string) {
+	userService := user.NewUserService(user.NewUserRepository(), role.NewRoleRepository(), audit.NewAuditRepository(), db, helpers.Validate)
+	other, accessToken, _ := userService.SignUp(context.Background(), user.UserCreateRequest{Username: username, Email: username + "@example.com", Password: "Password123!", Confirm_Password: "Password123!"})
+
+	return other, accessToken
+}
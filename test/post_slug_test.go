@@ -0,0 +1,109 @@
+package test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostSlug(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	category := createCategoryTestPost(db)
+	user, accessToken := createUserTestUser(db)
+
+	createPost := func(title, slug string) map[string]interface{} {
+		response, responseBody := requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/posts", accessToken, `{
+			"title": `+strconv.Quote(title)+`,
+			"slug": `+strconv.Quote(slug)+`,
+			"body": "body",
+			"published": true,
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		return responseBody.Data.(map[string]interface{})
+	}
+
+	slugUrl := "http://localhost:8080/api/v1/posts/by-slug/" + user.Username + "/"
+
+	var firstPostId int
+
+	t.Run("success create posts with unique slugs", func(t *testing.T) {
+		post := createPost("Crème Brûlée: à la Straße", "")
+		firstPostId = int(post["id"].(float64))
+
+		assert.Equal(t, "creme-brulee-a-la-strasse", post["slug"])
+
+		post = createPost("Creme brulee a la strasse!", "")
+
+		assert.Equal(t, "creme-brulee-a-la-strasse-2", post["slug"])
+
+		post = createPost("Привет мир", "")
+
+		assert.Equal(t, "privet-mir", post["slug"])
+
+		post = createPost("日本語", "")
+
+		assert.Equal(t, "post", post["slug"])
+
+		post = createPost("Anything", "My Custom Slug")
+
+		assert.Equal(t, "my-custom-slug", post["slug"])
+	})
+
+	t.Run("success find post by slug", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodGet, slugUrl+"creme-brulee-a-la-strasse", accessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, firstPostId, int(responseBody.Data.(map[string]interface{})["id"].(float64)))
+	})
+
+	t.Run("success update slug and redirect old slug", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPut, "http://localhost:8080/api/v1/posts/"+strconv.Itoa(firstPostId), accessToken, `{
+			"title": "Crème Brûlée: à la Straße",
+			"slug": "best-creme-brulee",
+			"body": "body",
+			"user_id": `+strconv.Itoa(user.Id)+`,
+			"published": true,
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "best-creme-brulee", responseBody.Data.(map[string]interface{})["slug"])
+
+		response, _ = requestTest(t, router, http.MethodGet, slugUrl+"creme-brulee-a-la-strasse", accessToken, "")
+
+		assert.Equal(t, http.StatusMovedPermanently, response.StatusCode)
+		assert.Equal(t, "/api/v1/posts/by-slug/"+user.Username+"/best-creme-brulee", response.Header.Get("Location"))
+	})
+
+	t.Run("old slugs are not reused by new posts", func(t *testing.T) {
+		post := createPost("Creme Brulee a la Strasse", "")
+
+		assert.Equal(t, "creme-brulee-a-la-strasse-3", post["slug"])
+	})
+
+	t.Run("not found post by slug", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodGet, slugUrl+"does-not-exist", accessToken, "")
+
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodGet, "http://localhost:8080/api/v1/posts/by-slug/nobody/best-creme-brulee", accessToken, "")
+
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+
+	t.Run("following route still resolves", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodGet, "http://localhost:8080/api/v1/posts/"+strconv.Itoa(user.Id)+"/following", accessToken, "")
+
+		assert.NotEqual(t, http.StatusMethodNotAllowed, response.StatusCode)
+		assert.NotEqual(t, http.StatusInternalServerError, response.StatusCode)
+	})
+}
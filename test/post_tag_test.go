@@ -0,0 +1,198 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/role"
+	"github.com/hutamatr/GoBlogify/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func tagNamesTestPostTag(data interface{}) []string {
+	var names []string
+
+	for _, name := range data.(map[string]interface{})["tags"].([]interface{}) {
+		names = append(names, name.(string))
+	}
+
+	return names
+}
+
+func TestPostTag(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	category := createCategoryTestPost(db)
+	author, accessToken := createUserTestUser(db)
+	_, adminAccessToken := createAdminTestAdmin(db)
+
+	userService := user.NewUserService(user.NewUserRepository(), role.NewRoleRepository(), audit.NewAuditRepository(), db, helpers.Validate)
+	_, otherAccessToken, _ := userService.SignUp(context.Background(), user.UserCreateRequest{Username: "tagOther", Email: "tag-other@example.com", Password: "Password123!", Confirm_Password: "Password123!"})
+
+	url := "http://localhost:8080/api/v1/posts"
+
+	createPost := func(accessToken, title, tags string) int {
+		response, responseBody := requestTest(t, router, http.MethodPost, url, accessToken, `{
+			"title": `+strconv.Quote(title)+`,
+			"body": "body",
+			"published": true,
+			"tags": `+tags+`,
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		return int(responseBody.Data.(map[string]interface{})["id"].(float64))
+	}
+
+	var postId int
+
+	t.Run("success create post with normalised tags", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPost, url, accessToken, `{
+			"title": "Tagged",
+			"body": "body",
+			"published": true,
+			"tags": ["Go", "#go", "Machine Learning"],
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+		assert.Equal(t, []string{"go", "machine-learning"}, tagNamesTestPostTag(responseBody.Data))
+
+		postId = int(responseBody.Data.(map[string]interface{})["id"].(float64))
+
+		_, responseBody = requestTest(t, router, http.MethodGet, "http://localhost:8080/api/v1/post/"+strconv.Itoa(postId), accessToken, "")
+
+		assert.Equal(t, []string{"go", "machine-learning"}, tagNamesTestPostTag(responseBody.Data))
+	})
+
+	t.Run("bad request too many tags", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodPost, url, accessToken, `{
+			"title": "Too many",
+			"body": "body",
+			"published": true,
+			"tags": ["a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"],
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("success update replaces tags", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPut, url+"/"+strconv.Itoa(postId), accessToken, `{
+			"title": "Tagged",
+			"body": "body",
+			"user_id": `+strconv.Itoa(author.Id)+`,
+			"published": true,
+			"tags": ["golang", "Databases"],
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, []string{"databases", "golang"}, tagNamesTestPostTag(responseBody.Data))
+
+		response, responseBody = requestTest(t, router, http.MethodPut, url+"/"+strconv.Itoa(postId), accessToken, `{
+			"title": "Tagged again",
+			"body": "body",
+			"user_id": `+strconv.Itoa(author.Id)+`,
+			"published": true,
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, []string{"databases", "golang"}, tagNamesTestPostTag(responseBody.Data))
+	})
+
+	t.Run("success find posts by tag", func(t *testing.T) {
+		createPost(otherAccessToken, "Other golang", `["GoLang"]`)
+
+		response, responseBody := requestTest(t, router, http.MethodGet, "http://localhost:8080/api/v1/tag/golang/posts", accessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		data := responseBody.Data.(map[string]interface{})
+
+		assert.Equal(t, 2, int(data["total"].(float64)))
+		assert.Equal(t, 2, len(data["posts"].([]interface{})))
+
+		_, responseBody = requestTest(t, router, http.MethodGet, "http://localhost:8080/api/v1/tag/unknown/posts", accessToken, "")
+
+		assert.Equal(t, 0, int(responseBody.Data.(map[string]interface{})["total"].(float64)))
+	})
+
+	t.Run("success find all tags with counts", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodGet, "http://localhost:8080/api/v1/tags", accessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		tags := responseBody.Data.(map[string]interface{})["tags"].([]interface{})
+		first := tags[0].(map[string]interface{})
+
+		assert.Equal(t, "golang", first["name"])
+		assert.Equal(t, 2, int(first["post_count"].(float64)))
+	})
+
+	t.Run("success autocomplete tags", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodGet, "http://localhost:8080/api/v1/tags/autocomplete?q=Go", accessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		var names []string
+		for _, tag := range responseBody.Data.([]interface{}) {
+			names = append(names, tag.(map[string]interface{})["name"].(string))
+		}
+
+		assert.Equal(t, []string{"golang", "go"}, names)
+	})
+
+	findTagId := func(name string) int {
+		var tagId int
+		err := db.QueryRow("SELECT id FROM tag WHERE name = ?", name).Scan(&tagId)
+		helpers.PanicError(err, "failed to query tag id")
+
+		return tagId
+	}
+
+	t.Run("success admin rename tag", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPut, "http://localhost:8080/api/v1/admin/tags/"+strconv.Itoa(findTagId("databases")), adminAccessToken, `{"name": "Database"}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "database", responseBody.Data.(map[string]interface{})["name"])
+
+		response, _ = requestTest(t, router, http.MethodPut, "http://localhost:8080/api/v1/admin/tags/"+strconv.Itoa(findTagId("database")), adminAccessToken, `{"name": "golang"}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("success admin merge tags", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/admin/tags/"+strconv.Itoa(findTagId("go"))+"/merge", adminAccessToken, `{"into_tag_id": `+strconv.Itoa(findTagId("golang"))+`}`)
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, "golang", responseBody.Data.(map[string]interface{})["name"])
+
+		var count int
+		err := db.QueryRow("SELECT COUNT(*) FROM tag WHERE name = 'go'").Scan(&count)
+		helpers.PanicError(err, "failed to count tags")
+
+		assert.Equal(t, 0, count)
+	})
+
+	t.Run("bad request rename and merge as non admin", func(t *testing.T) {
+		tagId := strconv.Itoa(findTagId("golang"))
+
+		response, _ := requestTest(t, router, http.MethodPut, "http://localhost:8080/api/v1/admin/tags/"+tagId, otherAccessToken, `{"name": "rust"}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/admin/tags/"+tagId+"/merge", otherAccessToken, `{"into_tag_id": `+strconv.Itoa(findTagId("database"))+`}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+}
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hutamatr/GoBlogify/audit"
 	"github.com/hutamatr/GoBlogify/category"
 	"github.com/hutamatr/GoBlogify/helpers"
 	"github.com/hutamatr/GoBlogify/post"
@@ -215,7 +216,7 @@ func TestFindAllPostByFollowed(t *testing.T) {
 
 		userRepository := user.NewUserRepository()
 		roleRepository := role.NewRoleRepository()
-		userService := user.NewUserService(userRepository, roleRepository, db, helpers.Validate)
+		userService := user.NewUserService(userRepository, roleRepository, audit.NewAuditRepository(), db, helpers.Validate)
 		newUser2, accessToken2, _ := userService.SignUp(ctx, user.UserCreateRequest{Username: "userTest2", Email: "testing2@example.com", Password: "Password123!", Confirm_Password: "Password123!"})
 
 		followUser := createFollowTest(db, newUser2.Id, newUser1.Id)
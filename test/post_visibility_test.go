@@ -0,0 +1,212 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/role"
+	"github.com/hutamatr/GoBlogify/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func listIdsTestPostVisibility(responseBody helpers.ResponseJSON) []int {
+	ids := []int{}
+
+	data, ok := responseBody.Data.(map[string]interface{})
+	if !ok {
+		return ids
+	}
+
+	posts, _ := data["posts"].([]interface{})
+
+	for _, post := range posts {
+		ids = append(ids, int(post.(map[string]interface{})["id"].(float64)))
+	}
+
+	return ids
+}
+
+func TestPostVisibility(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	category := createCategoryTestPost(db)
+	author, authorAccessToken := createUserTestUser(db)
+
+	userService := user.NewUserService(user.NewUserRepository(), role.NewRoleRepository(), audit.NewAuditRepository(), db, helpers.Validate)
+	follower, followerAccessToken, _ := userService.SignUp(context.Background(), user.UserCreateRequest{Username: "visibilityFollower", Email: "visibility-follower@example.com", Password: "Password123!", Confirm_Password: "Password123!"})
+	_, strangerAccessToken, _ := userService.SignUp(context.Background(), user.UserCreateRequest{Username: "visibilityStranger", Email: "visibility-stranger@example.com", Password: "Password123!", Confirm_Password: "Password123!"})
+
+	response, _ := requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/users/"+strconv.Itoa(follower.Id)+"/follow/"+strconv.Itoa(author.Id), followerAccessToken, "")
+	assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+	posts := map[string]int{}
+
+	for _, visibility := range []string{"public", "followers", "unlisted", "private"} {
+		response, responseBody := requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/posts", authorAccessToken, `{
+			"title": "Visibility `+visibility+`",
+			"body": "matrixword `+visibility+`",
+			"published": true,
+			"visibility": "`+visibility+`",
+			"tags": ["matrix"],
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+		assert.Equal(t, visibility, responseBody.Data.(map[string]interface{})["visibility"])
+
+		posts[visibility] = int(responseBody.Data.(map[string]interface{})["id"].(float64))
+	}
+
+	viewers := []struct {
+		name        string
+		accessToken string
+		byLink      []string
+		listed      []string
+	}{
+		{"author", authorAccessToken, []string{"public", "followers", "unlisted", "private"}, []string{"public", "followers", "unlisted", "private"}},
+		{"follower", followerAccessToken, []string{"public", "followers", "unlisted"}, []string{"public", "followers"}},
+		{"stranger", strangerAccessToken, []string{"public", "unlisted"}, []string{"public"}},
+	}
+
+	idsOf := func(visibilities []string) []int {
+		ids := []int{}
+		for _, visibility := range visibilities {
+			ids = append(ids, posts[visibility])
+		}
+		return ids
+	}
+
+	listUrls := map[string]string{
+		"user posts":  "http://localhost:8080/api/v1/posts/" + strconv.Itoa(author.Id),
+		"global feed": "http://localhost:8080/api/v1/posts",
+		"search":      "http://localhost:8080/api/v1/search/posts?q=matrixword",
+		"tag":         "http://localhost:8080/api/v1/tag/matrix/posts",
+	}
+
+	for _, viewer := range viewers {
+		for _, visibility := range []string{"public", "followers", "unlisted", "private"} {
+			allowed := false
+			for _, byLink := range viewer.byLink {
+				allowed = allowed || byLink == visibility
+			}
+
+			t.Run(viewer.name+" opens "+visibility+" post by link", func(t *testing.T) {
+				response, _ := requestTest(t, router, http.MethodGet, "http://localhost:8080/api/v1/post/"+strconv.Itoa(posts[visibility]), viewer.accessToken, "")
+
+				if allowed {
+					assert.Equal(t, http.StatusOK, response.StatusCode)
+				} else {
+					assert.Equal(t, http.StatusNotFound, response.StatusCode)
+				}
+			})
+		}
+
+		for listName, url := range listUrls {
+			t.Run(viewer.name+" lists "+listName, func(t *testing.T) {
+				_, responseBody := requestTest(t, router, http.MethodGet, url, viewer.accessToken, "")
+
+				assert.ElementsMatch(t, idsOf(viewer.listed), listIdsTestPostVisibility(responseBody))
+			})
+		}
+	}
+
+	t.Run("followed feed only shows what the caller may see", func(t *testing.T) {
+		followingUrl := "http://localhost:8080/api/v1/posts/" + strconv.Itoa(follower.Id) + "/following"
+
+		_, responseBody := requestTest(t, router, http.MethodGet, followingUrl, followerAccessToken, "")
+
+		assert.ElementsMatch(t, idsOf([]string{"public", "followers"}), listIdsTestPostVisibility(responseBody))
+
+		_, responseBody = requestTest(t, router, http.MethodGet, followingUrl, strangerAccessToken, "")
+
+		assert.ElementsMatch(t, idsOf([]string{"public"}), listIdsTestPostVisibility(responseBody))
+	})
+
+	t.Run("tag counts only include public posts", func(t *testing.T) {
+		_, responseBody := requestTest(t, router, http.MethodGet, "http://localhost:8080/api/v1/tags", strangerAccessToken, "")
+
+		tag := responseBody.Data.(map[string]interface{})["tags"].([]interface{})[0].(map[string]interface{})
+
+		assert.Equal(t, "matrix", tag["name"])
+		assert.Equal(t, 1, int(tag["post_count"].(float64)))
+	})
+
+	t.Run("comments follow the post visibility", func(t *testing.T) {
+		commentsUrl := "http://localhost:8080/api/v1/comments"
+
+		for _, visibility := range []string{"followers", "private"} {
+			postId := strconv.Itoa(posts[visibility])
+
+			response, _ := requestTest(t, router, http.MethodGet, commentsUrl+"?postId="+postId, strangerAccessToken, "")
+			assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+			response, _ = requestTest(t, router, http.MethodPost, commentsUrl, strangerAccessToken, `{"content": "stranger comment", "post_id": `+postId+`}`)
+			assert.Equal(t, http.StatusNotFound, response.StatusCode)
+		}
+
+		response, _ := requestTest(t, router, http.MethodPost, commentsUrl, followerAccessToken, `{"content": "follower comment", "post_id": `+strconv.Itoa(posts["followers"])+`}`)
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		response, responseBody := requestTest(t, router, http.MethodPost, commentsUrl, authorAccessToken, `{"content": "author comment", "post_id": `+strconv.Itoa(posts["private"])+`}`)
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		commentUrl := "http://localhost:8080/api/v1/comments/" + strconv.Itoa(int(responseBody.Data.(map[string]interface{})["id"].(float64)))
+
+		response, _ = requestTest(t, router, http.MethodGet, commentsUrl+"?postId="+strconv.Itoa(posts["private"]), authorAccessToken, "")
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodGet, commentUrl, authorAccessToken, "")
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodGet, commentUrl, strangerAccessToken, "")
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+
+	t.Run("success update keeps visibility unless changed", func(t *testing.T) {
+		postUrl := "http://localhost:8080/api/v1/posts/" + strconv.Itoa(posts["private"])
+
+		_, responseBody := requestTest(t, router, http.MethodPut, postUrl, authorAccessToken, `{
+			"title": "Visibility private",
+			"body": "matrixword private",
+			"user_id": `+strconv.Itoa(author.Id)+`,
+			"published": true,
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, "private", responseBody.Data.(map[string]interface{})["visibility"])
+
+		_, responseBody = requestTest(t, router, http.MethodPut, postUrl, authorAccessToken, `{
+			"title": "Visibility private",
+			"body": "matrixword private",
+			"user_id": `+strconv.Itoa(author.Id)+`,
+			"published": true,
+			"visibility": "public",
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, "public", responseBody.Data.(map[string]interface{})["visibility"])
+
+		response, _ := requestTest(t, router, http.MethodGet, "http://localhost:8080/api/v1/post/"+strconv.Itoa(posts["private"]), strangerAccessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+
+	t.Run("bad request invalid visibility", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/posts", authorAccessToken, `{
+			"title": "Invalid",
+			"body": "body",
+			"published": true,
+			"visibility": "friends",
+			"category_id": `+strconv.Itoa(category.Id)+`
+		}`)
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+}
@@ -0,0 +1,266 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hutamatr/GoBlogify/comment"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/post"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportContent(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	user, accessToken := createUserTestUser(db)
+	admin, adminAccessToken := createAdminTestAdmin(db)
+	category := createCategoryTestPost(db)
+	reportedPost := createPostTestComment(db, admin.Id, category.Id)
+	ownPost := createPostTestComment(db, user.Id, category.Id)
+
+	var reportId int
+
+	t.Run("success create report", func(t *testing.T) {
+		reportBody := strings.NewReader(`{
+			"target_type": "post",
+			"target_id": ` + strconv.Itoa(reportedPost.Id) + `,
+			"reason": "spam",
+			"note": "link farm"
+		}`)
+
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/reports", reportBody)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, http.StatusCreated, responseBody.Code)
+		assert.Equal(t, "CREATED", responseBody.Status)
+		assert.Equal(t, "open", responseBody.Data.(map[string]interface{})["status"])
+		assert.Equal(t, float64(admin.Id), responseBody.Data.(map[string]interface{})["target_user_id"])
+
+		reportId = int(responseBody.Data.(map[string]interface{})["id"].(float64))
+	})
+
+	t.Run("duplicate open report is rejected", func(t *testing.T) {
+		reportBody := strings.NewReader(`{
+			"target_type": "post",
+			"target_id": ` + strconv.Itoa(reportedPost.Id) + `,
+			"reason": "spam"
+		}`)
+
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/reports", reportBody)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("reporting own content is rejected", func(t *testing.T) {
+		reportBody := strings.NewReader(`{
+			"target_type": "post",
+			"target_id": ` + strconv.Itoa(ownPost.Id) + `,
+			"reason": "other"
+		}`)
+
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/reports", reportBody)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("reporting content the caller cannot read looks like missing content", func(t *testing.T) {
+		ctx := context.Background()
+		tx, err := db.Begin()
+		helpers.PanicError(err, "failed to begin transaction")
+
+		postRepository := post.NewPostRepository()
+		privatePost := postRepository.Save(ctx, tx, post.Post{Title: "Private", Body: "Body", Published: true, Visibility: post.VisibilityPrivate, User_Id: admin.Id, Category_Id: category.Id})
+		draft := postRepository.Save(ctx, tx, post.Post{Title: "Draft", Body: "Body", User_Id: admin.Id, Category_Id: category.Id})
+		privateComment := comment.NewCommentRepository().Save(ctx, tx, comment.Comment{Content: "comment", User_Id: admin.Id, Post_Id: privatePost.Id})
+
+		tx.Commit()
+
+		targets := []struct {
+			targetType string
+			targetId   int
+		}{
+			{"post", privatePost.Id},
+			{"post", draft.Id},
+			{"post", 999999},
+			{"comment", privateComment.Id},
+			{"comment", 999999},
+		}
+
+		for _, target := range targets {
+			reportBody := strings.NewReader(`{
+				"target_type": "` + target.targetType + `",
+				"target_id": ` + strconv.Itoa(target.targetId) + `,
+				"reason": "spam"
+			}`)
+
+			request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/reports", reportBody)
+			request.Header.Add("Content-Type", "application/json")
+			request.Header.Add("Authorization", "Bearer "+accessToken)
+
+			recorder := httptest.NewRecorder()
+
+			router.ServeHTTP(recorder, request)
+
+			response := recorder.Result()
+
+			assert.Equal(t, http.StatusNotFound, response.StatusCode)
+
+			body, err := io.ReadAll(response.Body)
+			helpers.PanicError(err, "failed to read response body")
+
+			var responseBody helpers.ErrorResponseJSON
+
+			assert.Nil(t, json.Unmarshal(body, &responseBody))
+			assert.Equal(t, target.targetType+" not found", responseBody.Error)
+		}
+	})
+
+	t.Run("non moderator cannot see the queue", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/moderation/reports", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
+	t.Run("moderator sees open reports", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/moderation/reports?target_type=post", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, float64(1), responseBody.Data.(map[string]interface{})["total"])
+	})
+
+	t.Run("success resolve report by hiding the post", func(t *testing.T) {
+		resolveBody := strings.NewReader(`{
+			"action": "hide",
+			"note": "spam links"
+		}`)
+
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/moderation/reports/"+strconv.Itoa(reportId)+"/resolve", resolveBody)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, "resolved", responseBody.Data.(map[string]interface{})["status"])
+		assert.Equal(t, "hide", responseBody.Data.(map[string]interface{})["action"])
+
+		request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/post/"+strconv.Itoa(reportedPost.Id), nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder = httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response = recorder.Result()
+
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+
+	t.Run("reporter sees the outcome", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/reports/mine", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		reports := responseBody.Data.(map[string]interface{})["reports"].([]interface{})
+		assert.Equal(t, 1, len(reports))
+		assert.Equal(t, "thanks, the reported content has been hidden", reports[0].(map[string]interface{})["outcome"])
+	})
+}
@@ -0,0 +1,129 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/category"
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/hutamatr/GoBlogify/role"
+	"github.com/hutamatr/GoBlogify/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func searchIdsTestSearch(responseBody helpers.ResponseJSON) []int {
+	var ids []int
+
+	for _, post := range responseBody.Data.(map[string]interface{})["posts"].([]interface{}) {
+		ids = append(ids, int(post.(map[string]interface{})["id"].(float64)))
+	}
+
+	return ids
+}
+
+func TestSearchPost(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	firstCategory := createCategoryTestPost(db)
+	author, accessToken := createUserTestUser(db)
+
+	tx, err := db.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	secondCategory := category.NewCategoryRepository().Save(context.Background(), tx, category.Category{Name: "search-category"})
+	helpers.PanicError(tx.Commit(), "failed to commit transaction")
+
+	userService := user.NewUserService(user.NewUserRepository(), role.NewRoleRepository(), audit.NewAuditRepository(), db, helpers.Validate)
+	_, otherAccessToken, _ := userService.SignUp(context.Background(), user.UserCreateRequest{Username: "searchOther", Email: "search-other@example.com", Password: "Password123!", Confirm_Password: "Password123!"})
+
+	createPost := func(accessToken, title, body string, categoryId int, tags string) int {
+		response, responseBody := requestTest(t, router, http.MethodPost, "http://localhost:8080/api/v1/posts", accessToken, `{
+			"title": `+strconv.Quote(title)+`,
+			"body": `+strconv.Quote(body)+`,
+			"published": true,
+			"tags": `+tags+`,
+			"category_id": `+strconv.Itoa(categoryId)+`
+		}`)
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		return int(responseBody.Data.(map[string]interface{})["id"].(float64))
+	}
+
+	bestMatch := createPost(accessToken, "Golang concurrency", "Golang channels and golang goroutines make golang fun", firstCategory.Id, `["go"]`)
+	weakMatch := createPost(otherAccessToken, "Kitchen notes", "Cooking pasta while reading about golang", secondCategory.Id, `[]`)
+	createPost(accessToken, "Gardening", "Tomatoes need sunlight and water", firstCategory.Id, `[]`)
+	draft := createPost(accessToken, "Golang draft", "Unfinished golang thoughts", firstCategory.Id, `[]`)
+
+	_, err = db.Exec("UPDATE post SET is_published = false WHERE id = ?", draft)
+	helpers.PanicError(err, "failed to unpublish post")
+
+	url := "http://localhost:8080/api/v1/search/posts"
+
+	t.Run("success ranked results with highlights", func(t *testing.T) {
+		response, responseBody := requestTest(t, router, http.MethodGet, url+"?q=golang", accessToken, "")
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Equal(t, []int{bestMatch, weakMatch}, searchIdsTestSearch(responseBody))
+		assert.Equal(t, 2, int(responseBody.Data.(map[string]interface{})["total"].(float64)))
+
+		first := responseBody.Data.(map[string]interface{})["posts"].([]interface{})[0].(map[string]interface{})
+
+		assert.Equal(t, "<mark>Golang</mark> concurrency", first["title_highlight"])
+		assert.Contains(t, first["snippet"], "<mark>golang</mark> goroutines")
+		assert.Equal(t, author.Id, int(first["author"].(map[string]interface{})["id"].(float64)))
+	})
+
+	t.Run("success prefix match", func(t *testing.T) {
+		_, responseBody := requestTest(t, router, http.MethodGet, url+"?q=tomato", accessToken, "")
+
+		assert.Equal(t, 1, len(searchIdsTestSearch(responseBody)))
+	})
+
+	t.Run("success filter by author category and tag", func(t *testing.T) {
+		_, responseBody := requestTest(t, router, http.MethodGet, url+"?q=golang&author_id="+strconv.Itoa(author.Id), accessToken, "")
+
+		assert.Equal(t, []int{bestMatch}, searchIdsTestSearch(responseBody))
+
+		_, responseBody = requestTest(t, router, http.MethodGet, url+"?q=golang&category_id="+strconv.Itoa(secondCategory.Id), accessToken, "")
+
+		assert.Equal(t, []int{weakMatch}, searchIdsTestSearch(responseBody))
+
+		_, responseBody = requestTest(t, router, http.MethodGet, url+"?q=golang&tag=Go", accessToken, "")
+
+		assert.Equal(t, []int{bestMatch}, searchIdsTestSearch(responseBody))
+	})
+
+	t.Run("success filter by date range", func(t *testing.T) {
+		tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+		yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+
+		_, responseBody := requestTest(t, router, http.MethodGet, url+"?q=golang&from="+tomorrow, accessToken, "")
+
+		assert.Equal(t, 0, int(responseBody.Data.(map[string]interface{})["total"].(float64)))
+
+		_, responseBody = requestTest(t, router, http.MethodGet, url+"?q=golang&from="+yesterday+"&to="+tomorrow, accessToken, "")
+
+		assert.Equal(t, 2, int(responseBody.Data.(map[string]interface{})["total"].(float64)))
+	})
+
+	t.Run("bad request search", func(t *testing.T) {
+		response, _ := requestTest(t, router, http.MethodGet, url, accessToken, "")
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodGet, url+"?q=%2B%2A%22", accessToken, "")
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		response, _ = requestTest(t, router, http.MethodGet, url+"?q=golang&from=yesterday", accessToken, "")
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+}
@@ -0,0 +1,125 @@
+package test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindStats(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	user, accessToken := createUserTestUser(db)
+	_, adminAccessToken := createAdminTestAdmin(db)
+	category := createCategoryTestPost(db)
+	createPostTestComment(db, user.Id, category.Id)
+
+	t.Run("success find stats", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/admin/stats?interval=day", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		data := responseBody.Data.(map[string]interface{})
+		totals := data["totals"].(map[string]interface{})
+		signups := data["series"].(map[string]interface{})["signups"].([]interface{})
+		topAuthor := data["top_authors"].([]interface{})[0].(map[string]interface{})
+
+		assert.Equal(t, float64(2), totals["users"])
+		assert.Equal(t, float64(1), totals["published_posts"])
+		assert.Len(t, signups, 30)
+		assert.Equal(t, float64(2), signups[len(signups)-1].(map[string]interface{})["count"])
+		assert.Equal(t, user.Username, topAuthor["username"])
+		assert.Equal(t, category.Name, data["top_categories"].([]interface{})[0].(map[string]interface{})["name"])
+	})
+
+	t.Run("success find stats by month", func(t *testing.T) {
+		from := time.Now().AddDate(0, -2, 0).Format("2006-01-02")
+		to := time.Now().Format("2006-01-02")
+
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/admin/stats?interval=month&from="+from+"&to="+to, nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		postsPublished := responseBody.Data.(map[string]interface{})["series"].(map[string]interface{})["posts_published"].([]interface{})
+
+		assert.Len(t, postsPublished, 3)
+		assert.Equal(t, time.Now().UTC().Format("2006-01"), postsPublished[2].(map[string]interface{})["period"])
+	})
+
+	t.Run("failed find stats with invalid interval", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/admin/stats?interval=year", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+	})
+
+	t.Run("failed find stats with too long a range", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/admin/stats?interval=day&from=0001-01-01&to=9999-12-31", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+	})
+
+	t.Run("failed find stats as non admin", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/admin/stats", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+	})
+}
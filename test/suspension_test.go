@@ -0,0 +1,232 @@
+package test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hutamatr/GoBlogify/helpers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuspendUser(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	user, accessToken := createUserTestUser(db)
+	_, adminAccessToken := createAdminTestAdmin(db)
+
+	t.Run("success suspend user", func(t *testing.T) {
+		suspensionBody := strings.NewReader(`{
+			"reason": "spamming comments",
+			"duration_hours": 24
+		}`)
+
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/admin/users/"+strconv.Itoa(user.Id)+"/suspensions", suspensionBody)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusCreated, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, http.StatusCreated, responseBody.Code)
+		assert.Equal(t, "CREATED", responseBody.Status)
+		assert.Equal(t, "spamming comments", responseBody.Data.(map[string]interface{})["reason"])
+		assert.Equal(t, true, responseBody.Data.(map[string]interface{})["active"])
+		assert.Equal(t, false, responseBody.Data.(map[string]interface{})["permanent"])
+	})
+
+	t.Run("suspended user is rejected by auth middleware", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/users/"+strconv.Itoa(user.Id), nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ErrorResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Contains(t, responseBody.Error, "account is suspended until")
+		assert.Contains(t, responseBody.Error, "spamming comments")
+	})
+
+	t.Run("suspended user cannot sign in", func(t *testing.T) {
+		accountBody := strings.NewReader(`{
+			"email": "testing@example.com",
+			"password": "Password123!"
+		}`)
+
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/signin", accountBody)
+		request.Header.Add("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+	})
+
+	t.Run("success lift suspension", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodDelete, "http://localhost:8080/api/v1/admin/users/"+strconv.Itoa(user.Id)+"/suspensions", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/users/"+strconv.Itoa(user.Id), nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder = httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	})
+
+	t.Run("expired suspension is lifted automatically", func(t *testing.T) {
+		_, err := db.Exec("INSERT INTO user_suspension(user_id, admin_id, reason, expires_at) SELECT ?, id, 'expired', NOW() - INTERVAL 1 HOUR FROM user WHERE email = 'admin@example.com'", user.Id)
+		helpers.PanicError(err, "failed to insert expired suspension")
+
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/users/"+strconv.Itoa(user.Id), nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	})
+
+	t.Run("success ban user permanently", func(t *testing.T) {
+		suspensionBody := strings.NewReader(`{
+			"reason": "repeated abuse",
+			"permanent": true
+		}`)
+
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/admin/users/"+strconv.Itoa(user.Id)+"/suspensions", suspensionBody)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusCreated, recorder.Result().StatusCode)
+
+		request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/users/"+strconv.Itoa(user.Id), nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder = httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		body, err := io.ReadAll(recorder.Result().Body)
+
+		var responseBody helpers.ErrorResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, http.StatusUnauthorized, responseBody.Code)
+		assert.Equal(t, "account is banned: repeated abuse", responseBody.Error)
+	})
+
+	t.Run("success find all suspensions by user", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/admin/users/"+strconv.Itoa(user.Id)+"/suspensions", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, float64(3), responseBody.Data.(map[string]interface{})["total"])
+	})
+
+	t.Run("failed suspend user without reason", func(t *testing.T) {
+		suspensionBody := strings.NewReader(`{
+			"duration_hours": 24
+		}`)
+
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/admin/users/"+strconv.Itoa(user.Id)+"/suspensions", suspensionBody)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+	})
+
+	t.Run("not found suspend user", func(t *testing.T) {
+		suspensionBody := strings.NewReader(`{
+			"reason": "spamming comments",
+			"duration_hours": 24
+		}`)
+
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/admin/users/"+strconv.Itoa(user.Id+100)+"/suspensions", suspensionBody)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Result().StatusCode)
+	})
+}
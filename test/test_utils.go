@@ -2,8 +2,13 @@ package test
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -14,6 +19,7 @@ import (
 	"github.com/hutamatr/GoBlogify/middleware"
 
 	"github.com/joho/godotenv"
+	"github.com/stretchr/testify/assert"
 )
 
 func init() {
@@ -43,12 +49,46 @@ func ConnectDBTest() *sql.DB {
 func DeleteDBTest(db *sql.DB) {
 	_, err := db.Exec("DELETE FROM comment")
 	helpers.PanicError(err, "failed to delete comment")
+	_, err = db.Exec("DELETE FROM post_slug_history")
+	helpers.PanicError(err, "failed to delete post_slug_history")
+	_, err = db.Exec("DELETE FROM post_revision")
+	helpers.PanicError(err, "failed to delete post_revision")
+	_, err = db.Exec("DELETE FROM post_reaction")
+	helpers.PanicError(err, "failed to delete post_reaction")
+	_, err = db.Exec("DELETE FROM post_tag")
+	helpers.PanicError(err, "failed to delete post_tag")
+	_, err = db.Exec("DELETE FROM tag")
+	helpers.PanicError(err, "failed to delete tag")
 	_, err = db.Exec("DELETE FROM post")
 	helpers.PanicError(err, "failed to delete post")
 	_, err = db.Exec("DELETE FROM category")
 	helpers.PanicError(err, "failed to delete category")
 	_, err = db.Exec("DELETE FROM follow")
 	helpers.PanicError(err, "failed to delete follow")
+	_, err = db.Exec("DELETE FROM erasure")
+	helpers.PanicError(err, "failed to delete erasure")
+	_, err = db.Exec("DELETE FROM data_export")
+	helpers.PanicError(err, "failed to delete data export")
+	_, err = db.Exec("DELETE FROM username_history")
+	helpers.PanicError(err, "failed to delete username history")
+	_, err = db.Exec("DELETE FROM spam_token")
+	helpers.PanicError(err, "failed to delete spam_token")
+	_, err = db.Exec("DELETE FROM spam_corpus")
+	helpers.PanicError(err, "failed to delete spam_corpus")
+	_, err = db.Exec("DELETE FROM filter_rule")
+	helpers.PanicError(err, "failed to delete filter_rule")
+	_, err = db.Exec("DELETE FROM report")
+	helpers.PanicError(err, "failed to delete report")
+	_, err = db.Exec("DELETE FROM audit_log")
+	helpers.PanicError(err, "failed to delete audit log")
+	_, err = db.Exec("DELETE FROM role_change")
+	helpers.PanicError(err, "failed to delete role change")
+	_, err = db.Exec("DELETE FROM impersonation")
+	helpers.PanicError(err, "failed to delete impersonation")
+	_, err = db.Exec("DELETE FROM revision_policy")
+	helpers.PanicError(err, "failed to delete revision_policy")
+	_, err = db.Exec("DELETE FROM user_suspension")
+	helpers.PanicError(err, "failed to delete user suspension")
 	_, err = db.Exec("DELETE FROM user")
 	helpers.PanicError(err, "failed to delete user")
 	_, err = db.Exec("DELETE FROM role")
@@ -65,16 +105,63 @@ func SetupRouterTest(db *sql.DB) http.Handler {
 	commentController := utils.InitializedCommentController(db, helpers.Validate)
 	categoryController := utils.InitializedCategoryController(db, helpers.Validate)
 	followController := utils.InitializedFollowController(db)
+	exportController := utils.InitializedExportController(db)
+	erasureController := utils.InitializedErasureController(db)
+	suspensionController := utils.InitializedSuspensionController(db, helpers.Validate)
+	auditController := utils.InitializedAuditController(db)
+	statsController := utils.InitializedStatsController(db, helpers.Validate)
+	reportController := utils.InitializedReportController(db, helpers.Validate)
+	filterRuleController := utils.InitializedFilterRuleController(db, helpers.Validate)
+	bulkController := utils.InitializedBulkController(db, helpers.Validate)
+	impersonationController := utils.InitializedImpersonationController(db, helpers.Validate)
+	revisionPolicyController := utils.InitializedRevisionPolicyController(db, helpers.Validate)
+	tagController := utils.InitializedTagController(db, helpers.Validate)
+	searchController := utils.InitializedSearchController(db, helpers.Validate)
 
 	router := routes.Router(&routes.RouterControllers{
-		Admin:    adminController,
-		User:     userController,
-		Post:     postController,
-		Category: categoryController,
-		Role:     roleController,
-		Comment:  commentController,
-		Follow:   followController,
+		Admin:          adminController,
+		User:           userController,
+		Post:           postController,
+		Category:       categoryController,
+		Role:           roleController,
+		Comment:        commentController,
+		Follow:         followController,
+		Export:         exportController,
+		Erasure:        erasureController,
+		Suspension:     suspensionController,
+		Audit:          auditController,
+		Stats:          statsController,
+		Report:         reportController,
+		FilterRule:     filterRuleController,
+		Bulk:           bulkController,
+		Impersonation:  impersonationController,
+		RevisionPolicy: revisionPolicyController,
+		Tag:            tagController,
+		Search:         searchController,
 	})
 
 	return middleware.NewAuthMiddleware(router)
 }
+
+func requestTest(t *testing.T, router http.Handler, method, url, accessToken, body string) (*http.Response, helpers.ResponseJSON) {
+	request := httptest.NewRequest(method, url, strings.NewReader(body))
+	request.Header.Add("Content-Type", "application/json")
+	request.Header.Add("Authorization", "Bearer "+accessToken)
+
+	recorder := httptest.NewRecorder()
+
+	router.ServeHTTP(recorder, request)
+
+	response := recorder.Result()
+
+	responseBodyBytes, err := io.ReadAll(response.Body)
+	helpers.PanicError(err, "failed to read response body")
+
+	var responseBody helpers.ResponseJSON
+
+	if len(responseBodyBytes) > 0 {
+		assert.NoError(t, json.Unmarshal(responseBodyBytes, &responseBody))
+	}
+
+	return response, responseBody
+}
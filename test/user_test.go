@@ -11,9 +11,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/hutamatr/GoBlogify/audit"
 	"github.com/hutamatr/GoBlogify/helpers"
 	"github.com/hutamatr/GoBlogify/role"
 	"github.com/hutamatr/GoBlogify/user"
+	"github.com/hutamatr/GoBlogify/utils"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -23,7 +25,7 @@ func createUserTestUser(db *sql.DB) (user.UserResponse, string) {
 
 	userRepository := user.NewUserRepository()
 	roleRepository := role.NewRoleRepository()
-	userService := user.NewUserService(userRepository, roleRepository, db, helpers.Validate)
+	userService := user.NewUserService(userRepository, roleRepository, audit.NewAuditRepository(), db, helpers.Validate)
 	user, accessToken, _ := userService.SignUp(ctx, user.UserCreateRequest{Username: "userTest", Email: "testing@example.com", Password: "Password123!", Confirm_Password: "Password123!"})
 
 	return user, accessToken
@@ -174,7 +176,7 @@ func TestFindAllUser(t *testing.T) {
 	router := SetupRouterTest(db)
 	defer db.Close()
 
-	createUserTestUser(db)
+	_, userAccessToken := createUserTestUser(db)
 	_, accessToken := createAdminTestAdmin(db)
 
 	t.Run("success find all user", func(t *testing.T) {
@@ -202,6 +204,99 @@ func TestFindAllUser(t *testing.T) {
 		assert.Equal(t, "OK", responseBody.Status)
 	})
 
+	t.Run("success search user", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/users?q=userTest&role=user&sort=followers&order=desc", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, http.StatusOK, responseBody.Code)
+		assert.Equal(t, "OK", responseBody.Status)
+		assert.Equal(t, float64(1), responseBody.Data.(map[string]interface{})["total"])
+		assert.Equal(t, "userTest", responseBody.Data.(map[string]interface{})["users"].([]interface{})[0].(map[string]interface{})["username"])
+	})
+
+	t.Run("failed search user with invalid sort", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/users?sort=unknown", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ErrorResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, http.StatusBadRequest, responseBody.Code)
+		assert.Equal(t, "BAD REQUEST", responseBody.Status)
+	})
+
+	t.Run("success search user as non admin", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/users?q=userTest", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+userAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		found := responseBody.Data.(map[string]interface{})["users"].([]interface{})[0].(map[string]interface{})
+
+		assert.Equal(t, "userTest", found["username"])
+		assert.Equal(t, "", found["email"])
+	})
+
+	t.Run("failed filter deleted users as non admin", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/users?deleted=all", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+userAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	})
+
 	t.Run("not found find all user", func(t *testing.T) {
 		DeleteDBTest(db)
 		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/users", nil)
@@ -424,3 +519,399 @@ func TestDeleteUser(t *testing.T) {
 		assert.Equal(t, "NOT FOUND", responseBody.Status)
 	})
 }
+
+func TestFindByUsernameUser(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	user, accessToken := createUserTestUser(db)
+
+	t.Run("success find by username user", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/@"+user.Username, nil)
+		request.Header.Add("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, http.StatusOK, responseBody.Code)
+		assert.Equal(t, "OK", responseBody.Status)
+		assert.Equal(t, user.Username, responseBody.Data.(map[string]interface{})["username"])
+		assert.Nil(t, responseBody.Data.(map[string]interface{})["email"])
+	})
+
+	t.Run("redirect old username", func(t *testing.T) {
+		accountBody := strings.NewReader(`{
+			"role_id" : ` + strconv.Itoa(user.Role_Id) + `,
+			"username": "userTestRenamed",
+			"first_name" : "test",
+			"last_name" : "testing"
+		}`)
+
+		request := httptest.NewRequest(http.MethodPut, "http://localhost:8080/api/v1/users/"+strconv.Itoa(user.Id), accountBody)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+
+		request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/@"+user.Username, nil)
+		request.Header.Add("Content-Type", "application/json")
+
+		recorder = httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusMovedPermanently, response.StatusCode)
+		assert.Equal(t, "/api/v1/@userTestRenamed", response.Header.Get("Location"))
+	})
+
+	t.Run("failed sign up with reserved username", func(t *testing.T) {
+		accountBody := strings.NewReader(`{
+			"username": "` + user.Username + `",
+			"email": "testing2@example.com",
+			"password": "Password123!",
+			"confirm_password": "Password123!"
+		}`)
+
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/signup", accountBody)
+		request.Header.Add("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ErrorResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, http.StatusBadRequest, responseBody.Code)
+		assert.Equal(t, "username is reserved", responseBody.Error)
+	})
+
+	t.Run("not found find by username user", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/@unknownUser", nil)
+		request.Header.Add("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+}
+
+func TestDeactivateUser(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	user, accessToken := createUserTestUser(db)
+	_, adminAccessToken := createAdminTestAdmin(db)
+	category := createCategoryTestPost(db)
+	post := createPostTestComment(db, user.Id, category.Id)
+
+	t.Run("success deactivate user", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/users/"+strconv.Itoa(user.Id)+"/deactivate", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, http.StatusOK, responseBody.Code)
+		assert.Equal(t, "DEACTIVATED", responseBody.Status)
+	})
+
+	t.Run("deactivated user token is rejected", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/post/"+strconv.Itoa(post.Id), nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+	})
+
+	t.Run("success reactivate user on sign in", func(t *testing.T) {
+		accountBody := strings.NewReader(`{
+			"email": "testing@example.com",
+			"password": "Password123!"
+		}`)
+
+		request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/api/v1/signin", accountBody)
+		request.Header.Add("Content-Type", "application/json")
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, user.Id, int(responseBody.Data.(map[string]interface{})["user"].(map[string]interface{})["id"].(float64)))
+
+		request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/post/"+strconv.Itoa(post.Id), nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder = httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	})
+
+	t.Run("success delete user cascades posts", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodDelete, "http://localhost:8080/api/v1/users/"+strconv.Itoa(user.Id), nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+
+		request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/post/"+strconv.Itoa(post.Id), nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder = httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Result().StatusCode)
+	})
+
+	t.Run("deleted user token is rejected", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/users", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Result().StatusCode)
+	})
+
+	t.Run("success purge accounts past the grace period", func(t *testing.T) {
+		expiredId := createDeactivatedTestUser(db, "purgeExpired", 31)
+		recentId := createDeactivatedTestUser(db, "purgeRecent", 0)
+
+		purged := utils.InitializedAccountPurger(db).RunDue(context.Background())
+
+		assert.Equal(t, 1, purged)
+
+		var isDeleted bool
+
+		err := db.QueryRow("SELECT is_deleted FROM user WHERE id = ?", expiredId).Scan(&isDeleted)
+		helpers.PanicError(err, "failed to query user")
+
+		assert.True(t, isDeleted)
+
+		err = db.QueryRow("SELECT is_deleted FROM user WHERE id = ?", recentId).Scan(&isDeleted)
+		helpers.PanicError(err, "failed to query user")
+
+		assert.False(t, isDeleted)
+	})
+}
+
+func createDeactivatedTestUser(db *sql.DB, username string, daysAgo int) int {
+	userService := user.NewUserService(user.NewUserRepository(), role.NewRoleRepository(), audit.NewAuditRepository(), db, helpers.Validate)
+	deactivated, _, _ := userService.SignUp(context.Background(), user.UserCreateRequest{Username: username, Email: username + "@example.com", Password: "Password123!", Confirm_Password: "Password123!"})
+
+	_, err := db.Exec("UPDATE user SET is_deactivated = true, deactivated_at = NOW() - INTERVAL ? DAY WHERE id = ?", daysAgo, deactivated.Id)
+	helpers.PanicError(err, "failed to deactivate user")
+
+	return deactivated.Id
+}
+
+func TestUpdateUserRole(t *testing.T) {
+	db := ConnectDBTest()
+	DeleteDBTest(db)
+	router := SetupRouterTest(db)
+	defer db.Close()
+
+	newUser, accessToken := createUserTestUser(db)
+	admin, adminAccessToken := createAdminTestAdmin(db)
+
+	t.Run("success promote user to admin", func(t *testing.T) {
+		roleBody := strings.NewReader(`{"role_id": ` + strconv.Itoa(admin.Role_Id) + `}`)
+
+		request := httptest.NewRequest(http.MethodPut, "http://localhost:8080/api/v1/admin/users/"+strconv.Itoa(newUser.Id)+"/role", roleBody)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, "UPDATED", responseBody.Status)
+		assert.Equal(t, float64(admin.Role_Id), responseBody.Data.(map[string]interface{})["role_id"])
+	})
+
+	t.Run("role change revokes issued tokens", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/users/"+strconv.Itoa(newUser.Id), nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+accessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Result().StatusCode)
+	})
+
+	t.Run("success find all role changes", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/admin/users/"+strconv.Itoa(newUser.Id)+"/role-changes", nil)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		roleChange := responseBody.Data.(map[string]interface{})["role_changes"].([]interface{})[0].(map[string]interface{})
+
+		assert.Equal(t, float64(1), responseBody.Data.(map[string]interface{})["total"])
+		assert.Equal(t, "user", roleChange["old_role_name"])
+		assert.Equal(t, "admin", roleChange["new_role_name"])
+		assert.Equal(t, float64(admin.Id), roleChange["admin_id"])
+	})
+
+	t.Run("failed demote last admin", func(t *testing.T) {
+		roleBody := strings.NewReader(`{"role_id": ` + strconv.Itoa(newUser.Role_Id) + `}`)
+
+		request := httptest.NewRequest(http.MethodPut, "http://localhost:8080/api/v1/admin/users/"+strconv.Itoa(newUser.Id)+"/role", roleBody)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+
+		roleBody = strings.NewReader(`{"role_id": ` + strconv.Itoa(newUser.Role_Id) + `}`)
+
+		request = httptest.NewRequest(http.MethodPut, "http://localhost:8080/api/v1/admin/users/"+strconv.Itoa(admin.Id)+"/role", roleBody)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+adminAccessToken)
+
+		recorder = httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		response := recorder.Result()
+
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+
+		body, err := io.ReadAll(response.Body)
+
+		var responseBody helpers.ErrorResponseJSON
+
+		json.Unmarshal(body, &responseBody)
+
+		helpers.PanicError(err, "failed to read response body")
+
+		assert.Equal(t, "cannot demote the last admin", responseBody.Error)
+	})
+
+	t.Run("failed change role as non admin", func(t *testing.T) {
+		_, otherAccessToken, _ := user.NewUserService(user.NewUserRepository(), role.NewRoleRepository(), audit.NewAuditRepository(), db, helpers.Validate).SignIn(context.Background(), user.UserLoginRequest{Email: "testing@example.com", Password: "Password123!"})
+
+		roleBody := strings.NewReader(`{"role_id": ` + strconv.Itoa(admin.Role_Id) + `}`)
+
+		request := httptest.NewRequest(http.MethodPut, "http://localhost:8080/api/v1/admin/users/"+strconv.Itoa(newUser.Id)+"/role", roleBody)
+		request.Header.Add("Content-Type", "application/json")
+		request.Header.Add("Authorization", "Bearer "+otherAccessToken)
+
+		recorder := httptest.NewRecorder()
+
+		router.ServeHTTP(recorder, request)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+	})
+}
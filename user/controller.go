@@ -19,6 +19,10 @@ type UserController interface {
 	UpdateUserHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	DeleteUserHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	GetRefreshTokenHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindByUsernameHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	DeactivateUserHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	UpdateUserRoleHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAllRoleChangesHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 }
 
 type UserControllerImpl struct {
@@ -122,13 +126,44 @@ func (controller *UserControllerImpl) SignOutUserHandler(writer http.ResponseWri
 
 func (controller *UserControllerImpl) FindAllUserHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	isAdmin := helpers.IsAdmin(request)
+	limit, offset := helpers.GetLimitOffset(request)
+	query := request.URL.Query()
 
-	users := controller.service.FindAll(request.Context(), isAdmin)
+	createdFrom, err := helpers.ParseDateQuery(query.Get("created_from"))
+	if err != nil {
+		panic(exception.NewBadRequestError("invalid created_from, use YYYY-MM-DD"))
+	}
+
+	createdTo, err := helpers.ParseDateQuery(query.Get("created_to"))
+	if err != nil {
+		panic(exception.NewBadRequestError("invalid created_to, use YYYY-MM-DD"))
+	}
+
+	if !createdTo.IsZero() {
+		createdTo = createdTo.AddDate(0, 0, 1)
+	}
+
+	filter := UserFilterRequest{
+		Query:        query.Get("q"),
+		Role:         query.Get("role"),
+		Created_From: createdFrom,
+		Created_To:   createdTo,
+		Deleted:      query.Get("deleted"),
+		Sort:         query.Get("sort"),
+		Order:        query.Get("order"),
+	}
+
+	users, countUsers := controller.service.FindAll(request.Context(), filter, limit, offset, isAdmin)
 
 	userResponse := helpers.ResponseJSON{
 		Code:   http.StatusOK,
 		Status: "OK",
-		Data:   users,
+		Data: map[string]interface{}{
+			"users":  users,
+			"limit":  limit,
+			"offset": offset,
+			"total":  countUsers,
+		},
 	}
 
 	writer.WriteHeader(http.StatusOK)
@@ -180,7 +215,10 @@ func (controller *UserControllerImpl) DeleteUserHandler(writer http.ResponseWrit
 	userId, err := strconv.Atoi(id)
 	helpers.PanicError(err, "Invalid User Id")
 
-	controller.service.Delete(request.Context(), userId)
+	callerId := helpers.GetUserId(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	controller.service.Delete(request.Context(), userId, callerId, isAdmin)
 
 	userResponse := helpers.ResponseJSON{
 		Code:   http.StatusOK,
@@ -211,6 +249,8 @@ func (controller *UserControllerImpl) GetRefreshTokenHandler(writer http.Respons
 	idFloat := claims["sub"].(float64)
 	userId := int(idFloat)
 
+	controller.service.VerifyTokenIssuedAt(request.Context(), userId, helpers.TokenIssuedAt(claims))
+
 	accessTokenExpired := helpers.AccessTokenDuration(AppEnv)
 
 	newAccessToken, err := helpers.GenerateToken(userId, accessTokenExpired, accessTokenSecret)
@@ -228,3 +268,109 @@ func (controller *UserControllerImpl) GetRefreshTokenHandler(writer http.Respons
 	writer.WriteHeader(http.StatusOK)
 	helpers.EncodeJSONFromResponse(writer, userResponse)
 }
+
+func (controller *UserControllerImpl) FindByUsernameHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	username := params.ByName("username")
+
+	user := controller.service.FindByUsername(request.Context(), username)
+
+	if user.Username != username {
+		http.Redirect(writer, request, "/api/v1/@"+user.Username, http.StatusMovedPermanently)
+		return
+	}
+
+	userResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   user,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, userResponse)
+}
+
+func (controller *UserControllerImpl) DeactivateUserHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	var env = helpers.NewEnv()
+	var AppEnv = env.App.AppEnv
+
+	id := params.ByName("userId")
+	userId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid User Id")
+
+	callerId := helpers.GetUserId(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	restorableUntil := controller.service.Deactivate(request.Context(), userId, callerId, isAdmin)
+
+	if userId == callerId {
+		cookie := http.Cookie{}
+		cookie.Name = "rt"
+		cookie.Value = ""
+		cookie.MaxAge = -1
+		cookie.Secure = AppEnv == "production"
+		cookie.HttpOnly = true
+		cookie.SameSite = http.SameSiteStrictMode
+		cookie.Expires = time.Now().Add(7 * 24 * time.Hour)
+		http.SetCookie(writer, &cookie)
+	}
+
+	userResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "DEACTIVATED",
+		Data: map[string]interface{}{
+			"restorable_until": restorableUntil,
+		},
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, userResponse)
+}
+
+func (controller *UserControllerImpl) UpdateUserRoleHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	var roleUpdateRequest UserRoleUpdateRequest
+	helpers.DecodeJSONFromRequest(request, &roleUpdateRequest)
+
+	id := params.ByName("userId")
+	userId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid User Id")
+
+	roleUpdateRequest.User_Id = userId
+	roleUpdateRequest.Admin_Id = helpers.GetUserId(request)
+	isAdmin := helpers.IsAdmin(request)
+
+	user := controller.service.UpdateRole(request.Context(), roleUpdateRequest, isAdmin)
+
+	userResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "UPDATED",
+		Data:   user,
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, userResponse)
+}
+
+func (controller *UserControllerImpl) FindAllRoleChangesHandler(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id := params.ByName("userId")
+	userId, err := strconv.Atoi(id)
+	helpers.PanicError(err, "Invalid User Id")
+
+	isAdmin := helpers.IsAdmin(request)
+	limit, offset := helpers.GetLimitOffset(request)
+
+	roleChanges, countRoleChanges := controller.service.FindAllRoleChanges(request.Context(), userId, limit, offset, isAdmin)
+
+	userResponse := helpers.ResponseJSON{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"role_changes": roleChanges,
+			"limit":        limit,
+			"offset":       offset,
+			"total":        countRoleChanges,
+		},
+	}
+
+	writer.WriteHeader(http.StatusOK)
+	helpers.EncodeJSONFromResponse(writer, userResponse)
+}
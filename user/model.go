@@ -2,17 +2,59 @@ package user
 
 import "time"
 
+const (
+	TrustNew     = "new"
+	TrustTrusted = "trusted"
+)
+
 type User struct {
+	Id             int
+	Role_Id        int
+	Username       string
+	Email          string
+	Password       string
+	First_Name     string
+	Last_Name      string
+	Deactivated    bool
+	Created_At     time.Time
+	Updated_At     time.Time
+	Deleted_At     time.Time
+	Deactivated_At time.Time
+	Revoked_At     time.Time
+	Suspension     Suspension
+}
+
+type Suspension struct {
 	Id         int
-	Role_Id    int
-	Username   string
-	Email      string
-	Password   string
-	First_Name string
-	Last_Name  string
+	Reason     string
+	Expires_At time.Time
+}
+
+type Trust struct {
+	User_Id    int
+	Level      string
+	Role_Name  string
 	Created_At time.Time
-	Updated_At time.Time
-	Deleted_At time.Time
+}
+
+// BypassesModeration reports whether posts by this author are published
+// without going through the pre-moderation queue.
+func (trust Trust) BypassesModeration() bool {
+	return trust.Level == TrustTrusted || trust.Role_Name == "admin" || trust.Role_Name == "moderator"
+}
+
+// EarnedPromotion reports whether a new author has reached either the
+// approved posts threshold or the account age threshold.
+func (trust Trust) EarnedPromotion(approvedPosts, minApprovedPosts, minAccountDays int, now time.Time) bool {
+	if trust.Level != TrustNew {
+		return false
+	}
+
+	if approvedPosts >= minApprovedPosts {
+		return true
+	}
+
+	return !trust.Created_At.IsZero() && now.Sub(trust.Created_At) >= time.Duration(minAccountDays)*24*time.Hour
 }
 
 type UserJoin struct {
@@ -29,3 +71,22 @@ type UserJoin struct {
 	Following  int
 	Follower   int
 }
+
+type RoleChange struct {
+	Id            int
+	User_Id       int
+	Admin_Id      int
+	Old_Role_Id   int
+	Old_Role_Name string
+	New_Role_Id   int
+	New_Role_Name string
+	Created_At    time.Time
+}
+
+type UsernameHistory struct {
+	Id             int
+	User_Id        int
+	Username       string
+	Reserved_Until time.Time
+	Created_At     time.Time
+}
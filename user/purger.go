@@ -0,0 +1,54 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/helpers"
+)
+
+type AccountPurger interface {
+	Start(ctx context.Context)
+	RunDue(ctx context.Context) int
+}
+
+type AccountPurgerImpl struct {
+	repository      UserRepository
+	auditRepository audit.AuditRepository
+	job             helpers.BatchJob
+	graceDays       int
+}
+
+func NewAccountPurger(userRepository UserRepository, auditRepository audit.AuditRepository, db *sql.DB) AccountPurger {
+	return &AccountPurgerImpl{
+		repository:      userRepository,
+		auditRepository: auditRepository,
+		job:             helpers.NewBatchJob("account purger", db),
+		graceDays:       helpers.EnvInt(helpers.NewEnv().Account.DeactivationGraceDays, 30),
+	}
+}
+
+func (purger *AccountPurgerImpl) Start(ctx context.Context) {
+	purger.job.Start(ctx, func(ctx context.Context) {
+		purger.RunDue(ctx)
+	})
+}
+
+// RunDue permanently deletes the accounts that were deactivated and not
+// reactivated within the grace period, the same way Delete does, and returns
+// how many it deleted.
+func (purger *AccountPurgerImpl) RunDue(ctx context.Context) int {
+	return helpers.RunBatches(ctx, purger.job, purger.findExpired, purger.purge)
+}
+
+func (purger *AccountPurgerImpl) findExpired(ctx context.Context, tx *sql.Tx, limit int) []int {
+	return purger.repository.FindExpiredDeactivated(ctx, tx, purger.graceDays, limit)
+}
+
+func (purger *AccountPurgerImpl) purge(ctx context.Context, tx *sql.Tx, userId int) {
+	purger.repository.DeleteRelations(ctx, tx, userId)
+	purger.repository.Delete(ctx, tx, userId)
+
+	purger.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionUserPurge, audit.TargetUser, userId, nil, nil))
+}
@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/hutamatr/GoBlogify/exception"
@@ -11,11 +12,27 @@ import (
 
 type UserRepository interface {
 	Save(ctx context.Context, tx *sql.Tx, user User) UserJoin
-	FindAll(ctx context.Context, tx *sql.Tx) []UserJoin
+	FindAll(ctx context.Context, tx *sql.Tx, filter UserFilterRequest, limit, offset int) []UserJoin
+	CountAll(ctx context.Context, tx *sql.Tx, filter UserFilterRequest) int
 	FindOne(ctx context.Context, tx *sql.Tx, userId int, email string) UserJoin
 	Update(ctx context.Context, tx *sql.Tx, user UserJoin) UserJoin
 	Delete(ctx context.Context, tx *sql.Tx, userId int)
 	FindPassword(ctx context.Context, tx *sql.Tx, email string) string
+	FindByUsername(ctx context.Context, tx *sql.Tx, username string) UserJoin
+	SaveUsernameHistory(ctx context.Context, tx *sql.Tx, history UsernameHistory)
+	FindUsernameHistory(ctx context.Context, tx *sql.Tx, username string) UsernameHistory
+	FindAccountStatus(ctx context.Context, tx *sql.Tx, userId int, email string) User
+	Deactivate(ctx context.Context, tx *sql.Tx, userId int)
+	Reactivate(ctx context.Context, tx *sql.Tx, userId int)
+	DeleteRelations(ctx context.Context, tx *sql.Tx, userId int)
+	FindExpiredDeactivated(ctx context.Context, tx *sql.Tx, graceDays, limit int) []int
+	UpdateRole(ctx context.Context, tx *sql.Tx, userId, roleId int)
+	CountByRoleForUpdate(ctx context.Context, tx *sql.Tx, roleId int) int
+	SaveRoleChange(ctx context.Context, tx *sql.Tx, roleChange RoleChange)
+	FindAllRoleChanges(ctx context.Context, tx *sql.Tx, userId, limit, offset int) []RoleChange
+	CountRoleChanges(ctx context.Context, tx *sql.Tx, userId int) int
+	FindTrust(ctx context.Context, tx *sql.Tx, userId int) Trust
+	UpdateTrustLevel(ctx context.Context, tx *sql.Tx, userId int, level string)
 }
 
 type UserRepositoryImpl struct {
@@ -41,13 +58,33 @@ func (repository *UserRepositoryImpl) Save(ctx context.Context, tx *sql.Tx, user
 	return newUser
 }
 
-func (repository *UserRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) []UserJoin {
+func (repository *UserRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, filter UserFilterRequest, limit, offset int) []UserJoin {
+	where, args := userFilterQuery(filter)
+
+	sortColumns := map[string]string{
+		"followers": "follower_count",
+		"posts":     "(SELECT COUNT(*) FROM post p WHERE p.user_id = u.id AND p.is_deleted = false)",
+		"joined":    "u.created_at",
+	}
+
+	sortColumn, ok := sortColumns[filter.Sort]
+	if !ok {
+		sortColumn = "u.created_at"
+	}
+
+	order := "DESC"
+	if filter.Order == "asc" {
+		order = "ASC"
+	}
+
 	query := `SELECT u.id, u.username, u.email, u.first_name, u.last_name, u.role_id, u.created_at, u.updated_at, u.deleted_at,
-	(SELECT COUNT(*) FROM follow f WHERE f.followed_id = u.id) AS follower_count,
-	(SELECT COUNT(*) FROM follow f WHERE f.follower_id = u.id) AS following_count
-	FROM user u WHERE u.is_deleted = false LIMIT 10`
+	(SELECT COUNT(*) FROM follow f JOIN user fu ON fu.id = f.follower_id WHERE f.followed_id = u.id AND fu.is_deleted = false AND fu.is_deactivated = false) AS follower_count,
+	(SELECT COUNT(*) FROM follow f JOIN user fu ON fu.id = f.followed_id WHERE f.follower_id = u.id AND fu.is_deleted = false AND fu.is_deactivated = false) AS following_count
+	FROM user u WHERE ` + where + ` ORDER BY ` + sortColumn + ` ` + order + `, u.id ` + order + ` LIMIT ? OFFSET ?`
+
+	args = append(args, limit, offset)
 
-	rows, err := tx.QueryContext(ctx, query)
+	rows, err := tx.QueryContext(ctx, query, args...)
 
 	helpers.PanicError(err, "failed to query all users")
 
@@ -89,23 +126,85 @@ func (repository *UserRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) [
 	return users
 }
 
+func (repository *UserRepositoryImpl) CountAll(ctx context.Context, tx *sql.Tx, filter UserFilterRequest) int {
+	where, args := userFilterQuery(filter)
+
+	query := "SELECT COUNT(*) FROM user u WHERE " + where
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	helpers.PanicError(err, "failed to query count users")
+
+	defer rows.Close()
+
+	var countUsers int
+
+	if rows.Next() {
+		err := rows.Scan(&countUsers)
+		helpers.PanicError(err, "failed to scan count users")
+	}
+
+	return countUsers
+}
+
+func userFilterQuery(filter UserFilterRequest) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	switch filter.Deleted {
+	case "true":
+		conditions = append(conditions, "u.is_deleted = true")
+	case "deactivated":
+		conditions = append(conditions, "u.is_deleted = false AND u.is_deactivated = true")
+	case "all":
+	default:
+		conditions = append(conditions, "u.is_deleted = false AND u.is_deactivated = false")
+	}
+
+	if filter.Query != "" {
+		keyword := helpers.LikePattern(filter.Query)
+		conditions = append(conditions, "(u.username LIKE ? OR u.first_name LIKE ? OR u.last_name LIKE ? OR CONCAT_WS(' ', u.first_name, u.last_name) LIKE ?)")
+		args = append(args, keyword, keyword, keyword, keyword)
+	}
+
+	if filter.Role != "" {
+		conditions = append(conditions, "u.role_id = (SELECT r.id FROM role r WHERE r.name = ?)")
+		args = append(args, filter.Role)
+	}
+
+	if !filter.Created_From.IsZero() {
+		conditions = append(conditions, "u.created_at >= ?")
+		args = append(args, filter.Created_From)
+	}
+
+	if !filter.Created_To.IsZero() {
+		conditions = append(conditions, "u.created_at < ?")
+		args = append(args, filter.Created_To)
+	}
+
+	if len(conditions) == 0 {
+		return "true", args
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
 func (repository *UserRepositoryImpl) FindOne(ctx context.Context, tx *sql.Tx, userId int, email string) UserJoin {
 	var rows *sql.Rows
 	var err error
 
 	if userId > 0 {
 		query := `SELECT u.id, u.username, u.email, u.first_name, u.last_name, u.role_id, u.created_at, u.updated_at, u.deleted_at,
-		(SELECT COUNT(*) FROM follow f WHERE f.followed_id = u.id) AS follower_count,
-		(SELECT COUNT(*) FROM follow f WHERE f.follower_id = u.id) AS following_count
-		FROM user u WHERE u.id = ? AND u.is_deleted = false`
+		(SELECT COUNT(*) FROM follow f JOIN user fu ON fu.id = f.follower_id WHERE f.followed_id = u.id AND fu.is_deleted = false AND fu.is_deactivated = false) AS follower_count,
+		(SELECT COUNT(*) FROM follow f JOIN user fu ON fu.id = f.followed_id WHERE f.follower_id = u.id AND fu.is_deleted = false AND fu.is_deactivated = false) AS following_count
+		FROM user u WHERE u.id = ? AND u.is_deleted = false AND u.is_deactivated = false`
 
 		rows, err = tx.QueryContext(ctx, query, userId)
 		helpers.PanicError(err, "failed to query one user")
 	} else if email != "" {
 		query := `SELECT u.id, u.username, u.email, u.first_name, u.last_name, u.role_id, u.created_at, u.updated_at, u.deleted_at,
-		(SELECT COUNT(*) FROM follow f WHERE f.followed_id = u.id) AS follower_count,
-		(SELECT COUNT(*) FROM follow f WHERE f.follower_id = u.id) AS following_count
-		FROM user u WHERE u.email = ? AND u.is_deleted = false`
+		(SELECT COUNT(*) FROM follow f JOIN user fu ON fu.id = f.follower_id WHERE f.followed_id = u.id AND fu.is_deleted = false AND fu.is_deactivated = false) AS follower_count,
+		(SELECT COUNT(*) FROM follow f JOIN user fu ON fu.id = f.followed_id WHERE f.follower_id = u.id AND fu.is_deleted = false AND fu.is_deactivated = false) AS following_count
+		FROM user u WHERE u.email = ? AND u.is_deleted = false AND u.is_deactivated = false`
 
 		rows, err = tx.QueryContext(ctx, query, email)
 		helpers.PanicError(err, "failed to query one user")
@@ -173,7 +272,7 @@ func (repository *UserRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, us
 }
 
 func (repository *UserRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, userId int) {
-	query := "UPDATE user SET deleted_at = NOW(), is_deleted = true WHERE id = ?"
+	query := "UPDATE user SET deleted_at = NOW(), is_deleted = true, tokens_revoked_at = NOW(6) WHERE id = ?"
 	_, err := tx.ExecContext(ctx, query, userId)
 	helpers.PanicError(err, "failed to exec query delete user")
 }
@@ -196,3 +295,289 @@ func (repository *UserRepositoryImpl) FindPassword(ctx context.Context, tx *sql.
 
 	return password
 }
+
+func (repository *UserRepositoryImpl) FindByUsername(ctx context.Context, tx *sql.Tx, username string) UserJoin {
+	query := `SELECT u.id, u.username, u.email, u.first_name, u.last_name, u.role_id, u.created_at, u.updated_at, u.deleted_at,
+	(SELECT COUNT(*) FROM follow f JOIN user fu ON fu.id = f.follower_id WHERE f.followed_id = u.id AND fu.is_deleted = false AND fu.is_deactivated = false) AS follower_count,
+	(SELECT COUNT(*) FROM follow f JOIN user fu ON fu.id = f.followed_id WHERE f.follower_id = u.id AND fu.is_deleted = false AND fu.is_deactivated = false) AS following_count
+	FROM user u WHERE u.username = ? AND u.is_deleted = false AND u.is_deactivated = false`
+
+	rows, err := tx.QueryContext(ctx, query, username)
+	helpers.PanicError(err, "failed to query user by username")
+
+	defer rows.Close()
+
+	var user UserJoin
+
+	var deletedAt sql.NullTime
+	var firstName sql.NullString
+	var lastName sql.NullString
+
+	if rows.Next() {
+		err := rows.Scan(&user.Id, &user.Username, &user.Email, &firstName, &lastName, &user.Role_Id, &user.Created_At, &user.Updated_At, &deletedAt, &user.Follower, &user.Following)
+
+		helpers.PanicError(err, "failed to scan user by username")
+
+		if deletedAt.Valid {
+			user.Deleted_At = deletedAt.Time
+		} else {
+			user.Deleted_At = time.Time{}
+		}
+
+		if firstName.Valid {
+			user.First_Name = firstName.String
+		} else {
+			user.First_Name = ""
+		}
+
+		if lastName.Valid {
+			user.Last_Name = lastName.String
+		} else {
+			user.Last_Name = ""
+		}
+	}
+
+	return user
+}
+
+func (repository *UserRepositoryImpl) SaveUsernameHistory(ctx context.Context, tx *sql.Tx, history UsernameHistory) {
+	query := "INSERT INTO username_history(user_id, username, reserved_until) VALUES (?, ?, ?)"
+
+	_, err := tx.ExecContext(ctx, query, history.User_Id, history.Username, history.Reserved_Until)
+	helpers.PanicError(err, "failed to exec query insert username history")
+}
+
+func (repository *UserRepositoryImpl) FindUsernameHistory(ctx context.Context, tx *sql.Tx, username string) UsernameHistory {
+	query := `SELECT h.id, h.user_id, h.username, h.reserved_until, h.created_at
+	FROM username_history h
+	JOIN user u
+	ON u.id = h.user_id
+	WHERE h.username = ? AND u.is_deleted = false
+	ORDER BY h.created_at DESC, h.id DESC LIMIT 1`
+
+	rows, err := tx.QueryContext(ctx, query, username)
+	helpers.PanicError(err, "failed to query username history")
+
+	defer rows.Close()
+
+	var history UsernameHistory
+
+	if rows.Next() {
+		err := rows.Scan(&history.Id, &history.User_Id, &history.Username, &history.Reserved_Until, &history.Created_At)
+		helpers.PanicError(err, "failed to scan username history")
+	}
+
+	return history
+}
+
+func (repository *UserRepositoryImpl) FindAccountStatus(ctx context.Context, tx *sql.Tx, userId int, email string) User {
+	query := `SELECT u.id, u.is_deactivated, u.deactivated_at, u.tokens_revoked_at, s.id, s.reason, s.expires_at
+		FROM user u
+		LEFT JOIN user_suspension s ON s.id = (
+			SELECT us.id FROM user_suspension us
+			WHERE us.user_id = u.id AND us.lifted_at IS NULL AND (us.expires_at IS NULL OR us.expires_at > NOW())
+			ORDER BY us.expires_at IS NULL DESC, us.expires_at DESC LIMIT 1
+		)
+		WHERE (u.id = ? OR u.email = ?) AND u.is_deleted = false`
+
+	rows, err := tx.QueryContext(ctx, query, userId, email)
+	helpers.PanicError(err, "failed to query account status")
+
+	defer rows.Close()
+
+	var user User
+	var deactivatedAt sql.NullTime
+	var revokedAt sql.NullTime
+	var suspensionId sql.NullInt64
+	var suspensionReason sql.NullString
+	var suspensionExpiresAt sql.NullTime
+
+	if rows.Next() {
+		err := rows.Scan(&user.Id, &user.Deactivated, &deactivatedAt, &revokedAt, &suspensionId, &suspensionReason, &suspensionExpiresAt)
+		helpers.PanicError(err, "failed to scan account status")
+
+		if deactivatedAt.Valid {
+			user.Deactivated_At = deactivatedAt.Time
+		} else {
+			user.Deactivated_At = time.Time{}
+		}
+
+		if revokedAt.Valid {
+			user.Revoked_At = revokedAt.Time
+		} else {
+			user.Revoked_At = time.Time{}
+		}
+
+		if suspensionId.Valid {
+			user.Suspension.Id = int(suspensionId.Int64)
+			user.Suspension.Reason = suspensionReason.String
+		}
+
+		if suspensionExpiresAt.Valid {
+			user.Suspension.Expires_At = suspensionExpiresAt.Time
+		}
+	}
+
+	return user
+}
+
+func (repository *UserRepositoryImpl) Deactivate(ctx context.Context, tx *sql.Tx, userId int) {
+	query := "UPDATE user SET is_deactivated = true, deactivated_at = NOW() WHERE id = ? AND is_deleted = false"
+	_, err := tx.ExecContext(ctx, query, userId)
+	helpers.PanicError(err, "failed to exec query deactivate user")
+}
+
+func (repository *UserRepositoryImpl) Reactivate(ctx context.Context, tx *sql.Tx, userId int) {
+	query := "UPDATE user SET is_deactivated = false, deactivated_at = NULL WHERE id = ? AND is_deleted = false"
+	_, err := tx.ExecContext(ctx, query, userId)
+	helpers.PanicError(err, "failed to exec query reactivate user")
+}
+
+func (repository *UserRepositoryImpl) DeleteRelations(ctx context.Context, tx *sql.Tx, userId int) {
+	queryComment := `UPDATE comment SET is_deleted = true, deleted_at = NOW() 
+	WHERE is_deleted = false 
+	AND (user_id = ? OR post_id IN (SELECT id FROM post WHERE user_id = ?))`
+	_, err := tx.ExecContext(ctx, queryComment, userId, userId)
+	helpers.PanicError(err, "failed to exec query delete user comments")
+
+	queryPost := "UPDATE post SET is_deleted = true, deleted_at = NOW() WHERE user_id = ? AND is_deleted = false"
+	_, err = tx.ExecContext(ctx, queryPost, userId)
+	helpers.PanicError(err, "failed to exec query delete user posts")
+
+	queryFollow := "DELETE FROM follow WHERE follower_id = ? OR followed_id = ?"
+	_, err = tx.ExecContext(ctx, queryFollow, userId, userId)
+	helpers.PanicError(err, "failed to exec query delete user follows")
+}
+
+// FindExpiredDeactivated locks the accounts whose deactivation grace period
+// has run out. Rows another instance is already purging are skipped.
+func (repository *UserRepositoryImpl) FindExpiredDeactivated(ctx context.Context, tx *sql.Tx, graceDays, limit int) []int {
+	query := `SELECT id FROM user 
+	WHERE is_deactivated = true AND is_deleted = false AND deactivated_at < NOW() - INTERVAL ? DAY 
+	ORDER BY deactivated_at ASC, id ASC LIMIT ? FOR UPDATE SKIP LOCKED`
+
+	rows, err := tx.QueryContext(ctx, query, graceDays, limit)
+	helpers.PanicError(err, "failed to query expired deactivated users")
+
+	defer rows.Close()
+
+	var userIds []int
+
+	for rows.Next() {
+		var userId int
+		err := rows.Scan(&userId)
+		helpers.PanicError(err, "failed to scan expired deactivated users")
+
+		userIds = append(userIds, userId)
+	}
+
+	return userIds
+}
+
+func (repository *UserRepositoryImpl) UpdateRole(ctx context.Context, tx *sql.Tx, userId, roleId int) {
+	query := "UPDATE user SET role_id = ?, tokens_revoked_at = NOW(6) WHERE id = ? AND is_deleted = false"
+	_, err := tx.ExecContext(ctx, query, roleId, userId)
+	helpers.PanicError(err, "failed to exec query update user role")
+}
+
+// CountByRoleForUpdate locks the active users of the role until the
+// transaction ends, so concurrent role changes count them one at a time.
+func (repository *UserRepositoryImpl) CountByRoleForUpdate(ctx context.Context, tx *sql.Tx, roleId int) int {
+	query := "SELECT COUNT(*) FROM user WHERE role_id = ? AND is_deleted = false AND is_deactivated = false FOR UPDATE"
+
+	rows, err := tx.QueryContext(ctx, query, roleId)
+	helpers.PanicError(err, "failed to query count user by role")
+
+	defer rows.Close()
+
+	var countUsers int
+
+	if rows.Next() {
+		err := rows.Scan(&countUsers)
+		helpers.PanicError(err, "failed to scan count user by role")
+	}
+
+	return countUsers
+}
+
+func (repository *UserRepositoryImpl) SaveRoleChange(ctx context.Context, tx *sql.Tx, roleChange RoleChange) {
+	query := "INSERT INTO role_change(user_id, admin_id, old_role_id, old_role_name, new_role_id, new_role_name) VALUES (?, ?, ?, ?, ?, ?)"
+	_, err := tx.ExecContext(ctx, query, roleChange.User_Id, roleChange.Admin_Id, roleChange.Old_Role_Id, roleChange.Old_Role_Name, roleChange.New_Role_Id, roleChange.New_Role_Name)
+	helpers.PanicError(err, "failed to exec query insert role change")
+}
+
+func (repository *UserRepositoryImpl) FindAllRoleChanges(ctx context.Context, tx *sql.Tx, userId, limit, offset int) []RoleChange {
+	query := "SELECT id, user_id, admin_id, old_role_id, old_role_name, new_role_id, new_role_name, created_at FROM role_change WHERE user_id = ? ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?"
+
+	rows, err := tx.QueryContext(ctx, query, userId, limit, offset)
+	helpers.PanicError(err, "failed to query role changes")
+
+	defer rows.Close()
+
+	var roleChanges []RoleChange
+
+	for rows.Next() {
+		var roleChange RoleChange
+		err := rows.Scan(&roleChange.Id, &roleChange.User_Id, &roleChange.Admin_Id, &roleChange.Old_Role_Id, &roleChange.Old_Role_Name, &roleChange.New_Role_Id, &roleChange.New_Role_Name, &roleChange.Created_At)
+		helpers.PanicError(err, "failed to scan role change")
+
+		roleChanges = append(roleChanges, roleChange)
+	}
+
+	return roleChanges
+}
+
+func (repository *UserRepositoryImpl) CountRoleChanges(ctx context.Context, tx *sql.Tx, userId int) int {
+	query := "SELECT COUNT(*) FROM role_change WHERE user_id = ?"
+
+	rows, err := tx.QueryContext(ctx, query, userId)
+	helpers.PanicError(err, "failed to query count role changes")
+
+	defer rows.Close()
+
+	var countRoleChanges int
+
+	if rows.Next() {
+		err := rows.Scan(&countRoleChanges)
+		helpers.PanicError(err, "failed to scan count role changes")
+	}
+
+	return countRoleChanges
+}
+
+func (repository *UserRepositoryImpl) FindTrust(ctx context.Context, tx *sql.Tx, userId int) Trust {
+	query := `SELECT u.id, u.trust_level, r.name, u.created_at
+		FROM user u
+		LEFT JOIN role r ON r.id = u.role_id
+		WHERE u.id = ? AND u.is_deleted = false`
+
+	rows, err := tx.QueryContext(ctx, query, userId)
+	helpers.PanicError(err, "failed to query user trust")
+
+	defer rows.Close()
+
+	var trust Trust
+	var roleName sql.NullString
+
+	if rows.Next() {
+		err := rows.Scan(&trust.User_Id, &trust.Level, &roleName, &trust.Created_At)
+		helpers.PanicError(err, "failed to scan user trust")
+
+		if roleName.Valid {
+			trust.Role_Name = roleName.String
+		} else {
+			trust.Role_Name = ""
+		}
+	} else {
+		panic(exception.NewNotFoundError("user not found"))
+	}
+
+	return trust
+}
+
+func (repository *UserRepositoryImpl) UpdateTrustLevel(ctx context.Context, tx *sql.Tx, userId int, level string) {
+	query := "UPDATE user SET trust_level = ? WHERE id = ?"
+
+	_, err := tx.ExecContext(ctx, query, level, userId)
+	helpers.PanicError(err, "failed to exec query update user trust level")
+}
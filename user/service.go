@@ -3,9 +3,11 @@ package user
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/hutamatr/GoBlogify/audit"
 	"github.com/hutamatr/GoBlogify/exception"
 	"github.com/hutamatr/GoBlogify/helpers"
 	"github.com/hutamatr/GoBlogify/role"
@@ -15,25 +17,32 @@ import (
 type UserService interface {
 	SignUp(ctx context.Context, request UserCreateRequest) (UserResponse, string, string)
 	SignIn(ctx context.Context, request UserLoginRequest) (UserResponse, string, string)
-	FindAll(ctx context.Context, isAdmin bool) []UserResponse
+	FindAll(ctx context.Context, filter UserFilterRequest, limit, offset int, isAdmin bool) ([]UserResponse, int)
 	FindById(ctx context.Context, userId int) UserResponse
 	Update(ctx context.Context, request UserUpdateRequest) UserResponse
-	Delete(ctx context.Context, userId int)
+	Delete(ctx context.Context, userId, callerId int, isAdmin bool)
+	Deactivate(ctx context.Context, userId, callerId int, isAdmin bool) time.Time
+	FindByUsername(ctx context.Context, username string) UserProfileResponse
+	UpdateRole(ctx context.Context, request UserRoleUpdateRequest, isAdmin bool) UserResponse
+	FindAllRoleChanges(ctx context.Context, userId, limit, offset int, isAdmin bool) ([]RoleChangeResponse, int)
+	VerifyTokenIssuedAt(ctx context.Context, userId int, issuedAt time.Time)
 }
 
 type UserServiceImpl struct {
-	userRepository UserRepository
-	roleRepository role.RoleRepository
-	DB             *sql.DB
-	Validator      *validator.Validate
+	userRepository  UserRepository
+	roleRepository  role.RoleRepository
+	auditRepository audit.AuditRepository
+	DB              *sql.DB
+	Validator       *validator.Validate
 }
 
-func NewUserService(userRepository UserRepository, roleRepository role.RoleRepository, db *sql.DB, validator *validator.Validate) UserService {
+func NewUserService(userRepository UserRepository, roleRepository role.RoleRepository, auditRepository audit.AuditRepository, db *sql.DB, validator *validator.Validate) UserService {
 	return &UserServiceImpl{
-		userRepository: userRepository,
-		roleRepository: roleRepository,
-		DB:             db,
-		Validator:      validator,
+		userRepository:  userRepository,
+		roleRepository:  roleRepository,
+		auditRepository: auditRepository,
+		DB:              db,
+		Validator:       validator,
 	}
 }
 
@@ -56,6 +65,8 @@ func (service *UserServiceImpl) SignUp(ctx context.Context, request UserCreateRe
 		panic(exception.NewBadRequestError("email already exist"))
 	}
 
+	service.checkUsernameAvailable(ctx, tx, request.Username, 0)
+
 	userRole := service.roleRepository.FindByName(ctx, tx, "user")
 
 	if userRole.Name != "user" {
@@ -109,6 +120,8 @@ func (service *UserServiceImpl) SignIn(ctx context.Context, request UserLoginReq
 		panic(exception.NewBadRequestError("invalid email or password"))
 	}
 
+	CheckSignIn(ctx, tx, service.userRepository, request.Email)
+
 	user := service.userRepository.FindOne(ctx, tx, 0, request.Email)
 
 	accessTokenExpired := helpers.AccessTokenDuration(appEnv)
@@ -122,6 +135,27 @@ func (service *UserServiceImpl) SignIn(ctx context.Context, request UserLoginReq
 	return ToUserResponse(user), accessToken, refreshToken
 }
 
+// CheckSignIn makes the checks every sign-in route runs once the password
+// matched: suspended accounts are turned away and accounts deactivated within
+// the grace period are reactivated.
+func CheckSignIn(ctx context.Context, tx *sql.Tx, repository UserRepository, email string) {
+	status := repository.FindAccountStatus(ctx, tx, 0, email)
+
+	if status.Suspension.Id > 0 {
+		panic(exception.NewUnauthorizedError(helpers.SuspensionMessage(status.Suspension.Reason, status.Suspension.Expires_At)))
+	}
+
+	if status.Deactivated {
+		graceDays := helpers.EnvInt(helpers.NewEnv().Account.DeactivationGraceDays, 30)
+
+		if time.Now().After(status.Deactivated_At.AddDate(0, 0, graceDays)) {
+			panic(exception.NewUnauthorizedError("account was deactivated and the grace period has expired"))
+		}
+
+		repository.Reactivate(ctx, tx, status.Id)
+	}
+}
+
 func (service *UserServiceImpl) FindById(ctx context.Context, userId int) UserResponse {
 	tx, err := service.DB.Begin()
 	helpers.PanicError(err, "failed to begin transaction")
@@ -132,16 +166,22 @@ func (service *UserServiceImpl) FindById(ctx context.Context, userId int) UserRe
 	return ToUserResponse(user)
 }
 
-func (service *UserServiceImpl) FindAll(ctx context.Context, isAdmin bool) []UserResponse {
-	if !isAdmin {
-		panic(exception.NewBadRequestError("only admin can get all users"))
+// FindAll lets anyone search active users. Deleted and deactivated accounts
+// and email addresses are only shown to admins.
+func (service *UserServiceImpl) FindAll(ctx context.Context, filter UserFilterRequest, limit, offset int, isAdmin bool) ([]UserResponse, int) {
+	if filter.Deleted != "" && filter.Deleted != "false" && !isAdmin {
+		panic(exception.NewBadRequestError("only admin can filter users by deleted status"))
 	}
 
+	err := service.Validator.Struct(filter)
+	helpers.PanicError(err, "invalid request")
+
 	tx, err := service.DB.Begin()
 	helpers.PanicError(err, "failed to begin transaction")
 	defer helpers.TxRollbackCommit(tx)
 
-	users := service.userRepository.FindAll(ctx, tx)
+	users := service.userRepository.FindAll(ctx, tx, filter, limit, offset)
+	countUsers := service.userRepository.CountAll(ctx, tx, filter)
 
 	var usersData []UserResponse
 
@@ -150,10 +190,16 @@ func (service *UserServiceImpl) FindAll(ctx context.Context, isAdmin bool) []Use
 	}
 
 	for _, user := range users {
-		usersData = append(usersData, ToUserResponse(user))
+		userData := ToUserResponse(user)
+
+		if !isAdmin {
+			userData.Email = ""
+		}
+
+		usersData = append(usersData, userData)
 	}
 
-	return usersData
+	return usersData, countUsers
 }
 
 func (service *UserServiceImpl) Update(ctx context.Context, request UserUpdateRequest) UserResponse {
@@ -166,6 +212,28 @@ func (service *UserServiceImpl) Update(ctx context.Context, request UserUpdateRe
 
 	user := service.userRepository.FindOne(ctx, tx, request.Id, "")
 
+	if user.Id <= 0 {
+		panic(exception.NewNotFoundError("user not found"))
+	}
+
+	if request.Role_Id != user.Role_Id {
+		panic(exception.NewBadRequestError("role can only be changed by an admin"))
+	}
+
+	if user.Username != request.Username {
+		service.checkUsernameAvailable(ctx, tx, request.Username, user.Id)
+
+		cooldownDays := helpers.EnvInt(helpers.NewEnv().Account.UsernameCooldownDays, 30)
+
+		history := UsernameHistory{
+			User_Id:        user.Id,
+			Username:       user.Username,
+			Reserved_Until: time.Now().AddDate(0, 0, cooldownDays),
+		}
+
+		service.userRepository.SaveUsernameHistory(ctx, tx, history)
+	}
+
 	user.Username = request.Username
 	user.First_Name = request.First_Name
 	user.Last_Name = request.Last_Name
@@ -175,16 +243,181 @@ func (service *UserServiceImpl) Update(ctx context.Context, request UserUpdateRe
 	return ToUserResponse(updatedUser)
 }
 
-func (service *UserServiceImpl) Delete(ctx context.Context, userId int) {
+func (service *UserServiceImpl) Delete(ctx context.Context, userId, callerId int, isAdmin bool) {
 	tx, err := service.DB.Begin()
 	helpers.PanicError(err, "failed to begin transaction")
 	defer helpers.TxRollbackCommit(tx)
 
-	user := service.userRepository.FindOne(ctx, tx, userId, "")
+	user := service.userRepository.FindAccountStatus(ctx, tx, userId, "")
 
 	if user.Id <= 0 {
 		panic(exception.NewNotFoundError("user not found"))
 	}
 
+	if user.Id != callerId && !isAdmin {
+		panic(exception.NewBadRequestError("only the account owner or admin can delete this account"))
+	}
+
+	var before interface{}
+	if deletedUser := service.userRepository.FindOne(ctx, tx, user.Id, ""); deletedUser.Id > 0 {
+		before = ToUserAuditResponse(deletedUser)
+	}
+
+	service.userRepository.DeleteRelations(ctx, tx, user.Id)
 	service.userRepository.Delete(ctx, tx, user.Id)
+
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionUserDelete, audit.TargetUser, user.Id, before, nil))
+}
+
+func (service *UserServiceImpl) Deactivate(ctx context.Context, userId, callerId int, isAdmin bool) time.Time {
+	tx, err := service.DB.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	user := service.userRepository.FindOne(ctx, tx, userId, "")
+
+	if user.Id <= 0 {
+		panic(exception.NewNotFoundError("user not found"))
+	}
+
+	if user.Id != callerId && !isAdmin {
+		panic(exception.NewBadRequestError("only the account owner or admin can deactivate this account"))
+	}
+
+	service.userRepository.Deactivate(ctx, tx, user.Id)
+
+	graceDays := helpers.EnvInt(helpers.NewEnv().Account.DeactivationGraceDays, 30)
+
+	return time.Now().AddDate(0, 0, graceDays)
+}
+
+func (service *UserServiceImpl) FindByUsername(ctx context.Context, username string) UserProfileResponse {
+	tx, err := service.DB.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	user := service.userRepository.FindByUsername(ctx, tx, username)
+
+	if user.Id > 0 {
+		return ToUserProfileResponse(user)
+	}
+
+	history := service.userRepository.FindUsernameHistory(ctx, tx, username)
+
+	if history.Id <= 0 {
+		panic(exception.NewNotFoundError("user not found"))
+	}
+
+	user = service.userRepository.FindOne(ctx, tx, history.User_Id, "")
+
+	if user.Id <= 0 {
+		panic(exception.NewNotFoundError("user not found"))
+	}
+
+	return ToUserProfileResponse(user)
+}
+
+func (service *UserServiceImpl) checkUsernameAvailable(ctx context.Context, tx *sql.Tx, username string, userId int) {
+	if username == "deleted-user" || strings.HasPrefix(username, "erased-") {
+		panic(exception.NewBadRequestError("username is reserved"))
+	}
+
+	existingUser := service.userRepository.FindByUsername(ctx, tx, username)
+
+	if existingUser.Id > 0 && existingUser.Id != userId {
+		panic(exception.NewBadRequestError("username already exist"))
+	}
+
+	history := service.userRepository.FindUsernameHistory(ctx, tx, username)
+
+	if history.Id > 0 && history.User_Id != userId && history.Reserved_Until.After(time.Now()) {
+		panic(exception.NewBadRequestError("username is reserved"))
+	}
+}
+
+func (service *UserServiceImpl) UpdateRole(ctx context.Context, request UserRoleUpdateRequest, isAdmin bool) UserResponse {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can change user role"))
+	}
+
+	err := service.Validator.Struct(request)
+	helpers.PanicError(err, "invalid request")
+
+	tx, err := service.DB.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	user := service.userRepository.FindOne(ctx, tx, request.User_Id, "")
+
+	if user.Id <= 0 {
+		panic(exception.NewNotFoundError("user not found"))
+	}
+
+	newRole := service.roleRepository.FindById(ctx, tx, request.Role_Id)
+
+	if user.Role_Id == newRole.Id {
+		return ToUserResponse(user)
+	}
+
+	oldRole := service.roleRepository.FindById(ctx, tx, user.Role_Id)
+
+	if oldRole.Name == "admin" && service.userRepository.CountByRoleForUpdate(ctx, tx, oldRole.Id) <= 1 {
+		panic(exception.NewBadRequestError("cannot demote the last admin"))
+	}
+
+	service.userRepository.UpdateRole(ctx, tx, user.Id, newRole.Id)
+
+	roleChange := RoleChange{
+		User_Id:       user.Id,
+		Admin_Id:      request.Admin_Id,
+		Old_Role_Id:   oldRole.Id,
+		Old_Role_Name: oldRole.Name,
+		New_Role_Id:   newRole.Id,
+		New_Role_Name: newRole.Name,
+	}
+
+	service.userRepository.SaveRoleChange(ctx, tx, roleChange)
+
+	updatedUser := service.userRepository.FindOne(ctx, tx, user.Id, "")
+
+	service.auditRepository.Save(ctx, tx, audit.NewEntry(ctx, audit.ActionUserRoleUpdate, audit.TargetUser, user.Id, ToUserAuditResponse(user), ToUserAuditResponse(updatedUser)))
+
+	return ToUserResponse(updatedUser)
+}
+
+func (service *UserServiceImpl) FindAllRoleChanges(ctx context.Context, userId, limit, offset int, isAdmin bool) ([]RoleChangeResponse, int) {
+	if !isAdmin {
+		panic(exception.NewBadRequestError("only admin can get role changes"))
+	}
+
+	tx, err := service.DB.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	roleChanges := service.userRepository.FindAllRoleChanges(ctx, tx, userId, limit, offset)
+	countRoleChanges := service.userRepository.CountRoleChanges(ctx, tx, userId)
+
+	var roleChangeResponses []RoleChangeResponse
+
+	for _, roleChange := range roleChanges {
+		roleChangeResponses = append(roleChangeResponses, ToRoleChangeResponse(roleChange))
+	}
+
+	return roleChangeResponses, countRoleChanges
+}
+
+func (service *UserServiceImpl) VerifyTokenIssuedAt(ctx context.Context, userId int, issuedAt time.Time) {
+	tx, err := service.DB.Begin()
+	helpers.PanicError(err, "failed to begin transaction")
+	defer helpers.TxRollbackCommit(tx)
+
+	status := service.userRepository.FindAccountStatus(ctx, tx, userId, "")
+
+	if status.Id <= 0 {
+		panic(exception.NewUnauthorizedError("user not found"))
+	}
+
+	if helpers.IsTokenRevoked(issuedAt, status.Revoked_At) {
+		panic(exception.NewUnauthorizedError("token has been revoked, please login again"))
+	}
 }
@@ -1,5 +1,7 @@
 package user
 
+import "time"
+
 type UserCreateRequest struct {
 	Username         string `json:"username" validate:"required,min=1,max=24"`
 	Email            string `json:"email" validate:"required,email"`
@@ -19,3 +21,19 @@ type UserUpdateRequest struct {
 	First_Name string `json:"first_name" validate:"required"`
 	Last_Name  string `json:"last_name" validate:"required"`
 }
+
+type UserRoleUpdateRequest struct {
+	User_Id  int `json:"user_id" validate:"required"`
+	Role_Id  int `json:"role_id" validate:"required"`
+	Admin_Id int `json:"admin_id" validate:"required"`
+}
+
+type UserFilterRequest struct {
+	Query        string
+	Role         string
+	Created_From time.Time
+	Created_To   time.Time
+	Deleted      string `validate:"omitempty,oneof=true false deactivated all"`
+	Sort         string `validate:"omitempty,oneof=followers posts joined"`
+	Order        string `validate:"omitempty,oneof=asc desc"`
+}
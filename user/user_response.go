@@ -34,6 +34,20 @@ func ToUserResponse(user UserJoin) UserResponse {
 	}
 }
 
+// UserAuditResponse is what the audit log keeps of a user. The log is
+// append-only and outlives erasure, so it holds no personal data.
+type UserAuditResponse struct {
+	Id      int `json:"id"`
+	Role_Id int `json:"role_id"`
+}
+
+func ToUserAuditResponse(user UserJoin) UserAuditResponse {
+	return UserAuditResponse{
+		Id:      user.Id,
+		Role_Id: user.Role_Id,
+	}
+}
+
 type UserCommentResponse struct {
 	Id       int    `json:"id"`
 	Username string `json:"username"`
@@ -63,3 +77,49 @@ func ToUserFollowResponse(user User) UserFollowResponse {
 		Last_Name:  user.Last_Name,
 	}
 }
+
+type UserProfileResponse struct {
+	Id         int       `json:"id"`
+	Username   string    `json:"username"`
+	First_Name string    `json:"first_name"`
+	Last_Name  string    `json:"last_name"`
+	Created_At time.Time `json:"created_at"`
+	Following  int       `json:"following"`
+	Follower   int       `json:"follower"`
+}
+
+func ToUserProfileResponse(user UserJoin) UserProfileResponse {
+	return UserProfileResponse{
+		Id:         user.Id,
+		Username:   user.Username,
+		First_Name: user.First_Name,
+		Last_Name:  user.Last_Name,
+		Created_At: user.Created_At,
+		Following:  user.Following,
+		Follower:   user.Follower,
+	}
+}
+
+type RoleChangeResponse struct {
+	Id            int       `json:"id"`
+	User_Id       int       `json:"user_id"`
+	Admin_Id      int       `json:"admin_id"`
+	Old_Role_Id   int       `json:"old_role_id"`
+	Old_Role_Name string    `json:"old_role_name"`
+	New_Role_Id   int       `json:"new_role_id"`
+	New_Role_Name string    `json:"new_role_name"`
+	Created_At    time.Time `json:"created_at"`
+}
+
+func ToRoleChangeResponse(roleChange RoleChange) RoleChangeResponse {
+	return RoleChangeResponse{
+		Id:            roleChange.Id,
+		User_Id:       roleChange.User_Id,
+		Admin_Id:      roleChange.Admin_Id,
+		Old_Role_Id:   roleChange.Old_Role_Id,
+		Old_Role_Name: roleChange.Old_Role_Name,
+		New_Role_Id:   roleChange.New_Role_Id,
+		New_Role_Name: roleChange.New_Role_Name,
+		Created_At:    roleChange.Created_At,
+	}
+}
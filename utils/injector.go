@@ -9,21 +9,35 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/google/wire"
 	"github.com/hutamatr/GoBlogify/admin"
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/bulk"
 	"github.com/hutamatr/GoBlogify/category"
 	"github.com/hutamatr/GoBlogify/comment"
+	"github.com/hutamatr/GoBlogify/contentfilter"
+	"github.com/hutamatr/GoBlogify/erasure"
+	"github.com/hutamatr/GoBlogify/export"
 	"github.com/hutamatr/GoBlogify/follow"
+	"github.com/hutamatr/GoBlogify/impersonation"
 	"github.com/hutamatr/GoBlogify/post"
+	"github.com/hutamatr/GoBlogify/reaction"
+	"github.com/hutamatr/GoBlogify/report"
+	"github.com/hutamatr/GoBlogify/revision"
 	"github.com/hutamatr/GoBlogify/role"
+	"github.com/hutamatr/GoBlogify/search"
+	"github.com/hutamatr/GoBlogify/spam"
+	"github.com/hutamatr/GoBlogify/stats"
+	"github.com/hutamatr/GoBlogify/suspension"
+	"github.com/hutamatr/GoBlogify/tag"
 	"github.com/hutamatr/GoBlogify/user"
 )
 
 func InitializedRoleController(db *sql.DB, validator *validator.Validate) role.RoleController {
-	wire.Build(role.NewRoleRepository, role.NewRoleService, role.NewRoleController)
+	wire.Build(role.NewRoleRepository, role.NewRoleService, role.NewRoleController, audit.NewAuditRepository)
 	return nil
 }
 
 func InitializedUserController(db *sql.DB, validator *validator.Validate) user.UserController {
-	wire.Build(user.NewUserRepository, user.NewUserService, user.NewUserController, role.NewRoleRepository)
+	wire.Build(user.NewUserRepository, user.NewUserService, user.NewUserController, role.NewRoleRepository, audit.NewAuditRepository)
 	return nil
 }
 
@@ -33,17 +47,32 @@ func InitializedAdminController(db *sql.DB, validator *validator.Validate) admin
 }
 
 func InitializedPostController(db *sql.DB, validator *validator.Validate) post.PostController {
-	wire.Build(post.NewPostRepository, post.NewPostService, post.NewPostController)
+	wire.Build(post.NewPostRepository, post.NewPostService, post.NewPostController, user.NewUserRepository, audit.NewAuditRepository, revision.NewRevisionRepository, tag.NewTagRepository, reaction.NewReactionRepository, contentfilter.NewFilterRuleRepository, contentfilter.NewDefaultPipeline)
+	return nil
+}
+
+func InitializedPostScheduler(db *sql.DB) post.PostScheduler {
+	wire.Build(post.NewPostRepository, user.NewUserRepository, contentfilter.NewFilterRuleRepository, contentfilter.NewDefaultPipeline, post.NewPostScheduler)
+	return nil
+}
+
+func InitializedAccountPurger(db *sql.DB) user.AccountPurger {
+	wire.Build(user.NewUserRepository, audit.NewAuditRepository, user.NewAccountPurger)
+	return nil
+}
+
+func InitializedExportCleaner(db *sql.DB) export.ExportCleaner {
+	wire.Build(export.NewExportRepository, export.NewExportCleaner)
 	return nil
 }
 
 func InitializedCommentController(db *sql.DB, validator *validator.Validate) comment.CommentController {
-	wire.Build(comment.NewCommentRepository, comment.NewCommentService, comment.NewCommentController)
+	wire.Build(comment.NewCommentRepository, post.NewPostRepository, comment.NewCommentService, comment.NewCommentController, contentfilter.NewFilterRuleRepository, contentfilter.NewDefaultPipeline, spam.NewSpamRepository, spam.NewNaiveBayesScorer, audit.NewAuditRepository)
 	return nil
 }
 
 func InitializedCategoryController(db *sql.DB, validator *validator.Validate) category.CategoryController {
-	wire.Build(category.NewCategoryRepository, category.NewCategoryService, category.NewCategoryController)
+	wire.Build(category.NewCategoryRepository, category.NewCategoryService, category.NewCategoryController, audit.NewAuditRepository)
 	return nil
 }
 
@@ -51,3 +80,63 @@ func InitializedFollowController(db *sql.DB) follow.FollowController {
 	wire.Build(follow.NewFollowRepository, follow.NewFollowService, follow.NewFollowController)
 	return nil
 }
+
+func InitializedExportController(db *sql.DB) export.ExportController {
+	wire.Build(export.NewExportRepository, export.NewExportService, export.NewExportController)
+	return nil
+}
+
+func InitializedErasureController(db *sql.DB) erasure.ErasureController {
+	wire.Build(erasure.NewErasureRepository, erasure.NewErasureService, erasure.NewErasureController)
+	return nil
+}
+
+func InitializedSuspensionController(db *sql.DB, validator *validator.Validate) suspension.SuspensionController {
+	wire.Build(suspension.NewSuspensionRepository, suspension.NewSuspensionService, suspension.NewSuspensionController, audit.NewAuditRepository)
+	return nil
+}
+
+func InitializedAuditController(db *sql.DB) audit.AuditController {
+	wire.Build(audit.NewAuditRepository, audit.NewAuditService, audit.NewAuditController)
+	return nil
+}
+
+func InitializedStatsController(db *sql.DB, validator *validator.Validate) stats.StatsController {
+	wire.Build(stats.NewStatsRepository, stats.NewStatsService, stats.NewStatsController)
+	return nil
+}
+
+func InitializedReportController(db *sql.DB, validator *validator.Validate) report.ReportController {
+	wire.Build(report.NewReportRepository, post.NewPostRepository, report.NewReportService, report.NewReportController, suspension.NewSuspensionRepository, audit.NewAuditRepository)
+	return nil
+}
+
+func InitializedFilterRuleController(db *sql.DB, validator *validator.Validate) contentfilter.FilterRuleController {
+	wire.Build(contentfilter.NewFilterRuleRepository, contentfilter.NewFilterRuleService, contentfilter.NewFilterRuleController, audit.NewAuditRepository)
+	return nil
+}
+
+func InitializedBulkController(db *sql.DB, validator *validator.Validate) bulk.BulkController {
+	wire.Build(bulk.NewBulkRepository, bulk.NewBulkService, bulk.NewBulkController, user.NewUserRepository, suspension.NewSuspensionRepository, audit.NewAuditRepository)
+	return nil
+}
+
+func InitializedImpersonationController(db *sql.DB, validator *validator.Validate) impersonation.ImpersonationController {
+	wire.Build(impersonation.NewImpersonationRepository, impersonation.NewImpersonationService, impersonation.NewImpersonationController, audit.NewAuditRepository)
+	return nil
+}
+
+func InitializedRevisionPolicyController(db *sql.DB, validator *validator.Validate) revision.RevisionPolicyController {
+	wire.Build(revision.NewRevisionRepository, revision.NewRevisionPolicyService, revision.NewRevisionPolicyController, audit.NewAuditRepository)
+	return nil
+}
+
+func InitializedTagController(db *sql.DB, validator *validator.Validate) tag.TagController {
+	wire.Build(tag.NewTagRepository, tag.NewTagService, tag.NewTagController, audit.NewAuditRepository)
+	return nil
+}
+
+func InitializedSearchController(db *sql.DB, validator *validator.Validate) search.SearchController {
+	wire.Build(search.NewMySQLPostSearchBackend, search.NewSearchService, search.NewSearchController)
+	return nil
+}
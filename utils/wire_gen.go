@@ -10,11 +10,25 @@ import (
 	"database/sql"
 	"github.com/go-playground/validator/v10"
 	"github.com/hutamatr/GoBlogify/admin"
+	"github.com/hutamatr/GoBlogify/audit"
+	"github.com/hutamatr/GoBlogify/bulk"
 	"github.com/hutamatr/GoBlogify/category"
 	"github.com/hutamatr/GoBlogify/comment"
+	"github.com/hutamatr/GoBlogify/contentfilter"
+	"github.com/hutamatr/GoBlogify/erasure"
+	"github.com/hutamatr/GoBlogify/export"
 	"github.com/hutamatr/GoBlogify/follow"
+	"github.com/hutamatr/GoBlogify/impersonation"
 	"github.com/hutamatr/GoBlogify/post"
+	"github.com/hutamatr/GoBlogify/reaction"
+	"github.com/hutamatr/GoBlogify/report"
+	"github.com/hutamatr/GoBlogify/revision"
 	"github.com/hutamatr/GoBlogify/role"
+	"github.com/hutamatr/GoBlogify/search"
+	"github.com/hutamatr/GoBlogify/spam"
+	"github.com/hutamatr/GoBlogify/stats"
+	"github.com/hutamatr/GoBlogify/suspension"
+	"github.com/hutamatr/GoBlogify/tag"
 	"github.com/hutamatr/GoBlogify/user"
 )
 
@@ -22,7 +36,8 @@ import (
 
 func InitializedRoleController(db *sql.DB, validator2 *validator.Validate) role.RoleController {
 	roleRepository := role.NewRoleRepository()
-	roleService := role.NewRoleService(roleRepository, db, validator2)
+	auditRepository := audit.NewAuditRepository()
+	roleService := role.NewRoleService(roleRepository, auditRepository, db, validator2)
 	roleController := role.NewRoleController(roleService)
 	return roleController
 }
@@ -30,7 +45,8 @@ func InitializedRoleController(db *sql.DB, validator2 *validator.Validate) role.
 func InitializedUserController(db *sql.DB, validator2 *validator.Validate) user.UserController {
 	userRepository := user.NewUserRepository()
 	roleRepository := role.NewRoleRepository()
-	userService := user.NewUserService(userRepository, roleRepository, db, validator2)
+	auditRepository := audit.NewAuditRepository()
+	userService := user.NewUserService(userRepository, roleRepository, auditRepository, db, validator2)
 	userController := user.NewUserController(userService)
 	return userController
 }
@@ -45,21 +61,57 @@ func InitializedAdminController(db *sql.DB, validator2 *validator.Validate) admi
 
 func InitializedPostController(db *sql.DB, validator2 *validator.Validate) post.PostController {
 	postRepository := post.NewPostRepository()
-	postService := post.NewPostService(postRepository, db, validator2)
+	userRepository := user.NewUserRepository()
+	auditRepository := audit.NewAuditRepository()
+	revisionRepository := revision.NewRevisionRepository()
+	tagRepository := tag.NewTagRepository()
+	reactionRepository := reaction.NewReactionRepository()
+	filterRuleRepository := contentfilter.NewFilterRuleRepository()
+	pipeline := contentfilter.NewDefaultPipeline(filterRuleRepository)
+	postService := post.NewPostService(postRepository, userRepository, auditRepository, revisionRepository, tagRepository, reactionRepository, pipeline, db, validator2)
 	postController := post.NewPostController(postService)
 	return postController
 }
 
+func InitializedPostScheduler(db *sql.DB) post.PostScheduler {
+	postRepository := post.NewPostRepository()
+	userRepository := user.NewUserRepository()
+	filterRuleRepository := contentfilter.NewFilterRuleRepository()
+	pipeline := contentfilter.NewDefaultPipeline(filterRuleRepository)
+	postScheduler := post.NewPostScheduler(postRepository, userRepository, pipeline, db)
+	return postScheduler
+}
+
+func InitializedAccountPurger(db *sql.DB) user.AccountPurger {
+	userRepository := user.NewUserRepository()
+	auditRepository := audit.NewAuditRepository()
+	accountPurger := user.NewAccountPurger(userRepository, auditRepository, db)
+	return accountPurger
+}
+
+func InitializedExportCleaner(db *sql.DB) export.ExportCleaner {
+	exportRepository := export.NewExportRepository()
+	exportCleaner := export.NewExportCleaner(exportRepository, db)
+	return exportCleaner
+}
+
 func InitializedCommentController(db *sql.DB, validator2 *validator.Validate) comment.CommentController {
 	commentRepository := comment.NewCommentRepository()
-	commentService := comment.NewCommentService(commentRepository, db, validator2)
+	postRepository := post.NewPostRepository()
+	filterRuleRepository := contentfilter.NewFilterRuleRepository()
+	pipeline := contentfilter.NewDefaultPipeline(filterRuleRepository)
+	spamRepository := spam.NewSpamRepository()
+	spamScorer := spam.NewNaiveBayesScorer(spamRepository)
+	auditRepository := audit.NewAuditRepository()
+	commentService := comment.NewCommentService(commentRepository, postRepository, pipeline, spamScorer, auditRepository, db, validator2)
 	commentController := comment.NewCommentController(commentService)
 	return commentController
 }
 
 func InitializedCategoryController(db *sql.DB, validator2 *validator.Validate) category.CategoryController {
 	categoryRepository := category.NewCategoryRepository()
-	categoryService := category.NewCategoryService(categoryRepository, db, validator2)
+	auditRepository := audit.NewAuditRepository()
+	categoryService := category.NewCategoryService(categoryRepository, auditRepository, db, validator2)
 	categoryController := category.NewCategoryController(categoryService)
 	return categoryController
 }
@@ -70,3 +122,98 @@ func InitializedFollowController(db *sql.DB) follow.FollowController {
 	followController := follow.NewFollowController(followService)
 	return followController
 }
+
+func InitializedExportController(db *sql.DB) export.ExportController {
+	exportRepository := export.NewExportRepository()
+	exportService := export.NewExportService(exportRepository, db)
+	exportController := export.NewExportController(exportService)
+	return exportController
+}
+
+func InitializedErasureController(db *sql.DB) erasure.ErasureController {
+	erasureRepository := erasure.NewErasureRepository()
+	erasureService := erasure.NewErasureService(erasureRepository, db)
+	erasureController := erasure.NewErasureController(erasureService)
+	return erasureController
+}
+
+func InitializedSuspensionController(db *sql.DB, validator2 *validator.Validate) suspension.SuspensionController {
+	suspensionRepository := suspension.NewSuspensionRepository()
+	auditRepository := audit.NewAuditRepository()
+	suspensionService := suspension.NewSuspensionService(suspensionRepository, auditRepository, db, validator2)
+	suspensionController := suspension.NewSuspensionController(suspensionService)
+	return suspensionController
+}
+
+func InitializedAuditController(db *sql.DB) audit.AuditController {
+	auditRepository := audit.NewAuditRepository()
+	auditService := audit.NewAuditService(auditRepository, db)
+	auditController := audit.NewAuditController(auditService)
+	return auditController
+}
+
+func InitializedStatsController(db *sql.DB, validator2 *validator.Validate) stats.StatsController {
+	statsRepository := stats.NewStatsRepository()
+	statsService := stats.NewStatsService(statsRepository, db, validator2)
+	statsController := stats.NewStatsController(statsService)
+	return statsController
+}
+
+func InitializedReportController(db *sql.DB, validator2 *validator.Validate) report.ReportController {
+	reportRepository := report.NewReportRepository()
+	postRepository := post.NewPostRepository()
+	suspensionRepository := suspension.NewSuspensionRepository()
+	auditRepository := audit.NewAuditRepository()
+	reportService := report.NewReportService(reportRepository, postRepository, suspensionRepository, auditRepository, db, validator2)
+	reportController := report.NewReportController(reportService)
+	return reportController
+}
+
+func InitializedFilterRuleController(db *sql.DB, validator2 *validator.Validate) contentfilter.FilterRuleController {
+	filterRuleRepository := contentfilter.NewFilterRuleRepository()
+	auditRepository := audit.NewAuditRepository()
+	filterRuleService := contentfilter.NewFilterRuleService(filterRuleRepository, auditRepository, db, validator2)
+	filterRuleController := contentfilter.NewFilterRuleController(filterRuleService)
+	return filterRuleController
+}
+
+func InitializedBulkController(db *sql.DB, validator2 *validator.Validate) bulk.BulkController {
+	bulkRepository := bulk.NewBulkRepository()
+	userRepository := user.NewUserRepository()
+	suspensionRepository := suspension.NewSuspensionRepository()
+	auditRepository := audit.NewAuditRepository()
+	bulkService := bulk.NewBulkService(bulkRepository, userRepository, suspensionRepository, auditRepository, db, validator2)
+	bulkController := bulk.NewBulkController(bulkService)
+	return bulkController
+}
+
+func InitializedImpersonationController(db *sql.DB, validator2 *validator.Validate) impersonation.ImpersonationController {
+	impersonationRepository := impersonation.NewImpersonationRepository()
+	auditRepository := audit.NewAuditRepository()
+	impersonationService := impersonation.NewImpersonationService(impersonationRepository, auditRepository, db, validator2)
+	impersonationController := impersonation.NewImpersonationController(impersonationService)
+	return impersonationController
+}
+
+func InitializedRevisionPolicyController(db *sql.DB, validator2 *validator.Validate) revision.RevisionPolicyController {
+	revisionRepository := revision.NewRevisionRepository()
+	auditRepository := audit.NewAuditRepository()
+	revisionPolicyService := revision.NewRevisionPolicyService(revisionRepository, auditRepository, db, validator2)
+	revisionPolicyController := revision.NewRevisionPolicyController(revisionPolicyService)
+	return revisionPolicyController
+}
+
+func InitializedTagController(db *sql.DB, validator2 *validator.Validate) tag.TagController {
+	tagRepository := tag.NewTagRepository()
+	auditRepository := audit.NewAuditRepository()
+	tagService := tag.NewTagService(tagRepository, auditRepository, db, validator2)
+	tagController := tag.NewTagController(tagService)
+	return tagController
+}
+
+func InitializedSearchController(db *sql.DB, validator2 *validator.Validate) search.SearchController {
+	postSearchBackend := search.NewMySQLPostSearchBackend(db)
+	searchService := search.NewSearchService(postSearchBackend, validator2)
+	searchController := search.NewSearchController(searchService)
+	return searchController
+}